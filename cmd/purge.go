@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/formancehq/auth/pkg/storage/sqlstorage"
+	"github.com/formancehq/go-libs/v3/aws/iam"
+	"github.com/formancehq/go-libs/v3/bun/bunconnect"
+	"github.com/spf13/cobra"
+)
+
+const (
+	PurgeIntervalFlag        = "purge-interval"
+	PurgeBatchSizeFlag       = "purge-batch-size"
+	AuthRequestRetentionFlag = "auth-request-retention"
+)
+
+func addPurgeFlags(cmd *cobra.Command) {
+	cmd.Flags().Int(PurgeBatchSizeFlag, sqlstorage.DefaultPurgeBatchSize, "Maximum number of rows deleted by a single purge query")
+	cmd.Flags().Duration(AuthRequestRetentionFlag, sqlstorage.DefaultAuthRequestRetention, "Delay after which an auth request is purged")
+}
+
+// purgeConfigFromFlags reads the flags shared by the purge and serve commands, the interval is only defined by serve
+func purgeConfigFromFlags(cmd *cobra.Command) sqlstorage.PurgeConfig {
+	config := sqlstorage.PurgeConfig{}
+	config.BatchSize, _ = cmd.Flags().GetInt(PurgeBatchSizeFlag)
+	config.AuthRequestRetention, _ = cmd.Flags().GetDuration(AuthRequestRetentionFlag)
+	return config
+}
+
+func newPurgeCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "purge",
+		Short: "Delete expired tokens, stale auth requests and expired device authorizations",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			purgeConfig := purgeConfigFromFlags(cmd)
+			if err := purgeConfig.Validate(); err != nil {
+				return err
+			}
+
+			connectionOptions, err := bunconnect.ConnectionOptionsFromFlags(cmd)
+			if err != nil {
+				return err
+			}
+
+			db, err := bunconnect.OpenSQLDB(cmd.Context(), *connectionOptions)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				_ = db.Close()
+			}()
+
+			purger, err := sqlstorage.NewPurger(db, nil, purgeConfig)
+			if err != nil {
+				return err
+			}
+
+			result, err := purger.Purge(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			_, err = fmt.Fprintf(cmd.OutOrStdout(), "Deleted %d access tokens, %d refresh tokens, %d auth requests and %d device authorizations\n",
+				result.AccessTokens, result.RefreshTokens, result.AuthRequests, result.DeviceAuthorizations)
+			return err
+		},
+	}
+
+	addPurgeFlags(cmd)
+	bunconnect.AddFlags(cmd.Flags())
+	iam.AddFlags(cmd.Flags())
+
+	return cmd
+}
@@ -25,6 +25,7 @@ func NewRootCommand() *cobra.Command {
 	cmd.AddCommand(
 		newServeCommand(),
 		newVersionCommand(),
+		newPurgeCommand(),
 		bunmigrate.NewDefaultCommand(func(cmd *cobra.Command, args []string, db *bun.DB) error {
 			return sqlstorage.Migrate(cmd.Context(), db)
 		}))
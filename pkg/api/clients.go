@@ -3,6 +3,7 @@ package api
 import (
 	authlib "github.com/formancehq/go-libs/v3/auth"
 	"net/http"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 
@@ -23,6 +24,7 @@ func addClientRoutes(db *bun.DB, r chi.Router, authenticator authlib.Authenticat
 			r.Route("/secrets", func(r chi.Router) {
 				r.Post("/", createSecret(db))
 				r.Delete("/{secretId}", deleteSecret(db))
+				r.Post("/{secretId}/rotate", rotateSecret(db))
 			})
 		})
 	})
@@ -42,14 +44,25 @@ type clientView struct {
 func mapBusinessClient(c auth.Client) clientView {
 	return clientView{
 		ClientOptions: auth.ClientOptions{
-			Public:                 c.Public,
-			RedirectURIs:           c.RedirectURIs,
-			Description:            c.Description,
-			Name:                   c.Name,
-			PostLogoutRedirectUris: c.PostLogoutRedirectUris,
-			Metadata:               c.Metadata,
-			Scopes:                 c.Scopes,
-			Trusted:                c.Trusted,
+			Public:                   c.Public,
+			RedirectURIs:             c.RedirectURIs,
+			Description:              c.Description,
+			Name:                     c.Name,
+			PostLogoutRedirectUris:   c.PostLogoutRedirectUris,
+			Metadata:                 c.Metadata,
+			Scopes:                   c.Scopes,
+			Trusted:                  c.Trusted,
+			AccessTokenLifetime:      c.AccessTokenLifetime,
+			IDTokenLifetime:          c.IDTokenLifetime,
+			RefreshTokenLifetime:     c.RefreshTokenLifetime,
+			RefreshTokenIdleLifetime: c.RefreshTokenIdleLifetime,
+			JWKS:                     c.JWKS,
+			JWKSURI:                  c.JWKSURI,
+			TLSClientAuth:            c.TLSClientAuth,
+			RequireDPoP:              c.RequireDPoP,
+			GrantTypes:               c.GrantTypes,
+			TokenExchange:            c.TokenExchange,
+			RequirePAR:               c.RequirePAR,
 		},
 		ID: c.Id,
 		Secrets: mapList(c.Secrets, func(i auth.ClientSecret) clientSecretView {
@@ -61,10 +74,21 @@ func mapBusinessClient(c auth.Client) clientView {
 }
 
 type secretCreateResult struct {
-	ID         string `json:"id"`
-	LastDigits string `json:"lastDigits"`
-	Name       string `json:"name"`
-	Clear      string `json:"clear"`
+	ID         string     `json:"id"`
+	LastDigits string     `json:"lastDigits"`
+	Name       string     `json:"name"`
+	ExpiresAt  *time.Time `json:"expiresAt,omitempty"`
+	Clear      string     `json:"clear"`
+}
+
+func mapSecretCreateResult(secret auth.ClientSecret, clear string) secretCreateResult {
+	return secretCreateResult{
+		ID:         secret.ID,
+		LastDigits: secret.LastDigits,
+		Name:       secret.Name,
+		ExpiresAt:  secret.ExpiresAt,
+		Clear:      clear,
+	}
 }
 
 func deleteSecret(db *bun.DB) http.HandlerFunc {
@@ -114,12 +138,42 @@ func createSecret(db *bun.DB) http.HandlerFunc {
 			return
 		}
 
-		writeJSONObject(w, r, secretCreateResult{
-			ID:         secret.ID,
-			LastDigits: secret.LastDigits,
-			Name:       secret.Name,
-			Clear:      clear,
-		})
+		writeJSONObject(w, r, mapSecretCreateResult(secret, clear))
+	}
+}
+
+func rotateSecret(db *bun.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		client := findById[*auth.Client](w, r, db, "clientId")
+		if client == nil {
+			return
+		}
+
+		sr := readJSONObject[auth.SecretRotate](w, r)
+		if sr == nil {
+			return
+		}
+		if err := sr.Validate(); err != nil {
+			validationError(w, r, err)
+			return
+		}
+
+		secret, clear, ok := client.RotateSecret(chi.URLParam(r, "secretId"), *sr, time.Now())
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		_, err := db.NewUpdate().
+			Model(client).
+			Where("id = ?", client.Id).
+			Exec(r.Context())
+		if err != nil {
+			internalServerError(w, r, err)
+			return
+		}
+
+		writeJSONObject(w, r, mapSecretCreateResult(secret, clear))
 	}
 }
 
@@ -174,6 +228,13 @@ func updateClient(db *bun.DB) http.HandlerFunc {
 		if opts == nil {
 			return
 		}
+		if err := opts.Validate(); err != nil {
+			validationError(w, r, err)
+			return
+		}
+		if !checkScopes(w, r, db, opts.Scopes) {
+			return
+		}
 
 		client.Update(*opts)
 
@@ -196,6 +257,13 @@ func createClient(db *bun.DB) http.HandlerFunc {
 		if opts == nil {
 			return
 		}
+		if err := opts.Validate(); err != nil {
+			validationError(w, r, err)
+			return
+		}
+		if !checkScopes(w, r, db, opts.Scopes) {
+			return
+		}
 
 		c := auth.NewClient(*opts)
 		if err := createObject(w, r, db, c); err != nil {
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 
@@ -40,6 +41,12 @@ func withDbAndClientRouter(t *testing.T, callback func(router chi.Router, db *bu
 
 	require.NoError(t, sqlstorage.Migrate(context.Background(), db))
 
+	// The scopes of the clients must be registered
+	_, err = db.NewInsert().Model(auth.NewScope(auth.ScopeOptions{
+		Name: "formance:test",
+	})).Exec(context.Background())
+	require.NoError(t, err)
+
 	router := chi.NewRouter()
 	addClientRoutes(db, router, authlib.NewNoAuth())
 
@@ -91,6 +98,104 @@ func TestCreateClient(t *testing.T) {
 				Scopes: []string{"ledger:read", "ledger:write", "formance:test"},
 			},
 		},
+		{
+			name: "client with scope patterns",
+			options: auth.ClientOptions{
+				Name:                   "client with scope patterns",
+				RedirectURIs:           []string{},
+				PostLogoutRedirectUris: []string{},
+				Metadata:               map[string]string{},
+				Scopes:                 []string{"ledger:*", "*:read"},
+			},
+		},
+		{
+			name: "client with token lifetimes",
+			options: auth.ClientOptions{
+				Name:                     "client with token lifetimes",
+				RedirectURIs:             []string{},
+				PostLogoutRedirectUris:   []string{},
+				Metadata:                 map[string]string{},
+				Scopes:                   []string{},
+				AccessTokenLifetime:      300,
+				IDTokenLifetime:          600,
+				RefreshTokenLifetime:     86400,
+				RefreshTokenIdleLifetime: 3600,
+			},
+		},
+		{
+			name: "client with jwks uri",
+			options: auth.ClientOptions{
+				Name:                   "client with jwks uri",
+				RedirectURIs:           []string{},
+				PostLogoutRedirectUris: []string{},
+				Metadata:               map[string]string{},
+				Scopes:                 []string{},
+				JWKSURI:                "https://example.com/.well-known/jwks.json",
+			},
+		},
+		{
+			name: "client with tls client auth",
+			options: auth.ClientOptions{
+				Name:                   "client with tls client auth",
+				RedirectURIs:           []string{},
+				PostLogoutRedirectUris: []string{},
+				Metadata:               map[string]string{},
+				Scopes:                 []string{},
+				TLSClientAuth: &auth.TLSClientAuth{
+					SubjectDN: "CN=client,O=Formance",
+				},
+			},
+		},
+		{
+			name: "public client requiring dpop",
+			options: auth.ClientOptions{
+				Name:                   "public client requiring dpop",
+				Public:                 true,
+				RedirectURIs:           []string{},
+				PostLogoutRedirectUris: []string{},
+				Metadata:               map[string]string{},
+				Scopes:                 []string{},
+				RequireDPoP:            true,
+			},
+		},
+		{
+			name: "client with device authorization grant",
+			options: auth.ClientOptions{
+				Name:                   "client with device authorization grant",
+				Public:                 true,
+				RedirectURIs:           []string{},
+				PostLogoutRedirectUris: []string{},
+				Metadata:               map[string]string{},
+				Scopes:                 []string{},
+				GrantTypes:             []string{"urn:ietf:params:oauth:grant-type:device_code"},
+			},
+		},
+		{
+			name: "client with token exchange",
+			options: auth.ClientOptions{
+				Name:                   "client with token exchange",
+				RedirectURIs:           []string{},
+				PostLogoutRedirectUris: []string{},
+				Metadata:               map[string]string{},
+				Scopes:                 []string{},
+				TokenExchange: &auth.TokenExchangePolicy{
+					SubjectTokenIssuers: []string{"https://accounts.example.com"},
+					Audiences:           []string{"ledger"},
+					Scopes:              []string{"ledger:read"},
+				},
+			},
+		},
+		{
+			name: "client requiring pushed authorization requests",
+			options: auth.ClientOptions{
+				Name:                   "client requiring pushed authorization requests",
+				RedirectURIs:           []string{},
+				PostLogoutRedirectUris: []string{},
+				Metadata:               map[string]string{},
+				Scopes:                 []string{},
+				RequirePAR:             true,
+			},
+		},
 	} {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
@@ -179,6 +284,20 @@ func TestUpdateClient(t *testing.T) {
 				Scopes: []string{"ledger:read", "ledger:write", "formance:test"},
 			},
 		},
+		{
+			name: "client with token lifetimes",
+			options: auth.ClientOptions{
+				Name:                     "client with token lifetimes",
+				RedirectURIs:             []string{},
+				PostLogoutRedirectUris:   []string{},
+				Metadata:                 map[string]string{},
+				Scopes:                   []string{},
+				AccessTokenLifetime:      300,
+				IDTokenLifetime:          600,
+				RefreshTokenLifetime:     86400,
+				RefreshTokenIdleLifetime: 3600,
+			},
+		},
 	} {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
@@ -356,3 +475,103 @@ func TestDeleteSecret(t *testing.T) {
 		require.Len(t, client.Secrets, 0)
 	})
 }
+
+func TestRotateSecret(t *testing.T) {
+	withDbAndClientRouter(t, func(router chi.Router, db *bun.DB) {
+		client := auth.NewClient(auth.ClientOptions{})
+		secret, clear := client.GenerateNewSecret(auth.SecretCreate{
+			Name: "testing",
+		})
+		_, err := db.NewInsert().Model(client).Exec(context.Background())
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/clients/"+client.Id+"/secrets/"+secret.ID+"/rotate", createJSONBuffer(t, auth.SecretRotate{
+			SecretCreate: auth.SecretCreate{
+				Name: "rotated",
+			},
+			GracePeriod: 60,
+		}))
+		res := httptest.NewRecorder()
+
+		router.ServeHTTP(res, req)
+
+		require.Equal(t, http.StatusOK, res.Code)
+		result := readTestResponse[secretCreateResult](t, res)
+		require.NotEmpty(t, result.Clear)
+		require.Equal(t, "rotated", result.Name)
+
+		err = db.NewSelect().
+			Model(client).
+			Where("id = ?", client.Id).
+			Scan(context.Background())
+		require.NoError(t, err)
+		require.Len(t, client.Secrets, 2)
+		require.NotNil(t, client.Secrets[0].ExpiresAt)
+		require.WithinDuration(t, time.Now().Add(time.Minute), *client.Secrets[0].ExpiresAt, 5*time.Second)
+		require.True(t, client.HasSecret(clear))
+		require.True(t, client.HasSecret(result.Clear))
+
+		req = httptest.NewRequest(http.MethodPost, "/clients/"+client.Id+"/secrets/unknown/rotate", createJSONBuffer(t, auth.SecretRotate{}))
+		res = httptest.NewRecorder()
+
+		router.ServeHTTP(res, req)
+
+		require.Equal(t, http.StatusNotFound, res.Code)
+
+		req = httptest.NewRequest(http.MethodPost, "/clients/"+client.Id+"/secrets/"+result.ID+"/rotate", createJSONBuffer(t, auth.SecretRotate{
+			GracePeriod: -1,
+		}))
+		res = httptest.NewRecorder()
+
+		router.ServeHTTP(res, req)
+
+		require.Equal(t, http.StatusBadRequest, res.Code)
+	})
+}
+
+func TestCreateClientWithInvalidJWKS(t *testing.T) {
+	withDbAndClientRouter(t, func(router chi.Router, db *bun.DB) {
+		for _, options := range []auth.ClientOptions{
+			{JWKSURI: "not an url"},
+			{
+				JWKS:    &auth.JSONWebKeySet{},
+				JWKSURI: "https://example.com/.well-known/jwks.json",
+			},
+			{
+				TLSClientAuth: &auth.TLSClientAuth{},
+			},
+			{
+				TLSClientAuth: &auth.TLSClientAuth{SelfSigned: true},
+			},
+			{
+				Public:        true,
+				TokenExchange: &auth.TokenExchangePolicy{},
+			},
+			{AccessTokenLifetime: -1},
+			{IDTokenLifetime: -1},
+			{RefreshTokenLifetime: -1},
+			{RefreshTokenIdleLifetime: -1},
+		} {
+			req := httptest.NewRequest(http.MethodPost, "/clients", createJSONBuffer(t, options))
+			res := httptest.NewRecorder()
+
+			router.ServeHTTP(res, req)
+
+			require.Equal(t, http.StatusBadRequest, res.Code)
+		}
+	})
+}
+
+func TestCreateClientWithUnknownScopes(t *testing.T) {
+	withDbAndClientRouter(t, func(router chi.Router, db *bun.DB) {
+		req := httptest.NewRequest(http.MethodPost, "/clients", createJSONBuffer(t, auth.ClientOptions{
+			Name:   "client",
+			Scopes: []string{"openid", "ledger:read", "unknown:read"},
+		}))
+		res := httptest.NewRecorder()
+
+		router.ServeHTTP(res, req)
+
+		require.Equal(t, http.StatusBadRequest, res.Code)
+	})
+}
@@ -0,0 +1,227 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	authlib "github.com/formancehq/go-libs/v3/auth"
+	"github.com/formancehq/go-libs/v3/collectionutils"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/uptrace/bun"
+
+	auth "github.com/formancehq/auth/pkg"
+)
+
+func addGroupRoutes(db *bun.DB, r chi.Router, authenticator authlib.Authenticator) {
+	r.With(authlib.Middleware(authenticator)).Route("/groups", func(r chi.Router) {
+		r.Post("/", createGroup(db))
+		r.Get("/", listGroups(db))
+		r.Route("/{groupId}", func(r chi.Router) {
+			r.Put("/", updateGroup(db))
+			r.Delete("/", deleteGroup(db))
+			r.Get("/", readGroup(db))
+			r.Route("/members", func(r chi.Router) {
+				r.Get("/", listGroupMembers(db))
+				r.Put("/{userId}", addGroupMember(db))
+				r.Delete("/{userId}", removeGroupMember(db))
+			})
+		})
+	})
+}
+
+func readGroup(db *bun.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		group := findById[*auth.Group](w, r, db, "groupId")
+		if group == nil {
+			return
+		}
+		writeJSONObject(w, r, group)
+	}
+}
+
+// deleteGroup deletes a group and its memberships
+func deleteGroup(db *bun.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		groupID := chi.URLParam(r, "groupId")
+		err := db.RunInTx(r.Context(), nil, func(ctx context.Context, tx bun.Tx) error {
+			if _, err := tx.NewDelete().
+				Model(&auth.Group{}).
+				Where("id = ?", groupID).
+				Exec(ctx); err != nil {
+				return err
+			}
+			_, err := tx.NewDelete().
+				Model((*auth.GroupMember)(nil)).
+				Where("group_id = ?", groupID).
+				Exec(ctx)
+			return err
+		})
+		if err != nil {
+			internalServerError(w, r, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func listGroups(db *bun.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		groups := make([]auth.Group, 0)
+		if err := db.
+			NewSelect().
+			Model(&groups).
+			Order("name").
+			Scan(r.Context()); err != nil {
+			internalServerError(w, r, err)
+			return
+		}
+		writeJSONObject(w, r, groups)
+	}
+}
+
+func updateGroup(db *bun.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		group := findById[*auth.Group](w, r, db, "groupId")
+		if group == nil {
+			return
+		}
+
+		opts := readJSONObject[auth.GroupOptions](w, r)
+		if opts == nil {
+			return
+		}
+		if err := opts.Validate(); err != nil {
+			validationError(w, r, err)
+			return
+		}
+		if !checkRoles(w, r, db, opts.Roles) {
+			return
+		}
+
+		group.Update(*opts)
+
+		_, err := db.NewUpdate().
+			Model(group).
+			Where("id = ?", group.ID).
+			Exec(r.Context())
+		if err != nil {
+			internalServerError(w, r, err)
+			return
+		}
+
+		writeJSONObject(w, r, group)
+	}
+}
+
+func createGroup(db *bun.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		opts := readJSONObject[auth.GroupOptions](w, r)
+		if opts == nil {
+			return
+		}
+		if err := opts.Validate(); err != nil {
+			validationError(w, r, err)
+			return
+		}
+		if !checkRoles(w, r, db, opts.Roles) {
+			return
+		}
+
+		group := auth.NewGroup(*opts)
+		if err := createObject(w, r, db, group); err != nil {
+			return
+		}
+
+		writeCreatedJSONObject(w, r, group, group.ID)
+	}
+}
+
+func listGroupMembers(db *bun.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		group := findById[*auth.Group](w, r, db, "groupId")
+		if group == nil {
+			return
+		}
+
+		users := make([]auth.User, 0)
+		if err := db.
+			NewSelect().
+			Model(&users).
+			Join("JOIN group_members ON group_members.user_id = ?TableAlias.id").
+			Where("group_members.group_id = ?", group.ID).
+			Scan(r.Context()); err != nil {
+			internalServerError(w, r, err)
+			return
+		}
+		writeJSONObject(w, r, users)
+	}
+}
+
+// addGroupMember adds a user to a group, a membership synchronized from the upstream identity provider is no longer synchronized
+func addGroupMember(db *bun.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		group := findById[*auth.Group](w, r, db, "groupId")
+		if group == nil {
+			return
+		}
+		user := findById[*auth.User](w, r, db, "userId")
+		if user == nil {
+			return
+		}
+
+		_, err := db.NewInsert().
+			Model(&auth.GroupMember{
+				GroupID: group.ID,
+				UserID:  user.ID,
+			}).
+			On("CONFLICT (group_id, user_id) DO UPDATE").
+			Set("synchronized = false").
+			Exec(r.Context())
+		if err != nil {
+			internalServerError(w, r, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func removeGroupMember(db *bun.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_, err := db.NewDelete().
+			Model((*auth.GroupMember)(nil)).
+			Where("group_id = ?", chi.URLParam(r, "groupId")).
+			Where("user_id = ?", chi.URLParam(r, "userId")).
+			Exec(r.Context())
+		if err != nil {
+			internalServerError(w, r, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// checkRoles writes a validation error if some roles do not exist
+func checkRoles(w http.ResponseWriter, r *http.Request, db *bun.DB, roles []string) bool {
+	if len(roles) == 0 {
+		return true
+	}
+	existing := make([]string, 0)
+	if err := db.
+		NewSelect().
+		Model((*auth.Role)(nil)).
+		Column("id").
+		Where("id IN (?)", bun.In(roles)).
+		Scan(r.Context(), &existing); err != nil {
+		internalServerError(w, r, err)
+		return false
+	}
+	for _, role := range roles {
+		if !collectionutils.Contains(existing, role) {
+			validationError(w, r, fmt.Errorf("unknown role '%s'", role))
+			return false
+		}
+	}
+	return true
+}
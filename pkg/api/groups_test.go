@@ -0,0 +1,187 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/uptrace/bun"
+
+	auth "github.com/formancehq/auth/pkg"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateGroup(t *testing.T) {
+	withDbAndRouter(t, addGroupRoutes, func(router chi.Router, db *bun.DB) {
+		role := auth.NewRole(auth.RoleOptions{
+			Name:   "operator",
+			Scopes: []string{"ledger:*"},
+		})
+		_, err := db.NewInsert().Model(role).Exec(context.Background())
+		require.NoError(t, err)
+
+		opts := auth.GroupOptions{
+			Name:        "operators",
+			Description: "Full access",
+			Roles:       []string{role.ID},
+		}
+
+		req := httptest.NewRequest(http.MethodPost, "/groups", createJSONBuffer(t, opts))
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		require.Equal(t, http.StatusCreated, res.Code)
+
+		created := readTestResponse[auth.Group](t, res)
+		require.NotEmpty(t, created.ID)
+		require.Equal(t, opts, created.GroupOptions)
+
+		fromDatabase := auth.Group{}
+		require.NoError(t, db.NewSelect().
+			Model(&fromDatabase).
+			Where("id = ?", created.ID).
+			Scan(context.Background()))
+		require.Equal(t, opts, fromDatabase.GroupOptions)
+
+		// The roles must exist
+		req = httptest.NewRequest(http.MethodPost, "/groups", createJSONBuffer(t, auth.GroupOptions{
+			Name:  "analysts",
+			Roles: []string{"unknown"},
+		}))
+		res = httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		require.Equal(t, http.StatusBadRequest, res.Code)
+	})
+}
+
+func TestUpdateGroup(t *testing.T) {
+	withDbAndRouter(t, addGroupRoutes, func(router chi.Router, db *bun.DB) {
+		group := auth.NewGroup(auth.GroupOptions{
+			Name: "analysts",
+		})
+		_, err := db.NewInsert().Model(group).Exec(context.Background())
+		require.NoError(t, err)
+
+		opts := auth.GroupOptions{
+			Name:        "analysts",
+			Description: "Read only access",
+			Roles:       []string{},
+		}
+		req := httptest.NewRequest(http.MethodPut, "/groups/"+group.ID, createJSONBuffer(t, opts))
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		require.Equal(t, http.StatusOK, res.Code)
+
+		updated := readTestResponse[auth.Group](t, res)
+		require.Equal(t, group.ID, updated.ID)
+		require.Equal(t, opts, updated.GroupOptions)
+	})
+}
+
+func TestGroupMembers(t *testing.T) {
+	withDbAndRouter(t, addGroupRoutes, func(router chi.Router, db *bun.DB) {
+		group := auth.NewGroup(auth.GroupOptions{
+			Name: "analysts",
+		})
+		_, err := db.NewInsert().Model(group).Exec(context.Background())
+		require.NoError(t, err)
+
+		user := auth.NewUser(auth.UserOptions{
+			Subject:  "subject",
+			Email:    "user@example.com",
+			Metadata: auth.Metadata{},
+		})
+		_, err = db.NewInsert().Model(user).Exec(context.Background())
+		require.NoError(t, err)
+
+		_, err = db.NewInsert().Model(&auth.GroupMember{
+			GroupID:      group.ID,
+			UserID:       user.ID,
+			Synchronized: true,
+		}).Exec(context.Background())
+		require.NoError(t, err)
+
+		// Adding a member is idempotent
+		for i := 0; i < 2; i++ {
+			req := httptest.NewRequest(http.MethodPut, "/groups/"+group.ID+"/members/"+user.ID, nil)
+			res := httptest.NewRecorder()
+			router.ServeHTTP(res, req)
+			require.Equal(t, http.StatusNoContent, res.Code)
+		}
+
+		// The membership synchronized from the upstream identity provider is now managed through the api
+		membership := auth.GroupMember{}
+		require.NoError(t, db.NewSelect().
+			Model(&membership).
+			Where("group_id = ?", group.ID).
+			Where("user_id = ?", user.ID).
+			Scan(context.Background()))
+		require.False(t, membership.Synchronized)
+
+		req := httptest.NewRequest(http.MethodPut, "/groups/"+group.ID+"/members/unknown", nil)
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		require.Equal(t, http.StatusNotFound, res.Code)
+
+		req = httptest.NewRequest(http.MethodGet, "/groups/"+group.ID+"/members", nil)
+		res = httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		require.Equal(t, http.StatusOK, res.Code)
+		members := readTestResponse[[]auth.User](t, res)
+		require.Len(t, members, 1)
+		require.Equal(t, user.ID, members[0].ID)
+
+		req = httptest.NewRequest(http.MethodDelete, "/groups/"+group.ID+"/members/"+user.ID, nil)
+		res = httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		require.Equal(t, http.StatusNoContent, res.Code)
+
+		req = httptest.NewRequest(http.MethodGet, "/groups/"+group.ID+"/members", nil)
+		res = httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		require.Equal(t, http.StatusOK, res.Code)
+		require.Empty(t, readTestResponse[[]auth.User](t, res))
+	})
+}
+
+func TestReadAndDeleteGroup(t *testing.T) {
+	withDbAndRouter(t, addGroupRoutes, func(router chi.Router, db *bun.DB) {
+		group := auth.NewGroup(auth.GroupOptions{
+			Name:  "analysts",
+			Roles: []string{},
+		})
+		_, err := db.NewInsert().Model(group).Exec(context.Background())
+		require.NoError(t, err)
+		_, err = db.NewInsert().Model(&auth.GroupMember{
+			GroupID: group.ID,
+			UserID:  "user",
+		}).Exec(context.Background())
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodGet, "/groups/"+group.ID, nil)
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		require.Equal(t, http.StatusOK, res.Code)
+		require.Equal(t, group.GroupOptions, readTestResponse[auth.Group](t, res).GroupOptions)
+
+		req = httptest.NewRequest(http.MethodDelete, "/groups/"+group.ID, nil)
+		res = httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		require.Equal(t, http.StatusNoContent, res.Code)
+
+		req = httptest.NewRequest(http.MethodGet, "/groups/"+group.ID, nil)
+		res = httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		require.Equal(t, http.StatusNotFound, res.Code)
+
+		// The memberships are deleted with the group
+		count, err := db.NewSelect().
+			Model((*auth.GroupMember)(nil)).
+			Where("group_id = ?", group.ID).
+			Count(context.Background())
+		require.NoError(t, err)
+		require.Zero(t, count)
+	})
+}
@@ -0,0 +1,143 @@
+package api
+
+import (
+	"errors"
+	authlib "github.com/formancehq/go-libs/v3/auth"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/uptrace/bun"
+
+	auth "github.com/formancehq/auth/pkg"
+	"github.com/formancehq/auth/pkg/storage/sqlstorage"
+)
+
+// addIdentityProviderRoutes registers the identity provider routes, the client secrets are encrypted if encryption is not nil
+func addIdentityProviderRoutes(db *bun.DB, encryption *sqlstorage.Encryption, r chi.Router, authenticator authlib.Authenticator) {
+	r.With(authlib.Middleware(authenticator)).Route("/identity-providers", func(r chi.Router) {
+		r.Post("/", createIdentityProvider(db, encryption))
+		r.Get("/", listIdentityProviders(db))
+		r.Route("/{identityProviderId}", func(r chi.Router) {
+			r.Put("/", updateIdentityProvider(db, encryption))
+			r.Delete("/", deleteIdentityProvider(db))
+			r.Get("/", readIdentityProvider(db))
+		})
+	})
+}
+
+// withoutSecret returns an identity provider without its client secret, which is never returned by the api
+func withoutSecret(identityProvider auth.IdentityProvider) auth.IdentityProvider {
+	identityProvider.ClientSecret = ""
+	return identityProvider
+}
+
+func readIdentityProvider(db *bun.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		identityProvider := findById[*auth.IdentityProvider](w, r, db, "identityProviderId")
+		if identityProvider == nil {
+			return
+		}
+		writeJSONObject(w, r, withoutSecret(*identityProvider))
+	}
+}
+
+// deleteIdentityProvider deletes an identity provider, its users are kept but can no longer log in
+func deleteIdentityProvider(db *bun.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_, err := db.NewDelete().
+			Model(&auth.IdentityProvider{}).
+			Where("id = ?", chi.URLParam(r, "identityProviderId")).
+			Exec(r.Context())
+		if err != nil {
+			internalServerError(w, r, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func listIdentityProviders(db *bun.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		identityProviders := make([]auth.IdentityProvider, 0)
+		if err := db.
+			NewSelect().
+			Model(&identityProviders).
+			Order("name").
+			Scan(r.Context()); err != nil {
+			internalServerError(w, r, err)
+			return
+		}
+		writeJSONObject(w, r, mapList(identityProviders, withoutSecret))
+	}
+}
+
+// updateIdentityProvider updates an identity provider, its name can not be changed as it identifies its users
+func updateIdentityProvider(db *bun.DB, encryption *sqlstorage.Encryption) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		identityProvider := findById[*auth.IdentityProvider](w, r, db, "identityProviderId")
+		if identityProvider == nil {
+			return
+		}
+
+		opts := readJSONObject[auth.IdentityProviderOptions](w, r)
+		if opts == nil {
+			return
+		}
+		if err := opts.Validate(); err != nil {
+			validationError(w, r, err)
+			return
+		}
+		if opts.Name != identityProvider.Name {
+			validationError(w, r, errors.New("the name of an identity provider can not be changed"))
+			return
+		}
+
+		var err error
+		opts.ClientSecret, err = sqlstorage.EncryptIdentityProviderSecret(encryption, opts.ClientSecret)
+		if err != nil {
+			internalServerError(w, r, err)
+			return
+		}
+
+		identityProvider.Update(*opts)
+
+		_, err = db.NewUpdate().
+			Model(identityProvider).
+			Where("id = ?", identityProvider.ID).
+			Exec(r.Context())
+		if err != nil {
+			internalServerError(w, r, err)
+			return
+		}
+
+		writeJSONObject(w, r, withoutSecret(*identityProvider))
+	}
+}
+
+func createIdentityProvider(db *bun.DB, encryption *sqlstorage.Encryption) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		opts := readJSONObject[auth.IdentityProviderOptions](w, r)
+		if opts == nil {
+			return
+		}
+		if err := opts.Validate(); err != nil {
+			validationError(w, r, err)
+			return
+		}
+
+		var err error
+		opts.ClientSecret, err = sqlstorage.EncryptIdentityProviderSecret(encryption, opts.ClientSecret)
+		if err != nil {
+			internalServerError(w, r, err)
+			return
+		}
+
+		identityProvider := auth.NewIdentityProvider(*opts)
+		if err := createObject(w, r, db, identityProvider); err != nil {
+			return
+		}
+
+		writeCreatedJSONObject(w, r, withoutSecret(*identityProvider), identityProvider.ID)
+	}
+}
@@ -0,0 +1,205 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/uptrace/bun"
+
+	auth "github.com/formancehq/auth/pkg"
+	"github.com/formancehq/auth/pkg/storage/sqlstorage"
+	authlib "github.com/formancehq/go-libs/v3/auth"
+	"github.com/stretchr/testify/require"
+)
+
+func identityProviderRoutes(encryption *sqlstorage.Encryption) func(db *bun.DB, router chi.Router, authenticator authlib.Authenticator) {
+	return func(db *bun.DB, router chi.Router, authenticator authlib.Authenticator) {
+		addIdentityProviderRoutes(db, encryption, router, authenticator)
+	}
+}
+
+func TestCreateIdentityProvider(t *testing.T) {
+	withDbAndRouter(t, identityProviderRoutes(nil), func(router chi.Router, db *bun.DB) {
+		opts := auth.IdentityProviderOptions{
+			Name:         "partner",
+			DisplayName:  "Partner",
+			Issuer:       "https://accounts.partner.com",
+			ClientID:     "auth",
+			ClientSecret: "secret",
+			Scopes:       []string{"openid", "email", "profile"},
+			Domains:      []string{"partner.com"},
+			GroupsClaim:  "groups",
+			GroupMappings: []auth.GroupMapping{{
+				Value:  "finance-team",
+				Groups: []string{"analysts"},
+			}},
+		}
+
+		req := httptest.NewRequest(http.MethodPost, "/identity-providers", createJSONBuffer(t, opts))
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		require.Equal(t, http.StatusCreated, res.Code)
+
+		// The client secret is never returned
+		created := readTestResponse[auth.IdentityProvider](t, res)
+		require.NotEmpty(t, created.ID)
+		require.Empty(t, created.ClientSecret)
+
+		fromDatabase := auth.IdentityProvider{}
+		require.NoError(t, db.NewSelect().
+			Model(&fromDatabase).
+			Where("id = ?", created.ID).
+			Scan(context.Background()))
+		require.Equal(t, opts, fromDatabase.IdentityProviderOptions)
+
+		// The names are unique
+		req = httptest.NewRequest(http.MethodPost, "/identity-providers", createJSONBuffer(t, opts))
+		res = httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		require.Equal(t, http.StatusConflict, res.Code)
+
+		req = httptest.NewRequest(http.MethodPost, "/identity-providers", createJSONBuffer(t, auth.IdentityProviderOptions{
+			Name:   "other",
+			Issuer: "https://accounts.other.com",
+		}))
+		res = httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		require.Equal(t, http.StatusBadRequest, res.Code)
+	})
+}
+
+func TestUpdateIdentityProvider(t *testing.T) {
+	withDbAndRouter(t, identityProviderRoutes(nil), func(router chi.Router, db *bun.DB) {
+		identityProvider := auth.NewIdentityProvider(auth.IdentityProviderOptions{
+			Name:         "partner",
+			Issuer:       "https://accounts.partner.com",
+			ClientID:     "auth",
+			ClientSecret: "secret",
+		})
+		_, err := db.NewInsert().Model(identityProvider).Exec(context.Background())
+		require.NoError(t, err)
+
+		opts := auth.IdentityProviderOptions{
+			Name:     "partner",
+			Issuer:   "https://accounts.partner.com",
+			ClientID: "auth",
+			Domains:  []string{"partner.com"},
+		}
+		req := httptest.NewRequest(http.MethodPut, "/identity-providers/"+identityProvider.ID, createJSONBuffer(t, opts))
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		require.Equal(t, http.StatusOK, res.Code)
+		require.Equal(t, opts, readTestResponse[auth.IdentityProvider](t, res).IdentityProviderOptions)
+
+		// The client secret is kept if not provided
+		fromDatabase := auth.IdentityProvider{}
+		require.NoError(t, db.NewSelect().
+			Model(&fromDatabase).
+			Where("id = ?", identityProvider.ID).
+			Scan(context.Background()))
+		require.Equal(t, "secret", fromDatabase.ClientSecret)
+		require.Equal(t, opts.Domains, fromDatabase.Domains)
+
+		// The name identifies the users of the provider and can not be changed
+		opts.Name = "renamed"
+		req = httptest.NewRequest(http.MethodPut, "/identity-providers/"+identityProvider.ID, createJSONBuffer(t, opts))
+		res = httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		require.Equal(t, http.StatusBadRequest, res.Code)
+	})
+}
+
+func TestListReadAndDeleteIdentityProvider(t *testing.T) {
+	withDbAndRouter(t, identityProviderRoutes(nil), func(router chi.Router, db *bun.DB) {
+		identityProvider := auth.NewIdentityProvider(auth.IdentityProviderOptions{
+			Name:         "partner",
+			Issuer:       "https://accounts.partner.com",
+			ClientID:     "auth",
+			ClientSecret: "secret",
+		})
+		_, err := db.NewInsert().Model(identityProvider).Exec(context.Background())
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodGet, "/identity-providers", nil)
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		require.Equal(t, http.StatusOK, res.Code)
+		identityProviders := readTestResponse[[]auth.IdentityProvider](t, res)
+		require.Len(t, identityProviders, 1)
+		require.Equal(t, identityProvider.ID, identityProviders[0].ID)
+		require.Empty(t, identityProviders[0].ClientSecret)
+
+		req = httptest.NewRequest(http.MethodGet, "/identity-providers/"+identityProvider.ID, nil)
+		res = httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		require.Equal(t, http.StatusOK, res.Code)
+		read := readTestResponse[auth.IdentityProvider](t, res)
+		require.Equal(t, identityProvider.Name, read.Name)
+		require.Empty(t, read.ClientSecret)
+
+		req = httptest.NewRequest(http.MethodDelete, "/identity-providers/"+identityProvider.ID, nil)
+		res = httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		require.Equal(t, http.StatusNoContent, res.Code)
+
+		req = httptest.NewRequest(http.MethodGet, "/identity-providers/"+identityProvider.ID, nil)
+		res = httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		require.Equal(t, http.StatusNotFound, res.Code)
+	})
+}
+
+func TestIdentityProviderSecretEncryption(t *testing.T) {
+	encryption, err := sqlstorage.NewEncryption("foo")
+	require.NoError(t, err)
+
+	withDbAndRouter(t, identityProviderRoutes(encryption), func(router chi.Router, db *bun.DB) {
+		opts := auth.IdentityProviderOptions{
+			Name:         "partner",
+			Issuer:       "https://accounts.partner.com",
+			ClientID:     "auth",
+			ClientSecret: "secret",
+		}
+		req := httptest.NewRequest(http.MethodPost, "/identity-providers", createJSONBuffer(t, opts))
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		require.Equal(t, http.StatusCreated, res.Code)
+		created := readTestResponse[auth.IdentityProvider](t, res)
+
+		readSecret := func() string {
+			identityProviders, err := sqlstorage.New(db, encryption).ListIdentityProviders(context.Background())
+			require.NoError(t, err)
+			require.Len(t, identityProviders, 1)
+			return identityProviders[0].ClientSecret
+		}
+
+		// The client secret is stored encrypted
+		fromDatabase := auth.IdentityProvider{}
+		require.NoError(t, db.NewSelect().
+			Model(&fromDatabase).
+			Where("id = ?", created.ID).
+			Scan(context.Background()))
+		require.NotContains(t, fromDatabase.ClientSecret, "secret")
+		require.Equal(t, "secret", readSecret())
+
+		// The encrypted client secret is kept if not provided
+		opts.ClientSecret = ""
+		opts.DisplayName = "Partner"
+		req = httptest.NewRequest(http.MethodPut, "/identity-providers/"+created.ID, createJSONBuffer(t, opts))
+		res = httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		require.Equal(t, http.StatusOK, res.Code)
+		require.Equal(t, "secret", readSecret())
+
+		opts.ClientSecret = "rotated"
+		req = httptest.NewRequest(http.MethodPut, "/identity-providers/"+created.ID, createJSONBuffer(t, opts))
+		res = httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		require.Equal(t, http.StatusOK, res.Code)
+		require.Equal(t, "rotated", readSecret())
+	})
+}
@@ -1,6 +1,8 @@
 package api
 
 import (
+	"context"
+	"crypto/tls"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
@@ -52,7 +54,29 @@ func addInfoRoute(router chi.Router, serviceInfo api.ServiceInfo) {
 	router.Get("/_info", api.InfoHandler(serviceInfo))
 }
 
-func Module(addr, defaultIssuer string, trustedIssuers []string, serviceInfo api.ServiceInfo, debug bool) fx.Option {
+// newTLSHook starts the server on a TLS listener, the client certificates are requested for the mutual TLS authentication
+func newTLSHook(handler http.Handler, addr string, config *tls.Config) fx.Hook {
+	var hook fx.Hook
+	return fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			listener, err := tls.Listen("tcp", addr, config)
+			if err != nil {
+				return err
+			}
+			hook = httpserver.NewHook(handler, httpserver.WithListener(listener))
+			return hook.OnStart(ctx)
+		},
+		OnStop: func(ctx context.Context) error {
+			if hook.OnStop == nil {
+				return nil
+			}
+			return hook.OnStop(ctx)
+		},
+	}
+}
+
+// Module starts the http server, TLS is terminated by the server if tlsConfig is not nil
+func Module(addr string, tlsConfig *tls.Config, defaultIssuer string, trustedIssuers []string, serviceInfo api.ServiceInfo, debug bool) fx.Option {
 	return fx.Options(
 		health.Module(),
 		fx.Supply(serviceInfo),
@@ -63,12 +87,22 @@ func Module(addr, defaultIssuer string, trustedIssuers []string, serviceInfo api
 			addInfoRoute,
 			addClientRoutes,
 			addUserRoutes,
+			addTokenRoutes,
+			addResourceServerRoutes,
+			addScopeRoutes,
+			addRoleRoutes,
+			addGroupRoutes,
+			addIdentityProviderRoutes,
 		),
 		fx.Invoke(func(lc fx.Lifecycle, r chi.Router, healthController *health.HealthController, o op.OpenIDProvider) {
 			finalRouter := chi.NewRouter()
 			finalRouter.Get("/_healthcheck", healthController.Check)
 			finalRouter.Mount("/", r)
 
+			if tlsConfig != nil {
+				lc.Append(newTLSHook(finalRouter, addr, tlsConfig))
+				return
+			}
 			lc.Append(httpserver.NewHook(finalRouter, httpserver.WithAddress(addr)))
 		}),
 	)
@@ -0,0 +1,114 @@
+package api
+
+import (
+	authlib "github.com/formancehq/go-libs/v3/auth"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/uptrace/bun"
+
+	auth "github.com/formancehq/auth/pkg"
+)
+
+func addResourceServerRoutes(db *bun.DB, r chi.Router, authenticator authlib.Authenticator) {
+	r.With(authlib.Middleware(authenticator)).Route("/resource-servers", func(r chi.Router) {
+		r.Post("/", createResourceServer(db))
+		r.Get("/", listResourceServers(db))
+		r.Route("/{resourceServerId}", func(r chi.Router) {
+			r.Put("/", updateResourceServer(db))
+			r.Delete("/", deleteResourceServer(db))
+			r.Get("/", readResourceServer(db))
+		})
+	})
+}
+
+func readResourceServer(db *bun.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		resourceServer := findById[*auth.ResourceServer](w, r, db, "resourceServerId")
+		if resourceServer == nil {
+			return
+		}
+		writeJSONObject(w, r, resourceServer)
+	}
+}
+
+func deleteResourceServer(db *bun.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_, err := db.
+			NewDelete().
+			Model(&auth.ResourceServer{}).
+			Where("id = ?", chi.URLParam(r, "resourceServerId")).
+			Exec(r.Context())
+		if err != nil {
+			internalServerError(w, r, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func listResourceServers(db *bun.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		resourceServers := make([]auth.ResourceServer, 0)
+		if err := db.
+			NewSelect().
+			Model(&resourceServers).
+			Scan(r.Context()); err != nil {
+			internalServerError(w, r, err)
+			return
+		}
+		writeJSONObject(w, r, resourceServers)
+	}
+}
+
+func updateResourceServer(db *bun.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		resourceServer := findById[*auth.ResourceServer](w, r, db, "resourceServerId")
+		if resourceServer == nil {
+			return
+		}
+
+		opts := readJSONObject[auth.ResourceServerOptions](w, r)
+		if opts == nil {
+			return
+		}
+		if err := opts.Validate(); err != nil {
+			validationError(w, r, err)
+			return
+		}
+
+		resourceServer.Update(*opts)
+
+		_, err := db.NewUpdate().
+			Model(resourceServer).
+			Where("id = ?", resourceServer.ID).
+			Exec(r.Context())
+		if err != nil {
+			internalServerError(w, r, err)
+			return
+		}
+
+		writeJSONObject(w, r, resourceServer)
+	}
+}
+
+func createResourceServer(db *bun.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		opts := readJSONObject[auth.ResourceServerOptions](w, r)
+		if opts == nil {
+			return
+		}
+		if err := opts.Validate(); err != nil {
+			validationError(w, r, err)
+			return
+		}
+
+		resourceServer := auth.NewResourceServer(*opts)
+		if err := createObject(w, r, db, resourceServer); err != nil {
+			return
+		}
+
+		writeCreatedJSONObject(w, r, resourceServer, resourceServer.ID)
+	}
+}
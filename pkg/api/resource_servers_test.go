@@ -0,0 +1,121 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/uptrace/bun"
+
+	auth "github.com/formancehq/auth/pkg"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateResourceServer(t *testing.T) {
+	withDbAndRouter(t, addResourceServerRoutes, func(router chi.Router, db *bun.DB) {
+		opts := auth.ResourceServerOptions{
+			Name:       "ledger",
+			Identifier: "https://ledger.formance.com",
+			Scopes:     []string{"ledger:read", "ledger:write"},
+		}
+
+		req := httptest.NewRequest(http.MethodPost, "/resource-servers", createJSONBuffer(t, opts))
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		require.Equal(t, http.StatusCreated, res.Code)
+
+		created := readTestResponse[auth.ResourceServer](t, res)
+		require.NotEmpty(t, created.ID)
+		require.Equal(t, opts, created.ResourceServerOptions)
+
+		fromDatabase := auth.ResourceServer{}
+		require.NoError(t, db.NewSelect().
+			Model(&fromDatabase).
+			Where("id = ?", created.ID).
+			Scan(context.Background()))
+		require.Equal(t, opts, fromDatabase.ResourceServerOptions)
+
+		// The identifier must be an absolute URI
+		opts.Identifier = "ledger"
+		req = httptest.NewRequest(http.MethodPost, "/resource-servers", createJSONBuffer(t, opts))
+		res = httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		require.Equal(t, http.StatusBadRequest, res.Code)
+	})
+}
+
+func TestUpdateResourceServer(t *testing.T) {
+	withDbAndRouter(t, addResourceServerRoutes, func(router chi.Router, db *bun.DB) {
+		resourceServer := auth.NewResourceServer(auth.ResourceServerOptions{
+			Name:       "ledger",
+			Identifier: "https://ledger.formance.com",
+		})
+		_, err := db.NewInsert().Model(resourceServer).Exec(context.Background())
+		require.NoError(t, err)
+
+		opts := auth.ResourceServerOptions{
+			Name:       "payments",
+			Identifier: "https://payments.formance.com",
+			Scopes:     []string{"payments:read"},
+		}
+		req := httptest.NewRequest(http.MethodPut, "/resource-servers/"+resourceServer.ID, createJSONBuffer(t, opts))
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		require.Equal(t, http.StatusOK, res.Code)
+
+		updated := readTestResponse[auth.ResourceServer](t, res)
+		require.Equal(t, resourceServer.ID, updated.ID)
+		require.Equal(t, opts, updated.ResourceServerOptions)
+	})
+}
+
+func TestListResourceServers(t *testing.T) {
+	withDbAndRouter(t, addResourceServerRoutes, func(router chi.Router, db *bun.DB) {
+		for _, identifier := range []string{"https://ledger.formance.com", "https://payments.formance.com"} {
+			_, err := db.NewInsert().Model(auth.NewResourceServer(auth.ResourceServerOptions{
+				Name:       identifier,
+				Identifier: identifier,
+			})).Exec(context.Background())
+			require.NoError(t, err)
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/resource-servers", nil)
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		require.Equal(t, http.StatusOK, res.Code)
+
+		resourceServers := readTestResponse[[]auth.ResourceServer](t, res)
+		require.Len(t, resourceServers, 2)
+	})
+}
+
+func TestReadAndDeleteResourceServer(t *testing.T) {
+	withDbAndRouter(t, addResourceServerRoutes, func(router chi.Router, db *bun.DB) {
+		resourceServer := auth.NewResourceServer(auth.ResourceServerOptions{
+			Name:       "ledger",
+			Identifier: "https://ledger.formance.com",
+			Scopes:     []string{"ledger:read"},
+		})
+		_, err := db.NewInsert().Model(resourceServer).Exec(context.Background())
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodGet, "/resource-servers/"+resourceServer.ID, nil)
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		require.Equal(t, http.StatusOK, res.Code)
+		require.Equal(t, resourceServer.ResourceServerOptions, readTestResponse[auth.ResourceServer](t, res).ResourceServerOptions)
+
+		req = httptest.NewRequest(http.MethodDelete, "/resource-servers/"+resourceServer.ID, nil)
+		res = httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		require.Equal(t, http.StatusNoContent, res.Code)
+
+		req = httptest.NewRequest(http.MethodGet, "/resource-servers/"+resourceServer.ID, nil)
+		res = httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		require.Equal(t, http.StatusNotFound, res.Code)
+	})
+}
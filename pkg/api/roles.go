@@ -0,0 +1,153 @@
+package api
+
+import (
+	"context"
+	authlib "github.com/formancehq/go-libs/v3/auth"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/uptrace/bun"
+
+	auth "github.com/formancehq/auth/pkg"
+)
+
+func addRoleRoutes(db *bun.DB, r chi.Router, authenticator authlib.Authenticator) {
+	r.With(authlib.Middleware(authenticator)).Route("/roles", func(r chi.Router) {
+		r.Post("/", createRole(db))
+		r.Get("/", listRoles(db))
+		r.Route("/{roleId}", func(r chi.Router) {
+			r.Put("/", updateRole(db))
+			r.Delete("/", deleteRole(db))
+			r.Get("/", readRole(db))
+		})
+	})
+}
+
+func readRole(db *bun.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		role := findById[*auth.Role](w, r, db, "roleId")
+		if role == nil {
+			return
+		}
+		writeJSONObject(w, r, role)
+	}
+}
+
+// deleteRole deletes a role and removes it from the groups having it
+func deleteRole(db *bun.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		roleID := chi.URLParam(r, "roleId")
+		err := db.RunInTx(r.Context(), nil, func(ctx context.Context, tx bun.Tx) error {
+			if _, err := tx.NewDelete().
+				Model(&auth.Role{}).
+				Where("id = ?", roleID).
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			groups := make([]auth.Group, 0)
+			if err := tx.NewSelect().
+				Model(&groups).
+				Scan(ctx); err != nil {
+				return err
+			}
+			for _, group := range groups {
+				roles := auth.Array[string]{}
+				for _, id := range group.Roles {
+					if id != roleID {
+						roles = append(roles, id)
+					}
+				}
+				if len(roles) == len(group.Roles) {
+					continue
+				}
+				if _, err := tx.NewUpdate().
+					Model((*auth.Group)(nil)).
+					Set("roles = ?", roles).
+					Where("id = ?", group.ID).
+					Exec(ctx); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			internalServerError(w, r, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func listRoles(db *bun.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		roles := make([]auth.Role, 0)
+		if err := db.
+			NewSelect().
+			Model(&roles).
+			Order("name").
+			Scan(r.Context()); err != nil {
+			internalServerError(w, r, err)
+			return
+		}
+		writeJSONObject(w, r, roles)
+	}
+}
+
+func updateRole(db *bun.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		role := findById[*auth.Role](w, r, db, "roleId")
+		if role == nil {
+			return
+		}
+
+		opts := readJSONObject[auth.RoleOptions](w, r)
+		if opts == nil {
+			return
+		}
+		if err := opts.Validate(); err != nil {
+			validationError(w, r, err)
+			return
+		}
+		if !checkScopes(w, r, db, opts.Scopes) {
+			return
+		}
+
+		role.Update(*opts)
+
+		_, err := db.NewUpdate().
+			Model(role).
+			Where("id = ?", role.ID).
+			Exec(r.Context())
+		if err != nil {
+			internalServerError(w, r, err)
+			return
+		}
+
+		writeJSONObject(w, r, role)
+	}
+}
+
+func createRole(db *bun.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		opts := readJSONObject[auth.RoleOptions](w, r)
+		if opts == nil {
+			return
+		}
+		if err := opts.Validate(); err != nil {
+			validationError(w, r, err)
+			return
+		}
+		if !checkScopes(w, r, db, opts.Scopes) {
+			return
+		}
+
+		role := auth.NewRole(*opts)
+		if err := createObject(w, r, db, role); err != nil {
+			return
+		}
+
+		writeCreatedJSONObject(w, r, role, role.ID)
+	}
+}
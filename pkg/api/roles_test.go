@@ -0,0 +1,116 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/uptrace/bun"
+
+	auth "github.com/formancehq/auth/pkg"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateRole(t *testing.T) {
+	withDbAndRouter(t, addRoleRoutes, func(router chi.Router, db *bun.DB) {
+		opts := auth.RoleOptions{
+			Name:        "analyst",
+			Description: "Read only access",
+			Scopes:      []string{"ledger:read", "payments:read"},
+		}
+
+		req := httptest.NewRequest(http.MethodPost, "/roles", createJSONBuffer(t, opts))
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		require.Equal(t, http.StatusCreated, res.Code)
+
+		created := readTestResponse[auth.Role](t, res)
+		require.NotEmpty(t, created.ID)
+		require.Equal(t, opts, created.RoleOptions)
+
+		fromDatabase := auth.Role{}
+		require.NoError(t, db.NewSelect().
+			Model(&fromDatabase).
+			Where("id = ?", created.ID).
+			Scan(context.Background()))
+		require.Equal(t, opts, fromDatabase.RoleOptions)
+
+		// The scopes of the role must be registered
+		req = httptest.NewRequest(http.MethodPost, "/roles", createJSONBuffer(t, auth.RoleOptions{
+			Name:   "reporter",
+			Scopes: []string{"reports:unknown"},
+		}))
+		res = httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		require.Equal(t, http.StatusBadRequest, res.Code)
+	})
+}
+
+func TestUpdateRole(t *testing.T) {
+	withDbAndRouter(t, addRoleRoutes, func(router chi.Router, db *bun.DB) {
+		role := auth.NewRole(auth.RoleOptions{
+			Name:   "operator",
+			Scopes: []string{"ledger:read"},
+		})
+		_, err := db.NewInsert().Model(role).Exec(context.Background())
+		require.NoError(t, err)
+
+		opts := auth.RoleOptions{
+			Name:   "operator",
+			Scopes: []string{"ledger:*"},
+		}
+		req := httptest.NewRequest(http.MethodPut, "/roles/"+role.ID, createJSONBuffer(t, opts))
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		require.Equal(t, http.StatusOK, res.Code)
+
+		updated := readTestResponse[auth.Role](t, res)
+		require.Equal(t, role.ID, updated.ID)
+		require.Equal(t, opts, updated.RoleOptions)
+	})
+}
+
+func TestReadAndDeleteRole(t *testing.T) {
+	withDbAndRouter(t, addRoleRoutes, func(router chi.Router, db *bun.DB) {
+		role := auth.NewRole(auth.RoleOptions{
+			Name:   "analyst",
+			Scopes: []string{"ledger:read"},
+		})
+		_, err := db.NewInsert().Model(role).Exec(context.Background())
+		require.NoError(t, err)
+
+		group := auth.NewGroup(auth.GroupOptions{
+			Name:  "analysts",
+			Roles: []string{role.ID},
+		})
+		_, err = db.NewInsert().Model(group).Exec(context.Background())
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodGet, "/roles/"+role.ID, nil)
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		require.Equal(t, http.StatusOK, res.Code)
+		require.Equal(t, role.RoleOptions, readTestResponse[auth.Role](t, res).RoleOptions)
+
+		req = httptest.NewRequest(http.MethodDelete, "/roles/"+role.ID, nil)
+		res = httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		require.Equal(t, http.StatusNoContent, res.Code)
+
+		req = httptest.NewRequest(http.MethodGet, "/roles/"+role.ID, nil)
+		res = httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		require.Equal(t, http.StatusNotFound, res.Code)
+
+		// The role is removed from the groups having it
+		fromDatabase := auth.Group{}
+		require.NoError(t, db.NewSelect().
+			Model(&fromDatabase).
+			Where("id = ?", group.ID).
+			Scan(context.Background()))
+		require.Empty(t, fromDatabase.Roles)
+	})
+}
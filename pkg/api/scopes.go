@@ -0,0 +1,139 @@
+package api
+
+import (
+	authlib "github.com/formancehq/go-libs/v3/auth"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/uptrace/bun"
+
+	auth "github.com/formancehq/auth/pkg"
+)
+
+func addScopeRoutes(db *bun.DB, r chi.Router, authenticator authlib.Authenticator) {
+	r.With(authlib.Middleware(authenticator)).Route("/scopes", func(r chi.Router) {
+		r.Post("/", createScope(db))
+		r.Get("/", listScopes(db))
+		r.Route("/{scopeId}", func(r chi.Router) {
+			r.Put("/", updateScope(db))
+			r.Delete("/", deleteScope(db))
+			r.Get("/", readScope(db))
+		})
+	})
+}
+
+func readScope(db *bun.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		scope := findById[*auth.Scope](w, r, db, "scopeId")
+		if scope == nil {
+			return
+		}
+		writeJSONObject(w, r, scope)
+	}
+}
+
+func deleteScope(db *bun.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_, err := db.
+			NewDelete().
+			Model(&auth.Scope{}).
+			Where("id = ?", chi.URLParam(r, "scopeId")).
+			Exec(r.Context())
+		if err != nil {
+			internalServerError(w, r, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func listScopes(db *bun.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		scopes := make([]auth.Scope, 0)
+		if err := db.
+			NewSelect().
+			Model(&scopes).
+			Order("name").
+			Scan(r.Context()); err != nil {
+			internalServerError(w, r, err)
+			return
+		}
+		writeJSONObject(w, r, scopes)
+	}
+}
+
+func updateScope(db *bun.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		scope := findById[*auth.Scope](w, r, db, "scopeId")
+		if scope == nil {
+			return
+		}
+
+		opts := readJSONObject[auth.ScopeOptions](w, r)
+		if opts == nil {
+			return
+		}
+		if err := opts.Validate(); err != nil {
+			validationError(w, r, err)
+			return
+		}
+		if !checkScopes(w, r, db, opts.Implies) {
+			return
+		}
+
+		scope.Update(*opts)
+
+		_, err := db.NewUpdate().
+			Model(scope).
+			Where("id = ?", scope.ID).
+			Exec(r.Context())
+		if err != nil {
+			internalServerError(w, r, err)
+			return
+		}
+
+		writeJSONObject(w, r, scope)
+	}
+}
+
+func createScope(db *bun.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		opts := readJSONObject[auth.ScopeOptions](w, r)
+		if opts == nil {
+			return
+		}
+		if err := opts.Validate(); err != nil {
+			validationError(w, r, err)
+			return
+		}
+		if !checkScopes(w, r, db, opts.Implies) {
+			return
+		}
+
+		scope := auth.NewScope(*opts)
+		if err := createObject(w, r, db, scope); err != nil {
+			return
+		}
+
+		writeCreatedJSONObject(w, r, scope, scope.ID)
+	}
+}
+
+// checkScopes writes a validation error if some scopes are neither OpenID Connect scopes, registered ones nor patterns
+func checkScopes(w http.ResponseWriter, r *http.Request, db *bun.DB, scopes []string) bool {
+	registered := make([]string, 0)
+	if err := db.
+		NewSelect().
+		Model((*auth.Scope)(nil)).
+		Column("name").
+		Scan(r.Context(), &registered); err != nil {
+		internalServerError(w, r, err)
+		return false
+	}
+	if err := auth.ValidateScopes(scopes, registered); err != nil {
+		validationError(w, r, err)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,128 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/uptrace/bun"
+
+	auth "github.com/formancehq/auth/pkg"
+	"github.com/formancehq/go-libs/v3/collectionutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateScope(t *testing.T) {
+	withDbAndRouter(t, addScopeRoutes, func(router chi.Router, db *bun.DB) {
+		opts := auth.ScopeOptions{
+			Name:            "reports:write",
+			Description:     "Write the reports",
+			Service:         "reports",
+			RequiresConsent: true,
+			Implies:         []string{"ledger:read"},
+		}
+
+		req := httptest.NewRequest(http.MethodPost, "/scopes", createJSONBuffer(t, opts))
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		require.Equal(t, http.StatusCreated, res.Code)
+
+		created := readTestResponse[auth.Scope](t, res)
+		require.NotEmpty(t, created.ID)
+		require.Equal(t, opts, created.ScopeOptions)
+
+		fromDatabase := auth.Scope{}
+		require.NoError(t, db.NewSelect().
+			Model(&fromDatabase).
+			Where("id = ?", created.ID).
+			Scan(context.Background()))
+		require.Equal(t, opts, fromDatabase.ScopeOptions)
+
+		// The scopes of OpenID Connect are reserved
+		req = httptest.NewRequest(http.MethodPost, "/scopes", createJSONBuffer(t, auth.ScopeOptions{
+			Name: "openid",
+		}))
+		res = httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		require.Equal(t, http.StatusBadRequest, res.Code)
+
+		// The implied scopes must be registered
+		req = httptest.NewRequest(http.MethodPost, "/scopes", createJSONBuffer(t, auth.ScopeOptions{
+			Name:    "reports:admin",
+			Implies: []string{"reports:unknown"},
+		}))
+		res = httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		require.Equal(t, http.StatusBadRequest, res.Code)
+	})
+}
+
+func TestUpdateScope(t *testing.T) {
+	withDbAndRouter(t, addScopeRoutes, func(router chi.Router, db *bun.DB) {
+		scope := auth.NewScope(auth.ScopeOptions{
+			Name: "reports:read",
+		})
+		_, err := db.NewInsert().Model(scope).Exec(context.Background())
+		require.NoError(t, err)
+
+		opts := auth.ScopeOptions{
+			Name:        "reports:read",
+			Description: "Read the reports",
+			Service:     "reports",
+		}
+		req := httptest.NewRequest(http.MethodPut, "/scopes/"+scope.ID, createJSONBuffer(t, opts))
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		require.Equal(t, http.StatusOK, res.Code)
+
+		updated := readTestResponse[auth.Scope](t, res)
+		require.Equal(t, scope.ID, updated.ID)
+		require.Equal(t, opts, updated.ScopeOptions)
+	})
+}
+
+func TestListScopes(t *testing.T) {
+	withDbAndRouter(t, addScopeRoutes, func(router chi.Router, db *bun.DB) {
+		req := httptest.NewRequest(http.MethodGet, "/scopes", nil)
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		require.Equal(t, http.StatusOK, res.Code)
+
+		// The scopes of the services are registered by the migrations
+		scopes := readTestResponse[[]auth.Scope](t, res)
+		require.Contains(t, collectionutils.Map(scopes, func(scope auth.Scope) string {
+			return scope.Name
+		}), "ledger:read")
+	})
+}
+
+func TestReadAndDeleteScope(t *testing.T) {
+	withDbAndRouter(t, addScopeRoutes, func(router chi.Router, db *bun.DB) {
+		scope := auth.NewScope(auth.ScopeOptions{
+			Name:    "reports:write",
+			Service: "reports",
+			Implies: []string{"ledger:read"},
+		})
+		_, err := db.NewInsert().Model(scope).Exec(context.Background())
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodGet, "/scopes/"+scope.ID, nil)
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		require.Equal(t, http.StatusOK, res.Code)
+		require.Equal(t, scope.ScopeOptions, readTestResponse[auth.Scope](t, res).ScopeOptions)
+
+		req = httptest.NewRequest(http.MethodDelete, "/scopes/"+scope.ID, nil)
+		res = httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		require.Equal(t, http.StatusNoContent, res.Code)
+
+		req = httptest.NewRequest(http.MethodGet, "/scopes/"+scope.ID, nil)
+		res = httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		require.Equal(t, http.StatusNotFound, res.Code)
+	})
+}
@@ -0,0 +1,323 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	authlib "github.com/formancehq/go-libs/v3/auth"
+	"github.com/formancehq/go-libs/v3/bun/bunpaginate"
+	"github.com/formancehq/go-libs/v3/pointer"
+	"github.com/go-chi/chi/v5"
+	"github.com/pkg/errors"
+	"github.com/uptrace/bun"
+
+	auth "github.com/formancehq/auth/pkg"
+)
+
+const (
+	tokenTypeAccessToken  = "access_token"
+	tokenTypeRefreshToken = "refresh_token"
+)
+
+func addTokenRoutes(db *bun.DB, r chi.Router, authenticator authlib.Authenticator) {
+	r.With(authlib.Middleware(authenticator)).Route("/tokens", func(r chi.Router) {
+		r.Get("/", listTokens(db))
+		r.Delete("/", revokeTokens(db))
+		r.Delete("/{tokenId}", revokeToken(db))
+	})
+}
+
+type tokenView struct {
+	ID        string             `json:"id"`
+	Type      string             `json:"type"`
+	ClientID  string             `json:"clientId"`
+	UserID    string             `json:"userId,omitempty"`
+	Scopes    auth.Array[string] `json:"scopes"`
+	Audience  auth.Array[string] `json:"audience"`
+	CreatedAt time.Time          `json:"createdAt"`
+	ExpiresAt time.Time          `json:"expiresAt"`
+}
+
+func (v tokenView) position() tokenPosition {
+	return tokenPosition{CreatedAt: v.CreatedAt, ID: v.ID}
+}
+
+func mapAccessToken(t auth.AccessToken) tokenView {
+	return tokenView{
+		ID:        t.ID,
+		Type:      tokenTypeAccessToken,
+		ClientID:  t.ApplicationID,
+		UserID:    t.UserID,
+		Scopes:    t.Scopes,
+		Audience:  t.Audience,
+		CreatedAt: t.CreatedAt,
+		ExpiresAt: t.Expiration,
+	}
+}
+
+func mapRefreshToken(t auth.RefreshToken) tokenView {
+	return tokenView{
+		ID:        t.ID,
+		Type:      tokenTypeRefreshToken,
+		ClientID:  t.ApplicationID,
+		UserID:    t.UserID,
+		Scopes:    t.Scopes,
+		Audience:  t.Audience,
+		CreatedAt: t.CreatedAt,
+		ExpiresAt: t.Expiration,
+	}
+}
+
+type tokenFilters struct {
+	TokenType     string     `json:"type,omitempty"`
+	ClientID      string     `json:"clientId,omitempty"`
+	UserID        string     `json:"userId,omitempty"`
+	ExpiresBefore *time.Time `json:"expiresBefore,omitempty"`
+	ExpiresAfter  *time.Time `json:"expiresAfter,omitempty"`
+}
+
+func (f tokenFilters) apply(query *bun.SelectQuery) *bun.SelectQuery {
+	if f.ClientID != "" {
+		query = query.Where("application_id = ?", f.ClientID)
+	}
+	if f.UserID != "" {
+		query = query.Where("user_id = ?", f.UserID)
+	}
+	if f.ExpiresBefore != nil {
+		query = query.Where("expiration < ?", *f.ExpiresBefore)
+	}
+	if f.ExpiresAfter != nil {
+		query = query.Where("expiration > ?", *f.ExpiresAfter)
+	}
+	return query
+}
+
+// tokenPosition is the position of a token in the list, sorted by creation date then id, newest first
+type tokenPosition struct {
+	CreatedAt time.Time `json:"createdAt"`
+	ID        string    `json:"id"`
+}
+
+func (p tokenPosition) before(other tokenPosition) bool {
+	if !p.CreatedAt.Equal(other.CreatedAt) {
+		return p.CreatedAt.After(other.CreatedAt)
+	}
+	return p.ID > other.ID
+}
+
+// listTokensQuery is encoded in the cursors, so the next pages keep the filters of the first one
+type listTokensQuery struct {
+	Filters  tokenFilters `json:"filters"`
+	PageSize uint64       `json:"pageSize"`
+	// After is the position of the last token of the previous page
+	After *tokenPosition `json:"after,omitempty"`
+}
+
+// apply selects the tokens of the page, plus one to know if there are more.
+// The ids are compared with the C collation, which matches the ordering of the strings in go.
+func (q listTokensQuery) apply(query *bun.SelectQuery) *bun.SelectQuery {
+	query = q.Filters.apply(query)
+	if q.After != nil {
+		query = query.Where(`(created_at, id collate "C") < (?, ?)`, q.After.CreatedAt, q.After.ID)
+	}
+	return query.
+		OrderExpr(`created_at desc, id collate "C" desc`).
+		Limit(int(q.PageSize) + 1)
+}
+
+func parseTimeQueryParam(r *http.Request, name string) (*time.Time, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", name, err)
+	}
+	return &t, nil
+}
+
+func readListTokensQuery(r *http.Request) (*listTokensQuery, error) {
+	return bunpaginate.Extract[listTokensQuery](r, func() (*listTokensQuery, error) {
+		pageSize, err := bunpaginate.GetPageSize(r)
+		if err != nil {
+			return nil, err
+		}
+
+		query := &listTokensQuery{
+			Filters: tokenFilters{
+				TokenType: r.URL.Query().Get("type"),
+				ClientID:  r.URL.Query().Get("clientId"),
+				UserID:    r.URL.Query().Get("userId"),
+			},
+			PageSize: pageSize,
+		}
+		switch query.Filters.TokenType {
+		case "", tokenTypeAccessToken, tokenTypeRefreshToken:
+		default:
+			return nil, fmt.Errorf("invalid type '%s', expected '%s' or '%s'",
+				query.Filters.TokenType, tokenTypeAccessToken, tokenTypeRefreshToken)
+		}
+
+		query.Filters.ExpiresBefore, err = parseTimeQueryParam(r, "expiresBefore")
+		if err != nil {
+			return nil, err
+		}
+		query.Filters.ExpiresAfter, err = parseTimeQueryParam(r, "expiresAfter")
+		if err != nil {
+			return nil, err
+		}
+		return query, nil
+	})
+}
+
+// listTokens lists the issued access and refresh tokens, newest first.
+// Rotated refresh tokens are only kept to detect replays and are not listed.
+func listTokens(db *bun.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query, err := readListTokensQuery(r)
+		if err != nil {
+			validationError(w, r, err)
+			return
+		}
+
+		tokens := make([]tokenView, 0)
+		if query.Filters.TokenType != tokenTypeRefreshToken {
+			accessTokens := make([]auth.AccessToken, 0)
+			if err := query.apply(db.NewSelect().Model(&accessTokens)).
+				Scan(r.Context()); err != nil {
+				internalServerError(w, r, err)
+				return
+			}
+			tokens = append(tokens, mapList(accessTokens, mapAccessToken)...)
+		}
+		if query.Filters.TokenType != tokenTypeAccessToken {
+			refreshTokens := make([]auth.RefreshToken, 0)
+			if err := query.apply(db.NewSelect().Model(&refreshTokens)).
+				Where("rotated_at is null").
+				Scan(r.Context()); err != nil {
+				internalServerError(w, r, err)
+				return
+			}
+			tokens = append(tokens, mapList(refreshTokens, mapRefreshToken)...)
+		}
+
+		sort.Slice(tokens, func(i, j int) bool {
+			return tokens[i].position().before(tokens[j].position())
+		})
+
+		cursor := bunpaginate.Cursor[tokenView]{
+			PageSize: int(query.PageSize),
+			HasMore:  len(tokens) > int(query.PageSize),
+			Data:     tokens,
+		}
+		if cursor.HasMore {
+			cursor.Data = tokens[:query.PageSize]
+			next := *query
+			next.After = pointer.For(cursor.Data[len(cursor.Data)-1].position())
+			cursor.Next = bunpaginate.EncodeCursor(next)
+		}
+
+		writeJSONCursor(w, r, cursor)
+	}
+}
+
+// revokeToken revokes a token by id.
+// Revoking a refresh token revokes its whole family and the access tokens issued from it.
+func revokeToken(db *bun.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tokenID := chi.URLParam(r, "tokenId")
+
+		ret, err := db.NewDelete().
+			Model(&auth.AccessToken{}).
+			Where("id = ?", tokenID).
+			Exec(r.Context())
+		if err != nil {
+			internalServerError(w, r, err)
+			return
+		}
+		if rowsAffected, err := ret.RowsAffected(); err != nil {
+			internalServerError(w, r, err)
+			return
+		} else if rowsAffected > 0 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		refreshToken := &auth.RefreshToken{}
+		if err := db.NewSelect().
+			Model(refreshToken).
+			Where("id = ?", tokenID).
+			Scan(r.Context()); err != nil {
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				w.WriteHeader(http.StatusNotFound)
+			default:
+				internalServerError(w, r, err)
+			}
+			return
+		}
+
+		err = db.RunInTx(r.Context(), nil, func(ctx context.Context, tx bun.Tx) error {
+			_, err := tx.NewDelete().
+				Model(&auth.AccessToken{}).
+				Where("refresh_token_id in (?)", tx.NewSelect().
+					Model(&auth.RefreshToken{}).
+					Column("id").
+					Where("family_id = ?", refreshToken.FamilyID)).
+				Exec(ctx)
+			if err != nil {
+				return err
+			}
+
+			_, err = tx.NewDelete().
+				Model(&auth.RefreshToken{}).
+				Where("family_id = ?", refreshToken.FamilyID).
+				Exec(ctx)
+			return err
+		})
+		if err != nil {
+			internalServerError(w, r, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// revokeTokens revokes in bulk all the tokens of a user and/or a client.
+// At least one filter is required to avoid revoking every token by mistake.
+func revokeTokens(db *bun.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		clientID := r.URL.Query().Get("clientId")
+		userID := r.URL.Query().Get("userId")
+		if clientID == "" && userID == "" {
+			validationError(w, r, errors.New("at least one of clientId or userId is required"))
+			return
+		}
+
+		err := db.RunInTx(r.Context(), nil, func(ctx context.Context, tx bun.Tx) error {
+			for _, model := range []any{(*auth.AccessToken)(nil), (*auth.RefreshToken)(nil)} {
+				query := tx.NewDelete().Model(model)
+				if clientID != "" {
+					query = query.Where("application_id = ?", clientID)
+				}
+				if userID != "" {
+					query = query.Where("user_id = ?", userID)
+				}
+				if _, err := query.Exec(ctx); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			internalServerError(w, r, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
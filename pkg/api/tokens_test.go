@@ -0,0 +1,219 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+
+	auth "github.com/formancehq/auth/pkg"
+)
+
+type testTokens struct {
+	refreshToken        *auth.RefreshToken
+	rotatedRefreshToken *auth.RefreshToken
+	accessToken         *auth.AccessToken
+	otherAccessToken    *auth.AccessToken
+}
+
+func insertTestTokens(t *testing.T, db *bun.DB) testTokens {
+	now := time.Now().Round(time.Microsecond).UTC()
+	familyID := uuid.NewString()
+	rotatedAt := now.Add(-time.Minute)
+
+	tokens := testTokens{
+		rotatedRefreshToken: &auth.RefreshToken{
+			ID:            uuid.NewString(),
+			FamilyID:      familyID,
+			CreatedAt:     now.Add(-2 * time.Minute),
+			UserID:        user1.ID,
+			ApplicationID: "client1",
+			Expiration:    now.Add(time.Hour),
+			RotatedAt:     &rotatedAt,
+		},
+		refreshToken: &auth.RefreshToken{
+			ID:            uuid.NewString(),
+			FamilyID:      familyID,
+			CreatedAt:     now.Add(-time.Minute),
+			UserID:        user1.ID,
+			ApplicationID: "client1",
+			Expiration:    now.Add(time.Hour),
+		},
+	}
+	tokens.accessToken = &auth.AccessToken{
+		ID:             uuid.NewString(),
+		CreatedAt:      now,
+		UserID:         user1.ID,
+		ApplicationID:  "client1",
+		Expiration:     now.Add(5 * time.Minute),
+		RefreshTokenID: tokens.refreshToken.ID,
+	}
+	tokens.otherAccessToken = &auth.AccessToken{
+		ID:            uuid.NewString(),
+		CreatedAt:     now,
+		ApplicationID: "client2",
+		Expiration:    now.Add(time.Hour),
+	}
+
+	for _, model := range []any{
+		tokens.rotatedRefreshToken, tokens.refreshToken, tokens.accessToken, tokens.otherAccessToken,
+	} {
+		_, err := db.NewInsert().Model(model).Exec(context.Background())
+		require.NoError(t, err)
+	}
+
+	return tokens
+}
+
+func TestListTokens(t *testing.T) {
+	withDbAndRouter(t, addTokenRoutes, func(router chi.Router, db *bun.DB) {
+		tokens := insertTestTokens(t, db)
+
+		type testCase struct {
+			name        string
+			query       url.Values
+			expectedIDs []string
+		}
+		for _, tc := range []testCase{
+			{
+				name: "all tokens",
+				expectedIDs: []string{
+					tokens.accessToken.ID, tokens.otherAccessToken.ID, tokens.refreshToken.ID,
+				},
+			},
+			{
+				name:        "by type",
+				query:       url.Values{"type": []string{"refresh_token"}},
+				expectedIDs: []string{tokens.refreshToken.ID},
+			},
+			{
+				name:        "by client",
+				query:       url.Values{"clientId": []string{"client2"}},
+				expectedIDs: []string{tokens.otherAccessToken.ID},
+			},
+			{
+				name:        "by user",
+				query:       url.Values{"userId": []string{user1.ID}},
+				expectedIDs: []string{tokens.accessToken.ID, tokens.refreshToken.ID},
+			},
+			{
+				name: "by expiration",
+				query: url.Values{
+					"expiresBefore": []string{time.Now().Add(10 * time.Minute).Format(time.RFC3339)},
+				},
+				expectedIDs: []string{tokens.accessToken.ID},
+			},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				req := httptest.NewRequest(http.MethodGet, "/tokens?"+tc.query.Encode(), nil)
+				res := httptest.NewRecorder()
+				router.ServeHTTP(res, req)
+				require.Equal(t, http.StatusOK, res.Code)
+
+				cursor := readTestCursor[tokenView](t, res)
+				require.False(t, cursor.HasMore)
+				require.ElementsMatch(t, tc.expectedIDs, mapList(cursor.Data, func(v tokenView) string {
+					return v.ID
+				}))
+			})
+		}
+
+		t.Run("paginated", func(t *testing.T) {
+			ids := make([]string, 0)
+			query := url.Values{"pageSize": []string{"2"}}
+			for {
+				req := httptest.NewRequest(http.MethodGet, "/tokens?"+query.Encode(), nil)
+				res := httptest.NewRecorder()
+				router.ServeHTTP(res, req)
+				require.Equal(t, http.StatusOK, res.Code)
+
+				cursor := readTestCursor[tokenView](t, res)
+				require.LessOrEqual(t, len(cursor.Data), 2)
+				ids = append(ids, mapList(cursor.Data, func(v tokenView) string {
+					return v.ID
+				})...)
+				if !cursor.HasMore {
+					break
+				}
+				query = url.Values{"cursor": []string{cursor.Next}}
+			}
+
+			sameTime := []string{tokens.accessToken.ID, tokens.otherAccessToken.ID}
+			if sameTime[0] < sameTime[1] {
+				sameTime[0], sameTime[1] = sameTime[1], sameTime[0]
+			}
+			require.Equal(t, append(sameTime, tokens.refreshToken.ID), ids)
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/tokens?expiresAfter=yesterday", nil)
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		require.Equal(t, http.StatusBadRequest, res.Code)
+	})
+}
+
+func TestRevokeToken(t *testing.T) {
+	withDbAndRouter(t, addTokenRoutes, func(router chi.Router, db *bun.DB) {
+		tokens := insertTestTokens(t, db)
+
+		req := httptest.NewRequest(http.MethodDelete, "/tokens/"+tokens.otherAccessToken.ID, nil)
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		require.Equal(t, http.StatusNoContent, res.Code)
+
+		exists, err := db.NewSelect().Model(&auth.AccessToken{}).Where("id = ?", tokens.otherAccessToken.ID).Exists(context.Background())
+		require.NoError(t, err)
+		require.False(t, exists)
+
+		// Revoking a refresh token revokes its family and the access tokens issued from it
+		req = httptest.NewRequest(http.MethodDelete, "/tokens/"+tokens.refreshToken.ID, nil)
+		res = httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		require.Equal(t, http.StatusNoContent, res.Code)
+
+		count, err := db.NewSelect().Model(&auth.RefreshToken{}).Count(context.Background())
+		require.NoError(t, err)
+		require.Zero(t, count)
+
+		count, err = db.NewSelect().Model(&auth.AccessToken{}).Count(context.Background())
+		require.NoError(t, err)
+		require.Zero(t, count)
+
+		req = httptest.NewRequest(http.MethodDelete, "/tokens/"+uuid.NewString(), nil)
+		res = httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		require.Equal(t, http.StatusNotFound, res.Code)
+	})
+}
+
+func TestRevokeTokens(t *testing.T) {
+	withDbAndRouter(t, addTokenRoutes, func(router chi.Router, db *bun.DB) {
+		tokens := insertTestTokens(t, db)
+
+		req := httptest.NewRequest(http.MethodDelete, "/tokens", nil)
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		require.Equal(t, http.StatusBadRequest, res.Code)
+
+		req = httptest.NewRequest(http.MethodDelete, "/tokens?clientId=client1", nil)
+		res = httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		require.Equal(t, http.StatusNoContent, res.Code)
+
+		count, err := db.NewSelect().Model(&auth.RefreshToken{}).Count(context.Background())
+		require.NoError(t, err)
+		require.Zero(t, count)
+
+		accessTokens := make([]auth.AccessToken, 0)
+		require.NoError(t, db.NewSelect().Model(&accessTokens).Scan(context.Background()))
+		require.Len(t, accessTokens, 1)
+		require.Equal(t, tokens.otherAccessToken.ID, accessTokens[0].ID)
+	})
+}
@@ -1,6 +1,9 @@
 package api
 
 import (
+	"context"
+	"database/sql"
+	"errors"
 	authlib "github.com/formancehq/go-libs/v3/auth"
 	"net/http"
 
@@ -13,9 +16,14 @@ import (
 
 func addUserRoutes(db *bun.DB, r chi.Router, authenticator authlib.Authenticator) {
 	r.With(authlib.Middleware(authenticator)).Route("/users", func(r chi.Router) {
+		r.Post("/", createUser(db))
 		r.Get("/", listUsers(db))
 		r.Route("/{userId}", func(r chi.Router) {
+			r.Put("/", updateUser(db))
+			r.Delete("/", deleteUser(db))
 			r.Get("/", readUser(db))
+			r.Post("/disable", setUserDisabled(db, true))
+			r.Post("/enable", setUserDisabled(db, false))
 		})
 	})
 }
@@ -43,3 +51,134 @@ func readUser(db *bun.DB) http.HandlerFunc {
 		writeJSONObject(w, r, user)
 	}
 }
+
+func createUser(db *bun.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		opts := readJSONObject[auth.UserOptions](w, r)
+		if opts == nil {
+			return
+		}
+		if err := opts.Validate(); err != nil {
+			validationError(w, r, err)
+			return
+		}
+
+		user := auth.NewUser(*opts)
+		if err := createObject(w, r, db, user); err != nil {
+			return
+		}
+
+		writeCreatedJSONObject(w, r, user, user.ID)
+	}
+}
+
+func updateUser(db *bun.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := findById[*auth.User](w, r, db, "userId")
+		if user == nil {
+			return
+		}
+
+		opts := readJSONObject[auth.UserOptions](w, r)
+		if opts == nil {
+			return
+		}
+		if err := opts.Validate(); err != nil {
+			validationError(w, r, err)
+			return
+		}
+
+		user.Update(*opts)
+
+		_, err := db.NewUpdate().
+			Model(user).
+			Where("id = ?", user.ID).
+			Exec(r.Context())
+		if err != nil {
+			storageError(w, r, err)
+			return
+		}
+
+		writeJSONObject(w, r, user)
+	}
+}
+
+// deleteUser deletes a user and its memberships, and revokes its tokens
+func deleteUser(db *bun.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := chi.URLParam(r, "userId")
+		err := db.RunInTx(r.Context(), nil, func(ctx context.Context, tx bun.Tx) error {
+			res, err := tx.NewDelete().
+				Model(&auth.User{}).
+				Where("id = ?", userID).
+				Exec(ctx)
+			if err != nil {
+				return err
+			}
+			if rowsAffected, err := res.RowsAffected(); err != nil {
+				return err
+			} else if rowsAffected == 0 {
+				return sql.ErrNoRows
+			}
+			if _, err := tx.NewDelete().
+				Model((*auth.GroupMember)(nil)).
+				Where("user_id = ?", userID).
+				Exec(ctx); err != nil {
+				return err
+			}
+			return revokeUserTokens(ctx, tx, userID)
+		})
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			w.WriteHeader(http.StatusNotFound)
+		case err != nil:
+			internalServerError(w, r, err)
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}
+}
+
+// setUserDisabled disables or enables a user, the tokens of a disabled user are revoked
+func setUserDisabled(db *bun.DB, disabled bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := findById[*auth.User](w, r, db, "userId")
+		if user == nil {
+			return
+		}
+
+		user.Disabled = disabled
+
+		err := db.RunInTx(r.Context(), nil, func(ctx context.Context, tx bun.Tx) error {
+			if _, err := tx.NewUpdate().
+				Model(user).
+				Column("disabled").
+				Where("id = ?", user.ID).
+				Exec(ctx); err != nil {
+				return err
+			}
+			if !disabled {
+				return nil
+			}
+			return revokeUserTokens(ctx, tx, user.ID)
+		})
+		if err != nil {
+			internalServerError(w, r, err)
+			return
+		}
+
+		writeJSONObject(w, r, user)
+	}
+}
+
+func revokeUserTokens(ctx context.Context, tx bun.Tx, userID string) error {
+	for _, model := range []any{(*auth.AccessToken)(nil), (*auth.RefreshToken)(nil)} {
+		if _, err := tx.NewDelete().
+			Model(model).
+			Where("user_id = ?", userID).
+			Exec(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package api
 import (
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"reflect"
 
@@ -11,7 +12,9 @@ import (
 	"github.com/uptrace/bun"
 
 	"github.com/formancehq/go-libs/v3/api"
+	"github.com/formancehq/go-libs/v3/bun/bunpaginate"
 	"github.com/formancehq/go-libs/v3/logging"
+	"github.com/formancehq/go-libs/v3/platform/postgres"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -25,6 +28,25 @@ func validationError(w http.ResponseWriter, r *http.Request, err error) {
 	}
 }
 
+func conflictError(w http.ResponseWriter, r *http.Request, err error) {
+	w.WriteHeader(http.StatusConflict)
+	if err := json.NewEncoder(w).Encode(api.ErrorResponse{
+		ErrorCode:    "CONFLICT",
+		ErrorMessage: err.Error(),
+	}); err != nil {
+		trace.SpanFromContext(r.Context()).RecordError(err)
+	}
+}
+
+// storageError writes the error of a write query, the violations of the unique constraints are conflicts
+func storageError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(postgres.ResolveError(err), postgres.ErrConstraintsFailed{}) {
+		conflictError(w, r, err)
+		return
+	}
+	internalServerError(w, r, err)
+}
+
 func internalServerError(w http.ResponseWriter, r *http.Request, err error) {
 	w.WriteHeader(http.StatusInternalServerError)
 	if err := json.NewEncoder(w).Encode(api.ErrorResponse{
@@ -43,6 +65,14 @@ func writeJSONObject[T any](w http.ResponseWriter, r *http.Request, v T) {
 	}
 }
 
+func writeJSONCursor[T any](w http.ResponseWriter, r *http.Request, cursor bunpaginate.Cursor[T]) {
+	if err := json.NewEncoder(w).Encode(api.BaseResponse[T]{
+		Cursor: &cursor,
+	}); err != nil {
+		trace.SpanFromContext(r.Context()).RecordError(err)
+	}
+}
+
 func writeCreatedJSONObject(w http.ResponseWriter, r *http.Request, v any, id string) {
 	w.WriteHeader(http.StatusCreated)
 	w.Header().Set("Location", "./"+id)
@@ -83,7 +113,7 @@ func findById[T any](w http.ResponseWriter, r *http.Request, db *bun.DB, params
 func createObject(w http.ResponseWriter, r *http.Request, db *bun.DB, v any) error {
 	_, err := db.NewInsert().Model(v).Exec(r.Context())
 	if err != nil {
-		internalServerError(w, r, err)
+		storageError(w, r, err)
 	}
 	return err
 }
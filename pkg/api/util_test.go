@@ -2,13 +2,23 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"io"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/formancehq/go-libs/v3/api"
+	authlib "github.com/formancehq/go-libs/v3/auth"
+	"github.com/formancehq/go-libs/v3/bun/bunconnect"
+	"github.com/formancehq/go-libs/v3/bun/bundebug"
+	"github.com/formancehq/go-libs/v3/bun/bunpaginate"
+	"github.com/formancehq/go-libs/v3/logging"
+	"github.com/go-chi/chi/v5"
 	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+
+	"github.com/formancehq/auth/pkg/storage/sqlstorage"
 )
 
 func createJSONBuffer(t *testing.T, v any) io.Reader {
@@ -23,3 +33,35 @@ func readTestResponse[T any](t *testing.T, recorder *httptest.ResponseRecorder)
 	require.NoError(t, json.NewDecoder(recorder.Body).Decode(&body))
 	return *body.Data
 }
+
+func readTestCursor[T any](t *testing.T, recorder *httptest.ResponseRecorder) *bunpaginate.Cursor[T] {
+	body := api.BaseResponse[T]{}
+	require.NoError(t, json.NewDecoder(recorder.Body).Decode(&body))
+	require.NotNil(t, body.Cursor)
+	return body.Cursor
+}
+
+// withDbAndRouter runs the callback with a migrated database and a router serving the routes added by addRoutes
+func withDbAndRouter(t *testing.T, addRoutes func(db *bun.DB, router chi.Router, authenticator authlib.Authenticator),
+	callback func(router chi.Router, db *bun.DB)) {
+	t.Parallel()
+
+	hooks := make([]bun.QueryHook, 0)
+	if testing.Verbose() {
+		hooks = append(hooks, bundebug.NewQueryHook())
+	}
+
+	pgDatabase := srv.NewDatabase(t)
+	db, err := bunconnect.OpenSQLDB(logging.TestingContext(), bunconnect.ConnectionOptions{
+		DatabaseSourceName: pgDatabase.ConnString(),
+	}, hooks...)
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	require.NoError(t, sqlstorage.Migrate(context.Background(), db))
+
+	router := chi.NewRouter()
+	addRoutes(db, router, authlib.NewNoAuth())
+
+	callback(router, db)
+}
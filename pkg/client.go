@@ -1,35 +1,65 @@
 package auth
 
 import (
-	"crypto/sha256"
-	"encoding/base64"
+	"crypto/subtle"
 	"errors"
+	"fmt"
+	"net/url"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/uptrace/bun"
 
+	"github.com/formancehq/go-libs/v3/collectionutils"
 	"github.com/google/uuid"
+	"github.com/zitadel/oidc/v2/pkg/oidc"
 )
 
-func newHash(v string) string {
-	digest := sha256.New()
-	digest.Write([]byte(v))
-	hash := digest.Sum(nil)
+const (
+	// DefaultSecretRotationGracePeriod is the delay during which a rotated secret is still accepted
+	DefaultSecretRotationGracePeriod = 24 * time.Hour
+	// SecretLastUsedAtPrecision is the precision of ClientSecret.LastUsedAt, to avoid writing the client on each authentication
+	SecretLastUsedAtPrecision = time.Hour
+)
 
-	return base64.StdEncoding.EncodeToString(hash)
+// OptionalGrantTypes are the grant types a client must opt in with ClientOptions.GrantTypes,
+// the token exchange grant is enabled with ClientOptions.TokenExchange and the others depending on the type of the client
+var OptionalGrantTypes = []string{
+	string(oidc.GrantTypeDeviceCode),
 }
 
 type ClientSecret struct {
-	ID         string   `json:"id"`
-	Hash       string   `json:"hash"`
-	LastDigits string   `json:"lastDigits"`
-	Name       string   `json:"name"`
-	Metadata   Metadata `json:"metadata" bun:"type:text"`
+	ID         string     `json:"id"`
+	Hash       string     `json:"hash"`
+	LastDigits string     `json:"lastDigits"`
+	Name       string     `json:"name"`
+	Metadata   Metadata   `json:"metadata" bun:"type:text"`
+	ExpiresAt  *time.Time `json:"expiresAt,omitempty"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
 }
 
 func (s ClientSecret) Check(clear string) bool {
-	return s.Hash == newHash(clear)
+	return checkHash(s.Hash, clear)
+}
+
+func (s ClientSecret) IsExpired(now time.Time) bool {
+	return s.ExpiresAt != nil && !now.Before(*s.ExpiresAt)
+}
+
+// MarkUsed records the use of the secret and upgrades its hash if it uses the legacy hashing or outdated parameters.
+// It returns true if the secret has been modified and must be saved.
+func (s *ClientSecret) MarkUsed(clear string, now time.Time) bool {
+	modified := false
+	if hashNeedsUpgrade(s.Hash) {
+		s.Hash = newHash(clear)
+		modified = true
+	}
+	if s.LastUsedAt == nil || now.Sub(*s.LastUsedAt) >= SecretLastUsedAtPrecision {
+		s.LastUsedAt = &now
+		modified = true
+	}
+	return modified
 }
 
 func newSecret(opts SecretCreate) (ClientSecret, string) {
@@ -40,6 +70,7 @@ func newSecret(opts SecretCreate) (ClientSecret, string) {
 		LastDigits: clear[len(clear)-4:],
 		Name:       opts.Name,
 		Metadata:   opts.Metadata,
+		ExpiresAt:  opts.ExpiresAt,
 	}, clear
 }
 
@@ -52,6 +83,17 @@ func (c *Client) Update(opts ClientOptions) {
 	c.Trusted = opts.Trusted
 	c.Public = opts.Public
 	c.Scopes = opts.Scopes
+	c.AccessTokenLifetime = opts.AccessTokenLifetime
+	c.IDTokenLifetime = opts.IDTokenLifetime
+	c.RefreshTokenLifetime = opts.RefreshTokenLifetime
+	c.RefreshTokenIdleLifetime = opts.RefreshTokenIdleLifetime
+	c.JWKS = opts.JWKS
+	c.JWKSURI = opts.JWKSURI
+	c.TLSClientAuth = opts.TLSClientAuth
+	c.RequireDPoP = opts.RequireDPoP
+	c.GrantTypes = opts.GrantTypes
+	c.TokenExchange = opts.TokenExchange
+	c.RequirePAR = opts.RequirePAR
 }
 
 func (c *Client) GenerateNewSecret(opts SecretCreate) (ClientSecret, string) {
@@ -61,6 +103,29 @@ func (c *Client) GenerateNewSecret(opts SecretCreate) (ClientSecret, string) {
 	return secret, clear
 }
 
+// RotateSecret generates a new secret and schedules the expiration of the secret with the given id after the grace period.
+// The expiration of the rotated secret is never postponed.
+func (c *Client) RotateSecret(id string, opts SecretRotate, now time.Time) (ClientSecret, string, bool) {
+	for i, secret := range c.Secrets {
+		if secret.ID != id {
+			continue
+		}
+
+		gracePeriod := opts.GetGracePeriod()
+		if gracePeriod == 0 {
+			gracePeriod = DefaultSecretRotationGracePeriod
+		}
+		expiresAt := now.Add(gracePeriod)
+		if secret.ExpiresAt == nil || expiresAt.Before(*secret.ExpiresAt) {
+			c.Secrets[i].ExpiresAt = &expiresAt
+		}
+
+		newSecret, clear := c.GenerateNewSecret(opts.SecretCreate)
+		return newSecret, clear, true
+	}
+	return ClientSecret{}, "", false
+}
+
 func (c *Client) ValidateSecret(secret string) error {
 	if !c.HasSecret(secret) {
 		return errors.New("invalid secret")
@@ -69,12 +134,18 @@ func (c *Client) ValidateSecret(secret string) error {
 }
 
 func (c *Client) HasSecret(clear string) bool {
-	for _, secret := range c.Secrets {
-		if secret.Check(clear) {
-			return true
+	return c.FindSecret(clear) != nil
+}
+
+// FindSecret returns the non expired secret matching the clear value
+func (c *Client) FindSecret(clear string) *ClientSecret {
+	now := time.Now()
+	for i, secret := range c.Secrets {
+		if !secret.IsExpired(now) && secret.Check(clear) {
+			return &c.Secrets[i]
 		}
 	}
-	return false
+	return nil
 }
 
 func (c *Client) DeleteSecret(id string) bool {
@@ -140,7 +211,7 @@ func (c StaticClient) FromEnvironment() (StaticClient, error) {
 
 func (s StaticClient) ValidateSecret(secret string) error {
 	for _, clientSecret := range s.Secrets {
-		if clientSecret == secret {
+		if subtle.ConstantTimeCompare([]byte(clientSecret), []byte(secret)) == 1 {
 			return nil
 		}
 	}
@@ -161,6 +232,132 @@ type ClientOptions struct {
 	Metadata               Metadata      `json:"metadata" yaml:"metadata" bun:"type:text"`
 	Trusted                bool          `json:"trusted" yaml:"trusted"`
 	Scopes                 Array[string] `bun:"type:text" json:"scopes" yaml:"scopes"`
+	// Token lifetimes in seconds, zero means the server default is used
+	AccessTokenLifetime      int64 `json:"accessTokenLifetime" yaml:"accessTokenLifetime"`
+	IDTokenLifetime          int64 `json:"idTokenLifetime" yaml:"idTokenLifetime" bun:"id_token_lifetime"`
+	RefreshTokenLifetime     int64 `json:"refreshTokenLifetime" yaml:"refreshTokenLifetime"`
+	RefreshTokenIdleLifetime int64 `json:"refreshTokenIdleLifetime" yaml:"refreshTokenIdleLifetime"`
+	// Public keys used to verify the client assertions (private_key_jwt), either inline or fetched from an url
+	JWKS    *JSONWebKeySet `json:"jwks,omitempty" yaml:"jwks" bun:"jwks,type:text"`
+	JWKSURI string         `json:"jwksUri" yaml:"jwksUri" bun:"jwks_uri"`
+	// Mutual TLS client authentication, replaces the secrets and the assertions
+	TLSClientAuth *TLSClientAuth `json:"tlsClientAuth,omitempty" yaml:"tlsClientAuth" bun:"tls_client_auth,type:text"`
+	// RequireDPoP rejects the token requests of the client without DPoP proof, so all its tokens are sender-constrained
+	RequireDPoP bool `json:"requireDpop" yaml:"requireDpop" bun:"require_dpop"`
+	// GrantTypes enables optional grant types for the client, see OptionalGrantTypes
+	GrantTypes Array[string] `json:"grantTypes,omitempty" yaml:"grantTypes" bun:"grant_types,type:text"`
+	// TokenExchange enables the token exchange grant, with the tokens the client can exchange and obtain
+	TokenExchange *TokenExchangePolicy `json:"tokenExchange,omitempty" yaml:"tokenExchange" bun:"token_exchange,type:text"`
+	// RequirePAR rejects the authorization requests of the client which have not been pushed to the par endpoint first
+	RequirePAR bool `json:"requirePar" yaml:"requirePar" bun:"require_par"`
+}
+
+func (c *ClientOptions) Validate() error {
+	for _, lifetime := range []struct {
+		name  string
+		value int64
+	}{
+		{"accessTokenLifetime", c.AccessTokenLifetime},
+		{"idTokenLifetime", c.IDTokenLifetime},
+		{"refreshTokenLifetime", c.RefreshTokenLifetime},
+		{"refreshTokenIdleLifetime", c.RefreshTokenIdleLifetime},
+	} {
+		if lifetime.value < 0 {
+			return fmt.Errorf("invalid %s %d, it must not be negative", lifetime.name, lifetime.value)
+		}
+	}
+	if c.JWKS != nil && c.JWKSURI != "" {
+		return errors.New("jwks and jwksUri are mutually exclusive")
+	}
+	if c.JWKS != nil {
+		if err := c.JWKS.Validate(); err != nil {
+			return fmt.Errorf("invalid jwks: %w", err)
+		}
+	}
+	if c.JWKSURI != "" {
+		u, err := url.Parse(c.JWKSURI)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return fmt.Errorf("invalid jwksUri '%s'", c.JWKSURI)
+		}
+	}
+	if c.TLSClientAuth != nil {
+		if c.Public {
+			return errors.New("public clients cannot use tls client authentication")
+		}
+		if err := c.TLSClientAuth.Validate(); err != nil {
+			return fmt.Errorf("invalid tlsClientAuth: %w", err)
+		}
+		if c.TLSClientAuth.SelfSigned && c.JWKS == nil && c.JWKSURI == "" {
+			return errors.New("self signed certificates must be registered with jwks or jwksUri")
+		}
+	}
+	if c.TokenExchange != nil {
+		if c.Public {
+			return errors.New("public clients cannot use token exchange")
+		}
+		if err := c.TokenExchange.Validate(); err != nil {
+			return fmt.Errorf("invalid tokenExchange: %w", err)
+		}
+	}
+	for _, grantType := range c.GrantTypes {
+		if !collectionutils.Contains(OptionalGrantTypes, grantType) {
+			return fmt.Errorf("unsupported grant type '%s'", grantType)
+		}
+	}
+	return nil
+}
+
+// UsesPrivateKeyJWT returns true if the client authenticates with signed assertions instead of secrets
+func (c *ClientOptions) UsesPrivateKeyJWT() bool {
+	if c.TLSClientAuth != nil {
+		// the key set holds the self signed certificates
+		return false
+	}
+	return (c.JWKS != nil && len(c.JWKS.Keys) > 0) || c.JWKSURI != ""
+}
+
+func (c *ClientOptions) GetGrantTypes() []string {
+	return c.GrantTypes
+}
+
+func (c *ClientOptions) GetTokenExchangePolicy() *TokenExchangePolicy {
+	return c.TokenExchange
+}
+
+func (c *ClientOptions) IsPARRequired() bool {
+	return c.RequirePAR
+}
+
+func (c *ClientOptions) IsDPoPRequired() bool {
+	return c.RequireDPoP
+}
+
+func (c *ClientOptions) GetTLSClientAuth() *TLSClientAuth {
+	return c.TLSClientAuth
+}
+
+func (c *ClientOptions) GetJWKS() *JSONWebKeySet {
+	return c.JWKS
+}
+
+func (c *ClientOptions) GetJWKSURI() string {
+	return c.JWKSURI
+}
+
+func (c *ClientOptions) GetAccessTokenLifetime() time.Duration {
+	return time.Duration(c.AccessTokenLifetime) * time.Second
+}
+
+func (c *ClientOptions) GetIDTokenLifetime() time.Duration {
+	return time.Duration(c.IDTokenLifetime) * time.Second
+}
+
+func (c *ClientOptions) GetRefreshTokenLifetime() time.Duration {
+	return time.Duration(c.RefreshTokenLifetime) * time.Second
+}
+
+func (c *ClientOptions) GetRefreshTokenIdleLifetime() time.Duration {
+	return time.Duration(c.RefreshTokenIdleLifetime) * time.Second
 }
 
 func (s *ClientOptions) IsTrusted() bool {
@@ -196,6 +393,24 @@ func NewClient(opts ClientOptions) *Client {
 }
 
 type SecretCreate struct {
-	Name     string   `json:"name"`
-	Metadata Metadata `json:"metadata"`
+	Name      string     `json:"name"`
+	Metadata  Metadata   `json:"metadata"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+type SecretRotate struct {
+	SecretCreate
+	// GracePeriod is the delay, in seconds, during which the rotated secret is still accepted, zero means the default
+	GracePeriod int64 `json:"gracePeriod"`
+}
+
+func (s SecretRotate) Validate() error {
+	if s.GracePeriod < 0 {
+		return fmt.Errorf("invalid gracePeriod %d, it must not be negative", s.GracePeriod)
+	}
+	return nil
+}
+
+func (s SecretRotate) GetGracePeriod() time.Duration {
+	return time.Duration(s.GracePeriod) * time.Second
 }
@@ -2,11 +2,31 @@
 
 package components
 
+import (
+	"github.com/formancehq/auth/pkg/client/internal/utils"
+	"time"
+)
+
 type ClientSecret struct {
 	LastDigits string            `json:"lastDigits"`
 	Name       string            `json:"name"`
 	ID         string            `json:"id"`
 	Metadata   map[string]string `json:"metadata,omitempty"`
+	// Date after which the secret is not accepted anymore
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+	// Last time the secret has been used to authenticate the client
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+}
+
+func (c ClientSecret) MarshalJSON() ([]byte, error) {
+	return utils.MarshalJSON(c, "", false)
+}
+
+func (c *ClientSecret) UnmarshalJSON(data []byte) error {
+	if err := utils.UnmarshalJSON(data, &c, "", false, false); err != nil {
+		return err
+	}
+	return nil
 }
 
 func (o *ClientSecret) GetLastDigits() string {
@@ -36,3 +56,17 @@ func (o *ClientSecret) GetMetadata() map[string]string {
 	}
 	return o.Metadata
 }
+
+func (o *ClientSecret) GetExpiresAt() *time.Time {
+	if o == nil {
+		return nil
+	}
+	return o.ExpiresAt
+}
+
+func (o *ClientSecret) GetLastUsedAt() *time.Time {
+	if o == nil {
+		return nil
+	}
+	return o.LastUsedAt
+}
@@ -10,7 +10,31 @@ type CreateClientRequest struct {
 	Trusted                *bool             `json:"trusted,omitempty"`
 	PostLogoutRedirectUris []string          `json:"postLogoutRedirectUris,omitempty"`
 	Metadata               map[string]string `json:"metadata,omitempty"`
-	Scopes                 []string          `json:"scopes,omitempty"`
+	// Scopes of the client, wildcards like ledger:* or *:read match the registered scopes
+	Scopes []string `json:"scopes,omitempty"`
+	// Access token lifetime in seconds, 0 to use the server default
+	AccessTokenLifetime *int64 `json:"accessTokenLifetime,omitempty"`
+	// ID token lifetime in seconds, 0 to use the server default
+	IDTokenLifetime *int64 `json:"idTokenLifetime,omitempty"`
+	// Refresh token absolute lifetime in seconds, 0 to use the server default
+	RefreshTokenLifetime *int64 `json:"refreshTokenLifetime,omitempty"`
+	// Refresh token idle lifetime in seconds, 0 to use the server default
+	RefreshTokenIdleLifetime *int64 `json:"refreshTokenIdleLifetime,omitempty"`
+	// Public keys used to verify the assertions of clients authenticating with private_key_jwt
+	Jwks *JSONWebKeySet `json:"jwks,omitempty"`
+	// URL of the public keys of the client, mutually exclusive with jwks
+	JwksURI *string `json:"jwksUri,omitempty"`
+	// Mutual TLS client authentication (RFC 8705). The certificate must match exactly one of the subject fields (tls_client_auth),
+	// or be registered in the jwks of the client if selfSigned is true (self_signed_tls_client_auth).
+	TLSClientAuth *TLSClientAuth `json:"tlsClientAuth,omitempty"`
+	// Require a DPoP proof on the token endpoint and bind the issued tokens to its key
+	RequireDpop *bool `json:"requireDpop,omitempty"`
+	// Optional grant types enabled for the client (urn:ietf:params:oauth:grant-type:device_code)
+	GrantTypes []string `json:"grantTypes,omitempty"`
+	// Enables the token exchange grant (RFC 8693) for a confidential client and restricts the tokens it can obtain.
+	TokenExchange *TokenExchangePolicy `json:"tokenExchange,omitempty"`
+	// Require the authorization requests to be pushed on the par endpoint (RFC 9126)
+	RequirePar *bool `json:"requirePar,omitempty"`
 }
 
 func (o *CreateClientRequest) GetPublic() *bool {
@@ -68,3 +92,80 @@ func (o *CreateClientRequest) GetScopes() []string {
 	}
 	return o.Scopes
 }
+
+func (o *CreateClientRequest) GetAccessTokenLifetime() *int64 {
+	if o == nil {
+		return nil
+	}
+	return o.AccessTokenLifetime
+}
+
+func (o *CreateClientRequest) GetIDTokenLifetime() *int64 {
+	if o == nil {
+		return nil
+	}
+	return o.IDTokenLifetime
+}
+
+func (o *CreateClientRequest) GetRefreshTokenLifetime() *int64 {
+	if o == nil {
+		return nil
+	}
+	return o.RefreshTokenLifetime
+}
+
+func (o *CreateClientRequest) GetRefreshTokenIdleLifetime() *int64 {
+	if o == nil {
+		return nil
+	}
+	return o.RefreshTokenIdleLifetime
+}
+
+func (o *CreateClientRequest) GetJwks() *JSONWebKeySet {
+	if o == nil {
+		return nil
+	}
+	return o.Jwks
+}
+
+func (o *CreateClientRequest) GetJwksURI() *string {
+	if o == nil {
+		return nil
+	}
+	return o.JwksURI
+}
+
+func (o *CreateClientRequest) GetTLSClientAuth() *TLSClientAuth {
+	if o == nil {
+		return nil
+	}
+	return o.TLSClientAuth
+}
+
+func (o *CreateClientRequest) GetRequireDpop() *bool {
+	if o == nil {
+		return nil
+	}
+	return o.RequireDpop
+}
+
+func (o *CreateClientRequest) GetGrantTypes() []string {
+	if o == nil {
+		return nil
+	}
+	return o.GrantTypes
+}
+
+func (o *CreateClientRequest) GetTokenExchange() *TokenExchangePolicy {
+	if o == nil {
+		return nil
+	}
+	return o.TokenExchange
+}
+
+func (o *CreateClientRequest) GetRequirePar() *bool {
+	if o == nil {
+		return nil
+	}
+	return o.RequirePar
+}
@@ -2,9 +2,27 @@
 
 package components
 
+import (
+	"github.com/formancehq/auth/pkg/client/internal/utils"
+	"time"
+)
+
 type CreateSecretRequest struct {
 	Name     string            `json:"name"`
 	Metadata map[string]string `json:"metadata,omitempty"`
+	// Date after which the secret is not accepted anymore
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+func (c CreateSecretRequest) MarshalJSON() ([]byte, error) {
+	return utils.MarshalJSON(c, "", false)
+}
+
+func (c *CreateSecretRequest) UnmarshalJSON(data []byte) error {
+	if err := utils.UnmarshalJSON(data, &c, "", false, false); err != nil {
+		return err
+	}
+	return nil
 }
 
 func (o *CreateSecretRequest) GetName() string {
@@ -20,3 +38,10 @@ func (o *CreateSecretRequest) GetMetadata() map[string]string {
 	}
 	return o.Metadata
 }
+
+func (o *CreateSecretRequest) GetExpiresAt() *time.Time {
+	if o == nil {
+		return nil
+	}
+	return o.ExpiresAt
+}
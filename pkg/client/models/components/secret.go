@@ -2,12 +2,30 @@
 
 package components
 
+import (
+	"github.com/formancehq/auth/pkg/client/internal/utils"
+	"time"
+)
+
 type Secret struct {
 	Name       string            `json:"name"`
 	Metadata   map[string]string `json:"metadata,omitempty"`
 	ID         string            `json:"id"`
 	LastDigits string            `json:"lastDigits"`
 	Clear      string            `json:"clear"`
+	// Date after which the secret is not accepted anymore
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+func (s Secret) MarshalJSON() ([]byte, error) {
+	return utils.MarshalJSON(s, "", false)
+}
+
+func (s *Secret) UnmarshalJSON(data []byte) error {
+	if err := utils.UnmarshalJSON(data, &s, "", false, false); err != nil {
+		return err
+	}
+	return nil
 }
 
 func (o *Secret) GetName() string {
@@ -44,3 +62,10 @@ func (o *Secret) GetClear() string {
 	}
 	return o.Clear
 }
+
+func (o *Secret) GetExpiresAt() *time.Time {
+	if o == nil {
+		return nil
+	}
+	return o.ExpiresAt
+}
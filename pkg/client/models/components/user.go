@@ -2,10 +2,38 @@
 
 package components
 
+import (
+	"github.com/formancehq/auth/pkg/client/internal/utils"
+	"time"
+)
+
 type User struct {
-	ID      *string `json:"id,omitempty"`
-	Subject *string `json:"subject,omitempty"`
-	Email   *string `json:"email,omitempty"`
+	ID *string `json:"id,omitempty"`
+	// Name of the upstream identity provider of the user
+	Provider      *string           `json:"provider,omitempty"`
+	Subject       *string           `json:"subject,omitempty"`
+	Email         *string           `json:"email,omitempty"`
+	EmailVerified *bool             `json:"emailVerified,omitempty"`
+	DisplayName   *string           `json:"displayName,omitempty"`
+	GivenName     *string           `json:"givenName,omitempty"`
+	FamilyName    *string           `json:"familyName,omitempty"`
+	Locale        *string           `json:"locale,omitempty"`
+	Metadata      map[string]string `json:"metadata,omitempty"`
+	// A disabled user can not log in
+	Disabled    *bool      `json:"disabled,omitempty"`
+	CreatedAt   *time.Time `json:"createdAt,omitempty"`
+	LastLoginAt *time.Time `json:"lastLoginAt,omitempty"`
+}
+
+func (u User) MarshalJSON() ([]byte, error) {
+	return utils.MarshalJSON(u, "", false)
+}
+
+func (u *User) UnmarshalJSON(data []byte) error {
+	if err := utils.UnmarshalJSON(data, &u, "", false, false); err != nil {
+		return err
+	}
+	return nil
 }
 
 func (o *User) GetID() *string {
@@ -15,6 +43,13 @@ func (o *User) GetID() *string {
 	return o.ID
 }
 
+func (o *User) GetProvider() *string {
+	if o == nil {
+		return nil
+	}
+	return o.Provider
+}
+
 func (o *User) GetSubject() *string {
 	if o == nil {
 		return nil
@@ -28,3 +63,66 @@ func (o *User) GetEmail() *string {
 	}
 	return o.Email
 }
+
+func (o *User) GetEmailVerified() *bool {
+	if o == nil {
+		return nil
+	}
+	return o.EmailVerified
+}
+
+func (o *User) GetDisplayName() *string {
+	if o == nil {
+		return nil
+	}
+	return o.DisplayName
+}
+
+func (o *User) GetGivenName() *string {
+	if o == nil {
+		return nil
+	}
+	return o.GivenName
+}
+
+func (o *User) GetFamilyName() *string {
+	if o == nil {
+		return nil
+	}
+	return o.FamilyName
+}
+
+func (o *User) GetLocale() *string {
+	if o == nil {
+		return nil
+	}
+	return o.Locale
+}
+
+func (o *User) GetMetadata() map[string]string {
+	if o == nil {
+		return nil
+	}
+	return o.Metadata
+}
+
+func (o *User) GetDisabled() *bool {
+	if o == nil {
+		return nil
+	}
+	return o.Disabled
+}
+
+func (o *User) GetCreatedAt() *time.Time {
+	if o == nil {
+		return nil
+	}
+	return o.CreatedAt
+}
+
+func (o *User) GetLastLoginAt() *time.Time {
+	if o == nil {
+		return nil
+	}
+	return o.LastLoginAt
+}
@@ -1,11 +1,22 @@
 package auth_test
 
 import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"net"
+	"net/url"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	auth "github.com/formancehq/auth/pkg"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/go-jose/go-jose.v2"
 )
 
 func TestStaticClientFromEnvironment(t *testing.T) {
@@ -59,3 +70,214 @@ func TestStaticClientFromEnvironment(t *testing.T) {
 		})
 	}
 }
+
+func TestClientSecretCheck(t *testing.T) {
+	client := auth.NewClient(auth.ClientOptions{})
+	secret, clear := client.GenerateNewSecret(auth.SecretCreate{})
+
+	require.True(t, strings.HasPrefix(secret.Hash, "$argon2id$v=19$"))
+	require.True(t, secret.Check(clear))
+	require.False(t, secret.Check("invalid"))
+	require.NoError(t, client.ValidateSecret(clear))
+	require.Error(t, client.ValidateSecret("invalid"))
+}
+
+func TestClientSecretMarkUsed(t *testing.T) {
+	const clear = "secret"
+	digest := sha256.Sum256([]byte(clear))
+
+	client := auth.NewClient(auth.ClientOptions{})
+	client.Secrets = append(client.Secrets, auth.ClientSecret{
+		ID:   "legacy",
+		Hash: base64.StdEncoding.EncodeToString(digest[:]),
+	})
+	require.NoError(t, client.ValidateSecret(clear))
+
+	// The legacy hash is upgraded on the first use
+	now := time.Now()
+	secret := client.FindSecret(clear)
+	require.NotNil(t, secret)
+	require.True(t, secret.MarkUsed(clear, now))
+	require.True(t, strings.HasPrefix(client.Secrets[0].Hash, "$argon2id$"))
+	require.Equal(t, &now, client.Secrets[0].LastUsedAt)
+	require.NoError(t, client.ValidateSecret(clear))
+
+	require.False(t, secret.MarkUsed(clear, now.Add(time.Minute)))
+	require.True(t, secret.MarkUsed(clear, now.Add(auth.SecretLastUsedAtPrecision)))
+}
+
+func TestClientSecretExpiration(t *testing.T) {
+	client := auth.NewClient(auth.ClientOptions{})
+	expiresAt := time.Now().Add(-time.Second)
+	_, expired := client.GenerateNewSecret(auth.SecretCreate{
+		ExpiresAt: &expiresAt,
+	})
+	require.Error(t, client.ValidateSecret(expired))
+}
+
+func TestClientRotateSecret(t *testing.T) {
+	client := auth.NewClient(auth.ClientOptions{})
+	secret, clear := client.GenerateNewSecret(auth.SecretCreate{})
+
+	_, _, ok := client.RotateSecret("unknown", auth.SecretRotate{}, time.Now())
+	require.False(t, ok)
+
+	now := time.Now()
+	newSecret, newClear, ok := client.RotateSecret(secret.ID, auth.SecretRotate{
+		SecretCreate: auth.SecretCreate{
+			Name: "rotated",
+		},
+	}, now)
+	require.True(t, ok)
+	require.Equal(t, "rotated", newSecret.Name)
+	require.Len(t, client.Secrets, 2)
+	require.Equal(t, now.Add(auth.DefaultSecretRotationGracePeriod), *client.Secrets[0].ExpiresAt)
+	require.NoError(t, client.ValidateSecret(clear))
+	require.NoError(t, client.ValidateSecret(newClear))
+
+	// A second rotation cannot postpone the expiration
+	_, _, ok = client.RotateSecret(secret.ID, auth.SecretRotate{GracePeriod: 7 * 24 * 3600}, now)
+	require.True(t, ok)
+	require.Equal(t, now.Add(auth.DefaultSecretRotationGracePeriod), *client.Secrets[0].ExpiresAt)
+}
+
+func TestStaticClientValidateSecret(t *testing.T) {
+	client := auth.StaticClient{
+		Secrets: []string{"secret1", "secret2"},
+	}
+	require.NoError(t, client.ValidateSecret("secret2"))
+	require.Error(t, client.ValidateSecret("secret"))
+	require.Error(t, client.ValidateSecret(""))
+}
+
+func TestClientOptionsValidateJWKS(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	options := auth.ClientOptions{
+		JWKS: &auth.JSONWebKeySet{
+			JSONWebKeySet: jose.JSONWebKeySet{
+				Keys: []jose.JSONWebKey{{Key: &key.PublicKey, KeyID: "key1"}},
+			},
+		},
+	}
+	require.NoError(t, options.Validate())
+	require.True(t, options.UsesPrivateKeyJWT())
+
+	options.JWKS.Keys[0].Key = key
+	require.Error(t, options.Validate(), "private keys must be rejected")
+
+	require.NoError(t, (&auth.ClientOptions{JWKSURI: "https://example.com/jwks.json"}).Validate())
+	require.Error(t, (&auth.ClientOptions{JWKSURI: "/jwks.json"}).Validate())
+	require.False(t, (&auth.ClientOptions{}).UsesPrivateKeyJWT())
+}
+
+func TestClientOptionsValidateGrantTypes(t *testing.T) {
+	require.NoError(t, (&auth.ClientOptions{
+		GrantTypes: []string{"urn:ietf:params:oauth:grant-type:device_code"},
+	}).Validate())
+	require.Error(t, (&auth.ClientOptions{
+		GrantTypes: []string{"password"},
+	}).Validate())
+}
+
+func TestClientOptionsValidateTokenExchange(t *testing.T) {
+	require.NoError(t, (&auth.ClientOptions{
+		TokenExchange: &auth.TokenExchangePolicy{
+			SubjectTokenIssuers: []string{"https://accounts.example.com"},
+		},
+	}).Validate())
+	require.Error(t, (&auth.ClientOptions{
+		Public:        true,
+		TokenExchange: &auth.TokenExchangePolicy{},
+	}).Validate())
+	require.Error(t, (&auth.ClientOptions{
+		TokenExchange: &auth.TokenExchangePolicy{
+			SubjectTokenIssuers: []string{"not an url"},
+		},
+	}).Validate())
+}
+
+func TestClientOptionsValidateTLSClientAuth(t *testing.T) {
+	type testCase struct {
+		name          string
+		tlsClientAuth auth.TLSClientAuth
+		jwksURI       string
+		public        bool
+		expectError   bool
+	}
+	for _, tc := range []testCase{
+		{
+			name:          "subject dn",
+			tlsClientAuth: auth.TLSClientAuth{SubjectDN: "CN=client"},
+		},
+		{
+			name:          "san ip",
+			tlsClientAuth: auth.TLSClientAuth{SANIP: "10.0.0.1"},
+		},
+		{
+			name:          "invalid san ip",
+			tlsClientAuth: auth.TLSClientAuth{SANIP: "client"},
+			expectError:   true,
+		},
+		{
+			name:          "missing subject",
+			tlsClientAuth: auth.TLSClientAuth{},
+			expectError:   true,
+		},
+		{
+			name:          "multiple subjects",
+			tlsClientAuth: auth.TLSClientAuth{SubjectDN: "CN=client", SANDNS: "client.example.com"},
+			expectError:   true,
+		},
+		{
+			name:          "public client",
+			tlsClientAuth: auth.TLSClientAuth{SubjectDN: "CN=client"},
+			public:        true,
+			expectError:   true,
+		},
+		{
+			name:          "self signed",
+			tlsClientAuth: auth.TLSClientAuth{SelfSigned: true},
+			jwksURI:       "https://example.com/jwks.json",
+		},
+		{
+			name:          "self signed without jwks",
+			tlsClientAuth: auth.TLSClientAuth{SelfSigned: true},
+			expectError:   true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			options := auth.ClientOptions{
+				Public:        tc.public,
+				JWKSURI:       tc.jwksURI,
+				TLSClientAuth: &tc.tlsClientAuth,
+			}
+			if tc.expectError {
+				require.Error(t, options.Validate())
+			} else {
+				require.NoError(t, options.Validate())
+				require.False(t, options.UsesPrivateKeyJWT())
+			}
+		})
+	}
+}
+
+func TestTLSClientAuthMatches(t *testing.T) {
+	certificate := &x509.Certificate{
+		Subject:        pkix.Name{CommonName: "client", Organization: []string{"Formance"}},
+		DNSNames:       []string{"client.example.com"},
+		IPAddresses:    []net.IP{net.ParseIP("10.0.0.1")},
+		EmailAddresses: []string{"client@example.com"},
+		URIs:           []*url.URL{{Scheme: "spiffe", Host: "example.com", Path: "/client"}},
+	}
+
+	require.True(t, (&auth.TLSClientAuth{SubjectDN: "CN=client,O=Formance"}).Matches(certificate))
+	require.False(t, (&auth.TLSClientAuth{SubjectDN: "CN=client"}).Matches(certificate))
+	require.True(t, (&auth.TLSClientAuth{SANDNS: "CLIENT.example.com"}).Matches(certificate))
+	require.True(t, (&auth.TLSClientAuth{SANIP: "10.0.0.1"}).Matches(certificate))
+	require.False(t, (&auth.TLSClientAuth{SANIP: "10.0.0.2"}).Matches(certificate))
+	require.True(t, (&auth.TLSClientAuth{SANEmail: "client@example.com"}).Matches(certificate))
+	require.True(t, (&auth.TLSClientAuth{SANURI: "spiffe://example.com/client"}).Matches(certificate))
+	require.False(t, (&auth.TLSClientAuth{SelfSigned: true}).Matches(certificate))
+}
@@ -1,8 +1,68 @@
 package delegatedauth
 
+import (
+	"fmt"
+
+	auth "github.com/formancehq/auth/pkg"
+)
+
+// Config is an upstream OpenID Connect provider the users log in with
 type Config struct {
-	Issuer       string
-	ClientID     string
-	ClientSecret string
-	RedirectURL  string
+	// Name identifies the provider in the idp_hint parameter and in the users
+	Name string `json:"name" yaml:"name"`
+	// DisplayName is the label of the button of the provider on the login page, the name if empty
+	DisplayName  string `json:"displayName" yaml:"displayName"`
+	Issuer       string `json:"issuer" yaml:"issuer"`
+	ClientID     string `json:"clientId" yaml:"clientId"`
+	ClientSecret string `json:"clientSecret" yaml:"clientSecret"`
+	// RedirectURL is the callback of the server, shared by the providers if empty
+	RedirectURL string `json:"redirectUrl" yaml:"redirectUrl"`
+	// Scopes are the scopes requested to the provider, openid and email if empty
+	Scopes []string `json:"scopes" yaml:"scopes"`
+	// Domains are the email domains of the users of the provider, the users are routed to it from their login_hint
+	Domains []string `json:"domains" yaml:"domains"`
+	// GroupSync synchronizes the group memberships of the users on login
+	GroupSync GroupSync `json:"groupSync" yaml:"groupSync"`
+	// Provisioning are the rules applied to the users logging in for the first time
+	Provisioning auth.Provisioning `json:"provisioning" yaml:"provisioning"`
+}
+
+// Label returns the label of the provider on the login page
+func (c Config) Label() string {
+	if c.DisplayName != "" {
+		return c.DisplayName
+	}
+	return c.Name
+}
+
+// Validate checks the configuration of a provider, as done for the ones stored in the database
+func (c Config) Validate() error {
+	opts := auth.IdentityProviderOptions{
+		Name:         c.Name,
+		Issuer:       c.Issuer,
+		ClientID:     c.ClientID,
+		Provisioning: c.Provisioning,
+	}
+	if err := opts.Validate(); err != nil {
+		return fmt.Errorf("identity provider '%s': %w", c.Name, err)
+	}
+	return nil
+}
+
+// ConfigFromIdentityProvider returns the configuration of a provider stored in the database
+func ConfigFromIdentityProvider(identityProvider auth.IdentityProvider) Config {
+	return Config{
+		Name:         identityProvider.Name,
+		DisplayName:  identityProvider.DisplayName,
+		Issuer:       identityProvider.Issuer,
+		ClientID:     identityProvider.ClientID,
+		ClientSecret: identityProvider.ClientSecret,
+		Scopes:       identityProvider.Scopes,
+		Domains:      identityProvider.Domains,
+		GroupSync: GroupSync{
+			Claim:    identityProvider.GroupsClaim,
+			Mappings: identityProvider.GroupMappings,
+		},
+		Provisioning: identityProvider.Provisioning,
+	}
 }
@@ -0,0 +1,83 @@
+package delegatedauth
+
+import (
+	"strings"
+
+	auth "github.com/formancehq/auth/pkg"
+	"github.com/formancehq/go-libs/v3/collectionutils"
+)
+
+// GroupSync synchronizes the group memberships of the users with a claim asserted by the identity provider
+// on every login, the memberships previously synchronized and no longer asserted are removed.
+type GroupSync struct {
+	// Claim is the name of the claim holding the groups of the user, like groups, the sync is disabled if empty.
+	// A nested claim can be addressed with a dotted path, like realm_access.roles.
+	Claim string `json:"claim" yaml:"claim"`
+	// Mappings map the values of the claim to local groups,
+	// without mappings the values are the names of the local groups.
+	Mappings []auth.GroupMapping `json:"mappings" yaml:"mappings"`
+}
+
+func (s GroupSync) Enabled() bool {
+	return s.Claim != ""
+}
+
+// Groups returns the names of the local groups of a user from its claims, an absent claim means no group
+func (s GroupSync) Groups(claims map[string]any) []string {
+	ret := make([]string, 0)
+	for _, value := range claimValues(lookupClaim(claims, s.Claim)) {
+		if len(s.Mappings) == 0 {
+			if !collectionutils.Contains(ret, value) {
+				ret = append(ret, value)
+			}
+			continue
+		}
+		for _, mapping := range s.Mappings {
+			if mapping.Value != value {
+				continue
+			}
+			for _, group := range mapping.Groups {
+				if !collectionutils.Contains(ret, group) {
+					ret = append(ret, group)
+				}
+			}
+		}
+	}
+	return ret
+}
+
+// lookupClaim returns a claim by its name, or by its dotted path if no claim has this name
+func lookupClaim(claims map[string]any, name string) any {
+	if value, ok := claims[name]; ok {
+		return value
+	}
+	var value any = claims
+	for _, key := range strings.Split(name, ".") {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+		value = object[key]
+	}
+	return value
+}
+
+// claimValues returns the string values of a claim, either a string or an array
+func claimValues(claim any) []string {
+	switch claim := claim.(type) {
+	case string:
+		return []string{claim}
+	case []string:
+		return claim
+	case []any:
+		ret := make([]string, 0, len(claim))
+		for _, value := range claim {
+			if value, ok := value.(string); ok {
+				ret = append(ret, value)
+			}
+		}
+		return ret
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,70 @@
+package delegatedauth_test
+
+import (
+	"testing"
+
+	auth "github.com/formancehq/auth/pkg"
+	"github.com/formancehq/auth/pkg/delegatedauth"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupSync(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name     string
+		sync     delegatedauth.GroupSync
+		claims   map[string]any
+		expected []string
+	}
+	for _, tc := range []testCase{
+		{
+			name:     "values are the names of the groups without mappings",
+			sync:     delegatedauth.GroupSync{Claim: "groups"},
+			claims:   map[string]any{"groups": []any{"analysts", "operators", "analysts"}},
+			expected: []string{"analysts", "operators"},
+		},
+		{
+			name:     "single value",
+			sync:     delegatedauth.GroupSync{Claim: "groups"},
+			claims:   map[string]any{"groups": "analysts"},
+			expected: []string{"analysts"},
+		},
+		{
+			name:     "missing claim",
+			sync:     delegatedauth.GroupSync{Claim: "groups"},
+			claims:   map[string]any{},
+			expected: []string{},
+		},
+		{
+			name:     "nested claim",
+			sync:     delegatedauth.GroupSync{Claim: "realm_access.roles"},
+			claims:   map[string]any{"realm_access": map[string]any{"roles": []any{"analysts"}}},
+			expected: []string{"analysts"},
+		},
+		{
+			name:     "claim name with dots",
+			sync:     delegatedauth.GroupSync{Claim: "https://example.com/groups"},
+			claims:   map[string]any{"https://example.com/groups": []any{"analysts"}},
+			expected: []string{"analysts"},
+		},
+		{
+			name: "mappings",
+			sync: delegatedauth.GroupSync{
+				Claim: "groups",
+				Mappings: []auth.GroupMapping{
+					{Value: "finance-team", Groups: []string{"analysts"}},
+					{Value: "platform-team", Groups: []string{"analysts", "operators"}},
+				},
+			},
+			claims:   map[string]any{"groups": []any{"finance-team", "platform-team", "sales-team"}},
+			expected: []string{"analysts", "operators"},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tc.expected, tc.sync.Groups(tc.claims))
+		})
+	}
+}
@@ -3,15 +3,15 @@ package delegatedauth
 import (
 	"net/http"
 
-	"github.com/zitadel/oidc/v2/pkg/client/rp"
 	"go.uber.org/fx"
 )
 
-func Module() fx.Option {
+// Module provides the identity providers of the configuration and of the database,
+// the redirect url being the callback of the server shared by the providers
+func Module(redirectURL string, configs ...Config) fx.Option {
 	return fx.Options(
-		fx.Provide(func(cfg Config, httpClient *http.Client) (rp.RelyingParty, error) {
-			return rp.NewRelyingPartyOIDC(cfg.Issuer, cfg.ClientID, cfg.ClientSecret, cfg.RedirectURL, []string{"openid email"},
-				rp.WithHTTPClient(httpClient))
-		}),
+		fx.Provide(fx.Annotate(func(httpClient *http.Client, storage ProviderStorage) *Providers {
+			return NewProviders(httpClient, redirectURL, storage, configs...)
+		}, fx.ParamTags(``, `optional:"true"`))),
 	)
 }
@@ -0,0 +1,177 @@
+package delegatedauth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+
+	auth "github.com/formancehq/auth/pkg"
+	"github.com/formancehq/go-libs/v3/collectionutils"
+	"github.com/zitadel/oidc/v2/pkg/client/rp"
+	"github.com/zitadel/oidc/v2/pkg/oidc"
+)
+
+// ErrUnknownProvider is returned for the names matching no identity provider
+var ErrUnknownProvider = errors.New("unknown identity provider")
+
+// DefaultScopes are the scopes requested to the identity providers defining none
+var DefaultScopes = []string{"openid", "email"}
+
+// ProviderStorage stores the identity providers managed with the api
+type ProviderStorage interface {
+	ListIdentityProviders(ctx context.Context) ([]auth.IdentityProvider, error)
+}
+
+type relyingParty struct {
+	config Config
+	rp.RelyingParty
+}
+
+// Providers are the upstream identity providers the users log in with, the ones of the configuration
+// and the ones stored in the database. The relying parties are created on first use, as they discover their issuer.
+type Providers struct {
+	httpClient  *http.Client
+	redirectURL string
+	configs     []Config
+	storage     ProviderStorage
+
+	mu             sync.Mutex
+	relyingParties map[string]relyingParty
+}
+
+// List returns the identity providers, the ones of the configuration take precedence over the stored ones of the same name
+func (p *Providers) List(ctx context.Context) ([]Config, error) {
+	ret := append([]Config{}, p.configs...)
+	if p.storage == nil {
+		return ret, nil
+	}
+	identityProviders, err := p.storage.ListIdentityProviders(ctx)
+	if err != nil {
+		return nil, err
+	}
+	names := collectionutils.Map(p.configs, func(config Config) string {
+		return config.Name
+	})
+	for _, identityProvider := range identityProviders {
+		if !collectionutils.Contains(names, identityProvider.Name) {
+			ret = append(ret, ConfigFromIdentityProvider(identityProvider))
+		}
+	}
+	return ret, nil
+}
+
+// Get returns an identity provider and its relying party
+func (p *Providers) Get(ctx context.Context, name string) (Config, rp.RelyingParty, error) {
+	configs, err := p.List(ctx)
+	if err != nil {
+		return Config{}, nil, err
+	}
+	for _, config := range configs {
+		if config.Name == name {
+			relyingParty, err := p.relyingParty(config)
+			if err != nil {
+				return Config{}, nil, err
+			}
+			return config, relyingParty, nil
+		}
+	}
+	return Config{}, nil, ErrUnknownProvider
+}
+
+// KeySet returns the keys of the identity provider of an issuer, the ones of its relying party,
+// which are cached and fetched again when a token is signed with an unknown key
+func (p *Providers) KeySet(ctx context.Context, issuer string) (oidc.KeySet, error) {
+	configs, err := p.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, config := range configs {
+		if config.Issuer == issuer {
+			relyingParty, err := p.relyingParty(config)
+			if err != nil {
+				return nil, err
+			}
+			return relyingParty.IDTokenVerifier().KeySet(), nil
+		}
+	}
+	return nil, ErrUnknownProvider
+}
+
+// cachedRelyingParty returns the relying party created for the current configuration of a provider, if any
+func (p *Providers) cachedRelyingParty(config Config) (rp.RelyingParty, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	cached, ok := p.relyingParties[config.Name]
+	if !ok || !reflect.DeepEqual(cached.config, config) {
+		return nil, false
+	}
+	return cached.RelyingParty, true
+}
+
+// relyingParty returns the relying party of a provider, created again if the provider has been updated.
+// The discovery of the issuer runs without the lock, so a slow provider does not block the logins with the others.
+func (p *Providers) relyingParty(config Config) (rp.RelyingParty, error) {
+	if cached, ok := p.cachedRelyingParty(config); ok {
+		return cached, nil
+	}
+
+	redirectURL := config.RedirectURL
+	if redirectURL == "" {
+		redirectURL = p.redirectURL
+	}
+	scopes := config.Scopes
+	if len(scopes) == 0 {
+		scopes = DefaultScopes
+	}
+	ret, err := rp.NewRelyingPartyOIDC(config.Issuer, config.ClientID, config.ClientSecret, redirectURL, scopes,
+		rp.WithHTTPClient(p.httpClient))
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.relyingParties[config.Name] = relyingParty{
+		config:       config,
+		RelyingParty: ret,
+	}
+	return ret, nil
+}
+
+// Select returns the provider requested with the idp_hint parameter, or the one of the domain of the email
+// given with the login_hint parameter, or the only provider. The users choose their provider otherwise.
+func Select(configs []Config, idpHint, loginHint string) (Config, bool) {
+	for _, config := range configs {
+		if idpHint != "" && config.Name == idpHint {
+			return config, true
+		}
+	}
+	if _, domain, found := strings.Cut(loginHint, "@"); found {
+		for _, config := range configs {
+			for _, configDomain := range config.Domains {
+				if strings.EqualFold(configDomain, domain) {
+					return config, true
+				}
+			}
+		}
+	}
+	if len(configs) == 1 {
+		return configs[0], true
+	}
+	return Config{}, false
+}
+
+func NewProviders(httpClient *http.Client, redirectURL string, storage ProviderStorage, configs ...Config) *Providers {
+	return &Providers{
+		httpClient:     httpClient,
+		redirectURL:    redirectURL,
+		configs:        configs,
+		storage:        storage,
+		relyingParties: map[string]relyingParty{},
+	}
+}
@@ -0,0 +1,132 @@
+package delegatedauth_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/formancehq/auth/pkg/delegatedauth"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelect(t *testing.T) {
+	t.Parallel()
+
+	google := delegatedauth.Config{Name: "google", Domains: []string{"example.com"}}
+	okta := delegatedauth.Config{Name: "okta", Domains: []string{"partner.com"}}
+
+	type testCase struct {
+		name      string
+		configs   []delegatedauth.Config
+		idpHint   string
+		loginHint string
+		expected  string
+	}
+	for _, tc := range []testCase{
+		{
+			name:     "idp hint",
+			configs:  []delegatedauth.Config{google, okta},
+			idpHint:  "okta",
+			expected: "okta",
+		},
+		{
+			name:      "idp hint takes precedence over the login hint",
+			configs:   []delegatedauth.Config{google, okta},
+			idpHint:   "google",
+			loginHint: "john@partner.com",
+			expected:  "google",
+		},
+		{
+			name:      "domain of the login hint",
+			configs:   []delegatedauth.Config{google, okta},
+			loginHint: "john@Partner.com",
+			expected:  "okta",
+		},
+		{
+			name:      "unknown hints",
+			configs:   []delegatedauth.Config{google, okta},
+			idpHint:   "azure",
+			loginHint: "john@unknown.com",
+		},
+		{
+			name:     "only provider",
+			configs:  []delegatedauth.Config{google},
+			expected: "google",
+		},
+		{
+			name:    "no provider",
+			idpHint: "google",
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			config, ok := delegatedauth.Select(tc.configs, tc.idpHint, tc.loginHint)
+			require.Equal(t, tc.expected != "", ok)
+			require.Equal(t, tc.expected, config.Name)
+		})
+	}
+}
+
+func TestRelyingPartyDiscovery(t *testing.T) {
+	t.Parallel()
+
+	discovered := make(chan struct{})
+	release := make(chan struct{})
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(discovered)
+		<-release
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(slow.Close)
+	t.Cleanup(func() { close(release) })
+
+	var fast *httptest.Server
+	fast = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"issuer":                 fast.URL,
+			"authorization_endpoint": fast.URL + "/authorize",
+			"token_endpoint":         fast.URL + "/token",
+			"jwks_uri":               fast.URL + "/keys",
+		})
+	}))
+	t.Cleanup(fast.Close)
+
+	providers := delegatedauth.NewProviders(http.DefaultClient, "http://localhost/callback", nil,
+		delegatedauth.Config{Name: "slow", Issuer: slow.URL, ClientID: "auth"},
+		delegatedauth.Config{Name: "fast", Issuer: fast.URL, ClientID: "auth"},
+	)
+
+	go func() {
+		_, _, _ = providers.Get(context.Background(), "slow")
+	}()
+	<-discovered
+
+	// The discovery of a provider does not block the other providers
+	done := make(chan error)
+	go func() {
+		_, relyingParty, err := providers.Get(context.Background(), "fast")
+		if err == nil && relyingParty.OAuthConfig().Endpoint.TokenURL != fast.URL+"/token" {
+			err = errors.New("unexpected token endpoint")
+		}
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the discovery of a provider blocked the other providers")
+	}
+
+	// The relying party is created once per configuration
+	_, first, err := providers.Get(context.Background(), "fast")
+	require.NoError(t, err)
+	_, second, err := providers.Get(context.Background(), "fast")
+	require.NoError(t, err)
+	require.Same(t, first, second)
+}
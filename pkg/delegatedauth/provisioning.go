@@ -0,0 +1,131 @@
+package delegatedauth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	auth "github.com/formancehq/auth/pkg"
+	"github.com/formancehq/go-libs/v3/collectionutils"
+	"golang.org/x/text/language"
+)
+
+// ErrUserRejected is returned for the users the provisioning rules of their identity provider reject
+var ErrUserRejected = errors.New("user rejected")
+
+// ProvisionUser creates the user logging in for the first time with the provider from its claims,
+// unless the provisioning rules of the provider reject it
+func (c Config) ProvisionUser(claims map[string]any) (*auth.User, error) {
+	rules := c.Provisioning
+	if rules.DenyUnknownUsers {
+		return nil, fmt.Errorf("%w: unknown user, an administrator must create your account", ErrUserRejected)
+	}
+
+	email := claimString(claims, "email")
+	emailVerified, _ := claims["email_verified"].(bool)
+	if len(rules.AllowedDomains) > 0 {
+		_, domain, found := strings.Cut(email, "@")
+		if !found {
+			return nil, fmt.Errorf("%w: an email is required", ErrUserRejected)
+		}
+		// the domain of an email the identity provider has not verified proves nothing
+		if !emailVerified {
+			return nil, fmt.Errorf("%w: the email must be verified by the identity provider", ErrUserRejected)
+		}
+		if !collectionutils.Contains(collectionutils.Map(rules.AllowedDomains, strings.ToLower), strings.ToLower(domain)) {
+			return nil, fmt.Errorf("%w: the email domain '%s' is not allowed", ErrUserRejected, domain)
+		}
+	}
+	for _, requirement := range rules.RequiredClaims {
+		if !matches(requirement, claims) {
+			return nil, fmt.Errorf("%w: missing or invalid claim '%s'", ErrUserRejected, requirement.Claim)
+		}
+	}
+
+	mapping := rules.ClaimMapping
+	opts := auth.UserOptions{
+		Provider:      c.Name,
+		Subject:       claimString(claims, "sub"),
+		Email:         email,
+		EmailVerified: emailVerified,
+		DisplayName:   claimString(claims, withDefault(mapping.DisplayName, "name")),
+		GivenName:     claimString(claims, withDefault(mapping.GivenName, "given_name")),
+		FamilyName:    claimString(claims, withDefault(mapping.FamilyName, "family_name")),
+	}
+	// an invalid locale is ignored rather than rejecting the user
+	if locale, err := language.Parse(claimString(claims, withDefault(mapping.Locale, "locale"))); err == nil {
+		opts.Locale = locale.String()
+	}
+	for key, claim := range mapping.Metadata {
+		value, err := metadataValue(lookupClaim(claims, claim))
+		if err != nil {
+			return nil, err
+		}
+		if value == "" {
+			continue
+		}
+		if opts.Metadata == nil {
+			opts.Metadata = auth.Metadata{}
+		}
+		opts.Metadata[key] = value
+	}
+
+	return auth.NewUser(opts), nil
+}
+
+// matches checks a requirement against the claims asserted by an identity provider
+func matches(requirement auth.ClaimRequirement, claims map[string]any) bool {
+	claim := lookupClaim(claims, requirement.Claim)
+	if claim == nil || claim == "" {
+		return false
+	}
+	if len(requirement.Values) == 0 {
+		return true
+	}
+	for _, value := range claimStrings(claim) {
+		if collectionutils.Contains(requirement.Values, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func withDefault(claim, standardClaim string) string {
+	if claim != "" {
+		return claim
+	}
+	return standardClaim
+}
+
+// claimString returns a claim holding a string, empty otherwise
+func claimString(claims map[string]any, name string) string {
+	value, _ := lookupClaim(claims, name).(string)
+	return value
+}
+
+// claimStrings returns the values of a claim as strings, including the booleans and the numbers
+func claimStrings(claim any) []string {
+	switch claim := claim.(type) {
+	case bool, float64:
+		return []string{fmt.Sprint(claim)}
+	default:
+		return claimValues(claim)
+	}
+}
+
+// metadataValue returns a claim as a metadata value, the claims which are not strings are encoded in JSON
+func metadataValue(claim any) (string, error) {
+	switch claim := claim.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return claim, nil
+	default:
+		data, err := json.Marshal(claim)
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	}
+}
@@ -0,0 +1,170 @@
+package delegatedauth_test
+
+import (
+	"maps"
+	"testing"
+
+	auth "github.com/formancehq/auth/pkg"
+	"github.com/formancehq/auth/pkg/delegatedauth"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProvisionUser(t *testing.T) {
+	t.Parallel()
+
+	claims := map[string]any{
+		"sub":            "1234",
+		"email":          "jane@Example.com",
+		"email_verified": true,
+		"name":           "Jane Doe",
+		"given_name":     "Jane",
+		"family_name":    "Doe",
+		"locale":         "fr-FR",
+		"department":     "finance",
+		"groups":         []any{"analysts", "operators"},
+		"address":        map[string]any{"country": "FR"},
+	}
+
+	type testCase struct {
+		name string
+		// claims overrides the default claims, a nil value removes a claim
+		claims       map[string]any
+		provisioning auth.Provisioning
+		expected     *auth.UserOptions
+	}
+	for _, tc := range []testCase{
+		{
+			name: "standard claims",
+			expected: &auth.UserOptions{
+				Provider:      "partner",
+				Subject:       "1234",
+				Email:         "jane@Example.com",
+				EmailVerified: true,
+				DisplayName:   "Jane Doe",
+				GivenName:     "Jane",
+				FamilyName:    "Doe",
+				Locale:        "fr-FR",
+			},
+		},
+		{
+			name:         "unknown users denied",
+			provisioning: auth.Provisioning{DenyUnknownUsers: true},
+		},
+		{
+			name:         "allowed domain",
+			provisioning: auth.Provisioning{AllowedDomains: []string{"other.com", "example.com"}},
+			expected: &auth.UserOptions{
+				Provider:      "partner",
+				Subject:       "1234",
+				Email:         "jane@Example.com",
+				EmailVerified: true,
+				DisplayName:   "Jane Doe",
+				GivenName:     "Jane",
+				FamilyName:    "Doe",
+				Locale:        "fr-FR",
+			},
+		},
+		{
+			name:         "domain not allowed",
+			provisioning: auth.Provisioning{AllowedDomains: []string{"other.com"}},
+		},
+		{
+			name:         "email not verified",
+			claims:       map[string]any{"email_verified": false},
+			provisioning: auth.Provisioning{AllowedDomains: []string{"example.com"}},
+		},
+		{
+			name:         "email verification not asserted",
+			claims:       map[string]any{"email_verified": nil},
+			provisioning: auth.Provisioning{AllowedDomains: []string{"example.com"}},
+		},
+		{
+			name: "required claims",
+			provisioning: auth.Provisioning{
+				RequiredClaims: []auth.ClaimRequirement{
+					{Claim: "email_verified", Values: []string{"true"}},
+					{Claim: "groups", Values: []string{"operators"}},
+					{Claim: "address.country"},
+				},
+				ClaimMapping: auth.ClaimMapping{DisplayName: "given_name"},
+			},
+			expected: &auth.UserOptions{
+				Provider:      "partner",
+				Subject:       "1234",
+				Email:         "jane@Example.com",
+				EmailVerified: true,
+				DisplayName:   "Jane",
+				GivenName:     "Jane",
+				FamilyName:    "Doe",
+				Locale:        "fr-FR",
+			},
+		},
+		{
+			name: "missing claim",
+			provisioning: auth.Provisioning{
+				RequiredClaims: []auth.ClaimRequirement{{Claim: "employee_id"}},
+			},
+		},
+		{
+			name: "invalid claim value",
+			provisioning: auth.Provisioning{
+				RequiredClaims: []auth.ClaimRequirement{{Claim: "department", Values: []string{"sales"}}},
+			},
+		},
+		{
+			name: "claim mapping",
+			provisioning: auth.Provisioning{
+				ClaimMapping: auth.ClaimMapping{
+					DisplayName: "email",
+					Locale:      "department",
+					Metadata: map[string]string{
+						"department": "department",
+						"groups":     "groups",
+						"country":    "address.country",
+						"missing":    "missing",
+					},
+				},
+			},
+			expected: &auth.UserOptions{
+				Provider:      "partner",
+				Subject:       "1234",
+				Email:         "jane@Example.com",
+				EmailVerified: true,
+				DisplayName:   "jane@Example.com",
+				GivenName:     "Jane",
+				FamilyName:    "Doe",
+				Metadata: auth.Metadata{
+					"department": "finance",
+					"groups":     `["analysts","operators"]`,
+					"country":    "FR",
+				},
+			},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			claims := maps.Clone(claims)
+			for name, value := range tc.claims {
+				if value == nil {
+					delete(claims, name)
+					continue
+				}
+				claims[name] = value
+			}
+
+			config := delegatedauth.Config{
+				Name:         "partner",
+				Provisioning: tc.provisioning,
+			}
+			user, err := config.ProvisionUser(claims)
+			if tc.expected == nil {
+				require.ErrorIs(t, err, delegatedauth.ErrUserRejected)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, *tc.expected, user.UserOptions)
+		})
+	}
+}
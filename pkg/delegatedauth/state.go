@@ -8,11 +8,20 @@ import (
 
 type DelegatedState struct {
 	AuthRequestID string `json:"authRequestID"`
+	// DeviceUserCode is set when the user logs in to approve a device instead of an auth request
+	DeviceUserCode string `json:"deviceUserCode,omitempty"`
+	// Provider is the name of the identity provider the user logs in with, the default one if empty
+	Provider string `json:"provider,omitempty"`
 }
 
 func (s DelegatedState) EncodeAsUrlParam() string {
 	buf := bytes.NewBufferString("")
-	if err := json.NewEncoder(base64.NewEncoder(base64.URLEncoding, buf)).Encode(s); err != nil {
+	encoder := base64.NewEncoder(base64.URLEncoding, buf)
+	if err := json.NewEncoder(encoder).Encode(s); err != nil {
+		panic(err)
+	}
+	// flush the last partial block
+	if err := encoder.Close(); err != nil {
 		panic(err)
 	}
 	return buf.String()
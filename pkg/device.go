@@ -0,0 +1,48 @@
+package auth
+
+import (
+	"strings"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// DeviceAuthorization is a pending device authorization grant (RFC 8628).
+// The device polls with the device code while the user approves the request with the user code.
+type DeviceAuthorization struct {
+	bun.BaseModel `bun:"table:device_authorizations"`
+
+	ID            string `bun:",pk"`
+	CreatedAt     time.Time
+	ApplicationID string
+	DeviceCode    string
+	// UserCode is stored normalized, see NormalizeUserCode
+	UserCode     string
+	Scopes       Array[string] `bun:"type:text"`
+	Expiration   time.Time
+	LastPolledAt *time.Time
+	UserID       string
+	AuthTime     time.Time
+	Denied       bool
+}
+
+// Done returns true once the user has approved the request
+func (d *DeviceAuthorization) Done() bool {
+	return d.UserID != ""
+}
+
+// IsPending returns true while the user can still approve or deny the request
+func (d *DeviceAuthorization) IsPending(now time.Time) bool {
+	return !d.Done() && !d.Denied && now.Before(d.Expiration)
+}
+
+// NormalizeUserCode removes the separators and the case of a user code, so the user can type it loosely
+func NormalizeUserCode(userCode string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '-', ' ', '\t':
+			return -1
+		}
+		return r
+	}, strings.ToUpper(strings.TrimSpace(userCode)))
+}
@@ -0,0 +1,51 @@
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/formancehq/go-libs/v3/logging"
+	"github.com/formancehq/go-libs/v3/publish"
+)
+
+const (
+	TopicAuth = "auth"
+
+	EventVersion = "v1"
+	EventApp     = "auth"
+
+	EventTypeRefreshTokenReused = "REFRESH_TOKEN_REUSED"
+)
+
+type RefreshTokenReused struct {
+	FamilyID string `json:"familyId"`
+	TokenID  string `json:"tokenId"`
+	ClientID string `json:"clientId"`
+	UserID   string `json:"userId,omitempty"`
+}
+
+func NewEventRefreshTokenReused(payload RefreshTokenReused) publish.EventMessage {
+	return newEventMessage(EventTypeRefreshTokenReused, payload)
+}
+
+func newEventMessage(eventType string, payload any) publish.EventMessage {
+	return publish.EventMessage{
+		Date:    time.Now().UTC(),
+		App:     EventApp,
+		Version: EventVersion,
+		Type:    eventType,
+		Payload: payload,
+	}
+}
+
+// Publish sends an event on the auth topic.
+// Events are informative, so a publishing failure is logged but does not fail the caller.
+func Publish(ctx context.Context, publisher message.Publisher, event publish.EventMessage) {
+	if publisher == nil {
+		return
+	}
+	if err := publisher.Publish(TopicAuth, publish.NewMessage(ctx, event)); err != nil {
+		logging.FromContext(ctx).Errorf("error while publishing event %s: %s", event.Type, err)
+	}
+}
@@ -0,0 +1,51 @@
+package auth
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type GroupOptions struct {
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description" yaml:"description"`
+	// Roles are the ids of the roles of the members of the group
+	Roles Array[string] `json:"roles" yaml:"roles" bun:"type:text"`
+}
+
+func (o *GroupOptions) Validate() error {
+	if o.Name == "" {
+		return errors.New("name is required")
+	}
+	return nil
+}
+
+// Group is a set of users sharing roles, like analysts or operators
+type Group struct {
+	bun.BaseModel `bun:"table:groups"`
+
+	ID string `json:"id" bun:",pk"`
+	GroupOptions
+}
+
+func (g *Group) Update(opts GroupOptions) {
+	g.GroupOptions = opts
+}
+
+func NewGroup(opts GroupOptions) *Group {
+	return &Group{
+		ID:           uuid.NewString(),
+		GroupOptions: opts,
+	}
+}
+
+// GroupMember is the membership of a user in a group
+type GroupMember struct {
+	bun.BaseModel `bun:"table:group_members"`
+
+	GroupID string `json:"groupId" bun:",pk"`
+	UserID  string `json:"userId" bun:",pk"`
+	// Synchronized memberships are managed by the upstream identity provider, they are updated on every login
+	Synchronized bool `json:"synchronized"`
+}
@@ -0,0 +1,128 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// Parameters of the argon2id hashes, as recommended by OWASP.
+// They are encoded in the hashes, so they can be raised without invalidating the existing secrets.
+const (
+	argon2Memory      = 19 * 1024
+	argon2Iterations  = 2
+	argon2Parallelism = 1
+	argon2SaltLength  = 16
+	argon2KeyLength   = 32
+)
+
+const argon2Prefix = "$argon2id$"
+
+type argon2Params struct {
+	memory      uint32
+	iterations  uint32
+	parallelism uint8
+}
+
+var defaultArgon2Params = argon2Params{
+	memory:      argon2Memory,
+	iterations:  argon2Iterations,
+	parallelism: argon2Parallelism,
+}
+
+// newHash hashes the value with argon2id and a random salt.
+// The result uses the PHC string format: $argon2id$v=19$m=<memory>,t=<iterations>,p=<parallelism>$<salt>$<key>
+func newHash(v string) string {
+	salt := make([]byte, argon2SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		panic(err)
+	}
+
+	return encodeArgon2Hash(defaultArgon2Params, salt, argon2.IDKey([]byte(v), salt,
+		defaultArgon2Params.iterations, defaultArgon2Params.memory, defaultArgon2Params.parallelism, argon2KeyLength))
+}
+
+func encodeArgon2Hash(params argon2Params, salt, key []byte) string {
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s", argon2Prefix, argon2.Version,
+		params.memory, params.iterations, params.parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key))
+}
+
+func decodeArgon2Hash(hash string) (*argon2Params, []byte, []byte, error) {
+	parts := strings.Split(strings.TrimPrefix(hash, argon2Prefix), "$")
+	if len(parts) != 4 {
+		return nil, nil, nil, fmt.Errorf("malformed argon2id hash")
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[0], "v=%d", &version); err != nil {
+		return nil, nil, nil, err
+	}
+	if version != argon2.Version {
+		return nil, nil, nil, fmt.Errorf("unsupported argon2 version %d", version)
+	}
+
+	params := &argon2Params{}
+	if _, err := fmt.Sscanf(parts[1], "m=%d,t=%d,p=%d", &params.memory, &params.iterations, &params.parallelism); err != nil {
+		return nil, nil, nil, err
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[3])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return params, salt, key, nil
+}
+
+// newLegacyHash is the unsalted SHA-256 hash used before argon2id, only kept to verify the old secrets
+func newLegacyHash(v string) string {
+	digest := sha256.New()
+	digest.Write([]byte(v))
+	hash := digest.Sum(nil)
+
+	return base64.StdEncoding.EncodeToString(hash)
+}
+
+func isLegacyHash(hash string) bool {
+	return !strings.HasPrefix(hash, argon2Prefix)
+}
+
+// checkHash compares, in constant time, the value with a hash produced by newHash or by the legacy hashing
+func checkHash(hash, v string) bool {
+	if isLegacyHash(hash) {
+		return subtle.ConstantTimeCompare([]byte(hash), []byte(newLegacyHash(v))) == 1
+	}
+
+	params, salt, key, err := decodeArgon2Hash(hash)
+	if err != nil {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare(key,
+		argon2.IDKey([]byte(v), salt, params.iterations, params.memory, params.parallelism, uint32(len(key)))) == 1
+}
+
+// hashNeedsUpgrade returns true if the hash is a legacy one or has been produced with outdated parameters
+func hashNeedsUpgrade(hash string) bool {
+	if isLegacyHash(hash) {
+		return true
+	}
+
+	params, _, _, err := decodeArgon2Hash(hash)
+	if err != nil {
+		return false
+	}
+
+	return *params != defaultArgon2Params
+}
@@ -0,0 +1,26 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/argon2"
+)
+
+func TestHashWithOutdatedParameters(t *testing.T) {
+	params := argon2Params{
+		memory:      8 * 1024,
+		iterations:  1,
+		parallelism: 1,
+	}
+	salt := []byte("0123456789abcdef")
+	hash := encodeArgon2Hash(params, salt, argon2.IDKey([]byte("secret"), salt,
+		params.iterations, params.memory, params.parallelism, argon2KeyLength))
+
+	require.True(t, checkHash(hash, "secret"))
+	require.False(t, checkHash(hash, "other"))
+	require.True(t, hashNeedsUpgrade(hash))
+	require.False(t, hashNeedsUpgrade(newHash("secret")))
+
+	require.False(t, checkHash("$argon2id$v=19$malformed", "secret"))
+}
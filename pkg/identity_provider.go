@@ -0,0 +1,80 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// DefaultIdentityProvider is the name of the identity provider configured with the delegated issuer flags,
+// the users created before the support of several identity providers belong to it
+const DefaultIdentityProvider = "default"
+
+var identityProviderNameRegexp = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// GroupMapping maps a value of the groups claim asserted by an identity provider to local groups
+type GroupMapping struct {
+	// Value is the value of the claim, like the name of a group of the identity provider
+	Value string `json:"value" yaml:"value"`
+	// Groups are the names of the local groups the users are members of when the claim contains the value
+	Groups []string `json:"groups" yaml:"groups"`
+}
+
+type IdentityProviderOptions struct {
+	// Name identifies the provider in the idp_hint parameter and in the users, like google or okta
+	Name string `json:"name" yaml:"name" bun:",unique"`
+	// DisplayName is the label of the button of the provider on the login page
+	DisplayName  string `json:"displayName" yaml:"displayName"`
+	Issuer       string `json:"issuer" yaml:"issuer"`
+	ClientID     string `json:"clientId" yaml:"clientId"`
+	ClientSecret string `json:"clientSecret,omitempty" yaml:"clientSecret"`
+	// Scopes are the scopes requested to the provider, openid and email if empty
+	Scopes Array[string] `json:"scopes" yaml:"scopes" bun:"type:text"`
+	// Domains are the email domains of the users of the provider, the users are routed to it from their login_hint
+	Domains Array[string] `json:"domains" yaml:"domains" bun:"type:text"`
+	// GroupsClaim is the claim holding the groups of the users synchronized with the local groups on login, if any
+	GroupsClaim   string              `json:"groupsClaim" yaml:"groupsClaim"`
+	GroupMappings Array[GroupMapping] `json:"groupMappings" yaml:"groupMappings" bun:"type:text"`
+	// Provisioning are the rules applied to the users logging in for the first time
+	Provisioning Provisioning `json:"provisioning" yaml:"provisioning" bun:"type:text"`
+}
+
+func (o *IdentityProviderOptions) Validate() error {
+	if !identityProviderNameRegexp.MatchString(o.Name) {
+		return fmt.Errorf("invalid name '%s'", o.Name)
+	}
+	if u, err := url.Parse(o.Issuer); err != nil || !u.IsAbs() {
+		return fmt.Errorf("invalid issuer '%s'", o.Issuer)
+	}
+	if o.ClientID == "" {
+		return errors.New("client id is required")
+	}
+	return o.Provisioning.Validate()
+}
+
+// IdentityProvider is an upstream OpenID Connect provider the users log in with, like a Google Workspace or an Okta tenant
+type IdentityProvider struct {
+	bun.BaseModel `bun:"table:identity_providers"`
+
+	ID string `json:"id" bun:",pk"`
+	IdentityProviderOptions
+}
+
+// Update updates the provider, the client secret is kept if not provided
+func (p *IdentityProvider) Update(opts IdentityProviderOptions) {
+	if opts.ClientSecret == "" {
+		opts.ClientSecret = p.ClientSecret
+	}
+	p.IdentityProviderOptions = opts
+}
+
+func NewIdentityProvider(opts IdentityProviderOptions) *IdentityProvider {
+	return &IdentityProvider{
+		ID:                      uuid.NewString(),
+		IdentityProviderOptions: opts,
+	}
+}
@@ -0,0 +1,44 @@
+package auth_test
+
+import (
+	"testing"
+
+	auth "github.com/formancehq/auth/pkg"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdentityProviderOptionsValidate(t *testing.T) {
+	opts := auth.IdentityProviderOptions{
+		Name:     "partner",
+		Issuer:   "https://accounts.example.com",
+		ClientID: "auth",
+	}
+	require.NoError(t, opts.Validate())
+
+	for _, invalid := range []auth.IdentityProviderOptions{
+		{Issuer: opts.Issuer, ClientID: opts.ClientID},
+		{Name: "Partner Inc", Issuer: opts.Issuer, ClientID: opts.ClientID},
+		{Name: opts.Name, Issuer: "accounts.example.com", ClientID: opts.ClientID},
+		{Name: opts.Name, Issuer: opts.Issuer},
+		{Name: opts.Name, Issuer: opts.Issuer, ClientID: opts.ClientID, Provisioning: auth.Provisioning{
+			RequiredClaims: []auth.ClaimRequirement{{Values: []string{"finance"}}},
+		}},
+	} {
+		require.Error(t, invalid.Validate())
+	}
+}
+
+func TestIdentityProviderUpdate(t *testing.T) {
+	identityProvider := auth.NewIdentityProvider(auth.IdentityProviderOptions{
+		Name:         "partner",
+		ClientSecret: "secret",
+	})
+
+	// The client secret is kept if not provided
+	identityProvider.Update(auth.IdentityProviderOptions{Name: "partner", DisplayName: "Partner"})
+	require.Equal(t, "secret", identityProvider.ClientSecret)
+	require.Equal(t, "Partner", identityProvider.DisplayName)
+
+	identityProvider.Update(auth.IdentityProviderOptions{Name: "partner", ClientSecret: "rotated"})
+	require.Equal(t, "rotated", identityProvider.ClientSecret)
+}
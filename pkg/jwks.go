@@ -0,0 +1,59 @@
+package auth
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+
+	"gopkg.in/go-jose/go-jose.v2"
+)
+
+// JSONWebKeySet is a set of public keys, stored as JSON
+type JSONWebKeySet struct {
+	jose.JSONWebKeySet
+}
+
+// Scan implements the sql.Scanner interface.
+func (s *JSONWebKeySet) Scan(src interface{}) error {
+	*s = JSONWebKeySet{}
+	var err error
+	switch src := src.(type) {
+	case []byte:
+		err = json.Unmarshal(src, s)
+	case string:
+		err = json.Unmarshal([]byte(src), s)
+	case nil:
+	default:
+		return fmt.Errorf("type '%T' not handled", src)
+	}
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (s *JSONWebKeySet) Value() (driver.Value, error) {
+	if s == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(data), nil
+}
+
+// Validate checks the keys can be used to verify client assertions
+func (s *JSONWebKeySet) Validate() error {
+	for i, key := range s.Keys {
+		if !key.Valid() {
+			return fmt.Errorf("key %d is not valid", i)
+		}
+		if !key.IsPublic() {
+			return fmt.Errorf("key %d is not a public key", i)
+		}
+	}
+	return nil
+}
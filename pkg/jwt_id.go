@@ -0,0 +1,17 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// UsedJWTID is the id (jti) of a single use JWT, a DPoP proof or a client assertion.
+// It is kept until the JWT expires to reject its replays, on every instance of the server.
+type UsedJWTID struct {
+	bun.BaseModel `bun:"table:used_jwt_ids"`
+
+	// ID is namespaced by the kind of JWT and by its issuer, so the ids of different issuers do not collide
+	ID         string `bun:",pk"`
+	Expiration time.Time
+}
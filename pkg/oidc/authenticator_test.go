@@ -0,0 +1,87 @@
+package oidc_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/formancehq/auth/pkg/oidc"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-jose/go-jose.v2"
+)
+
+func TestAuthenticator(t *testing.T) {
+	t.Parallel()
+
+	key, err := oidc.GenerateSigningKey(jose.EdDSA)
+	require.NoError(t, err)
+
+	keyRing, err := oidc.NewKeyRing(key)
+	require.NoError(t, err)
+
+	authenticator := oidc.NewAuthenticator(keyRing, []string{"http://localhost"}, "auth", true)
+
+	type testCase struct {
+		name          string
+		issuer        string
+		scope         string
+		method        string
+		expectSuccess bool
+	}
+	for _, tc := range []testCase{
+		{
+			name:          "read with read scope",
+			issuer:        "http://localhost",
+			scope:         "auth:read",
+			method:        http.MethodGet,
+			expectSuccess: true,
+		},
+		{
+			name:   "write with read scope",
+			issuer: "http://localhost",
+			scope:  "auth:read",
+			method: http.MethodPost,
+		},
+		{
+			name:   "untrusted issuer",
+			issuer: "http://example.com",
+			scope:  "auth:write",
+			method: http.MethodPost,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			signer, err := jose.NewSigner(jose.SigningKey{
+				Algorithm: jose.EdDSA,
+				Key: jose.JSONWebKey{
+					Key:   key,
+					KeyID: keyRing.SigningKey().ID(),
+				},
+			}, nil)
+			require.NoError(t, err)
+
+			jws, err := signer.Sign([]byte(fmt.Sprintf(`{"iss":%q,"exp":%d,"scope":%q}`,
+				tc.issuer, time.Now().Add(time.Minute).Unix(), tc.scope)))
+			require.NoError(t, err)
+
+			token, err := jws.CompactSerialize()
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(tc.method, "/", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+
+			ok, err := authenticator.Authenticate(httptest.NewRecorder(), req)
+			if tc.expectSuccess {
+				require.NoError(t, err)
+				require.True(t, ok)
+			} else {
+				require.Error(t, err)
+				require.False(t, ok)
+			}
+		})
+	}
+}
@@ -1,13 +1,17 @@
 package oidc
 
 import (
+	"context"
 	"embed"
+	"encoding/json"
+	"errors"
 	"html/template"
 	"net/http"
+	"time"
 
 	auth "github.com/formancehq/auth/pkg"
 	"github.com/formancehq/auth/pkg/delegatedauth"
-	"github.com/google/uuid"
+	"github.com/formancehq/auth/pkg/storage"
 	"github.com/zitadel/oidc/v2/pkg/client/rp"
 	"github.com/zitadel/oidc/v2/pkg/oidc"
 	"github.com/zitadel/oidc/v2/pkg/op"
@@ -33,23 +37,52 @@ func authorizeErrorHandler() http.HandlerFunc {
 func authorizeCallbackHandler(
 	provider op.OpenIDProvider,
 	storage Storage,
-	relyingParty rp.RelyingParty,
+	providers *delegatedauth.Providers,
 ) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 
 		state, err := delegatedauth.DecodeDelegatedState(r.URL.Query().Get("state"))
 		if err != nil {
-			panic(err)
+			renderLoginPage(w, http.StatusBadRequest, map[string]any{
+				"Error": "Invalid login request",
+			})
+			return
+		}
+		// the states of the logins started before the support of several identity providers have no provider
+		if state.Provider == "" {
+			state.Provider = auth.DefaultIdentityProvider
 		}
 
-		authRequest, err := storage.FindAuthRequest(r.Context(), state.AuthRequestID)
+		config, relyingParty, err := providers.Get(r.Context(), state.Provider)
 		if err != nil {
-			panic(err)
+			if !errors.Is(err, delegatedauth.ErrUnknownProvider) {
+				panic(err)
+			}
+			renderLoginPage(w, http.StatusBadRequest, map[string]any{
+				"Error": "Invalid login request",
+			})
+			return
+		}
+
+		// the identity provider redirects with an error when the user cancels the login or is not allowed to log in
+		if upstreamError := r.URL.Query().Get("error"); upstreamError != "" {
+			description := r.URL.Query().Get("error_description")
+			if description == "" {
+				description = upstreamError
+			}
+			renderLoginPage(w, http.StatusForbidden, map[string]any{
+				"Error":            "Login failed",
+				"ErrorDescription": description,
+			})
+			return
 		}
 
 		tokens, err := rp.CodeExchange[*oidc.IDTokenClaims](r.Context(), r.URL.Query().Get("code"), relyingParty)
 		if err != nil {
-			panic(err)
+			renderLoginPage(w, http.StatusBadRequest, map[string]any{
+				"Error": "Invalid or expired login request",
+			})
+			return
 		}
 
 		userInfos, err := rp.Userinfo(tokens.AccessToken, "Bearer", tokens.IDTokenClaims.GetSubject(), relyingParty)
@@ -57,20 +90,62 @@ func authorizeCallbackHandler(
 			panic(err)
 		}
 
-		user, err := storage.FindUserBySubject(r.Context(), tokens.IDTokenClaims.GetSubject())
+		claims, err := upstreamClaims(tokens.IDTokenClaims, userInfos)
+		if err != nil {
+			panic(err)
+		}
+
+		user, err := findOrProvisionUser(r.Context(), storage, config, tokens.IDTokenClaims.GetSubject(), claims)
 		if err != nil {
-			user = &auth.User{
-				ID:      uuid.NewString(),
-				Subject: userInfos.Subject,
-				Email:   userInfos.Email,
+			if !errors.Is(err, delegatedauth.ErrUserRejected) {
+				panic(err)
 			}
-			if err := storage.SaveUser(r.Context(), user); err != nil {
+			renderLoginPage(w, http.StatusForbidden, map[string]any{
+				"Error":            "Access denied",
+				"ErrorDescription": err.Error(),
+			})
+			return
+		}
+
+		if config.GroupSync.Enabled() && !user.Disabled {
+			if err := storage.SyncUserGroups(r.Context(), user.ID, config.GroupSync.Groups(claims)); err != nil {
 				panic(err)
 			}
 		}
 
+		if state.DeviceUserCode != "" {
+			if user.Disabled {
+				renderDevicePage(w, http.StatusForbidden, map[string]any{
+					"Error": "Your account is disabled",
+				})
+				return
+			}
+			deviceAuthorizationCallback(w, r, provider, state.DeviceUserCode, user.ID)
+			return
+		}
+
+		authRequest, err := storage.FindAuthRequest(r.Context(), state.AuthRequestID)
+		if err != nil {
+			renderLoginPage(w, http.StatusBadRequest, map[string]any{
+				"Error": "Invalid or expired login request",
+			})
+			return
+		}
+
+		if user.Disabled {
+			op.AuthRequestError(w, r, authRequest, oidc.ErrAccessDenied().WithDescription("user is disabled"), provider.Encoder())
+			return
+		}
+
 		authRequest.UserID = user.ID
 
+		if policyStorage, ok := provider.Storage().(ScopePolicyStorage); ok {
+			authRequest.Scopes, err = policyStorage.GrantScopes(r.Context(), authRequest.ApplicationID, user, claims, authRequest.Scopes)
+			if err != nil {
+				panic(err)
+			}
+		}
+
 		if err := storage.UpdateAuthRequest(r.Context(), authRequest); err != nil {
 			panic(err)
 		}
@@ -79,3 +154,44 @@ func authorizeCallbackHandler(
 		w.WriteHeader(http.StatusFound)
 	}
 }
+
+// findOrProvisionUser returns the user logging in with an upstream identity provider,
+// created with the provisioning rules of the provider on its first login
+func findOrProvisionUser(ctx context.Context, s Storage, config delegatedauth.Config, subject string, claims map[string]any) (*auth.User, error) {
+	now := time.Now()
+	user, err := s.FindUserBySubject(ctx, config.Name, subject)
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		user, err = config.ProvisionUser(claims)
+		if err != nil {
+			return nil, err
+		}
+		user.LastLoginAt = &now
+		if err := s.SaveUser(ctx, user); err != nil {
+			return nil, err
+		}
+	case err != nil:
+		return nil, err
+	case !user.Disabled:
+		user.LastLoginAt = &now
+		if err := s.UpdateUser(ctx, user); err != nil {
+			return nil, err
+		}
+	}
+	return user, nil
+}
+
+// upstreamClaims merges the claims of the id token and the user info returned by an upstream identity provider
+func upstreamClaims(idTokenClaims *oidc.IDTokenClaims, userInfo *oidc.UserInfo) (map[string]any, error) {
+	claims := map[string]any{}
+	for _, source := range []any{idTokenClaims, userInfo} {
+		data, err := json.Marshal(source)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, &claims); err != nil {
+			return nil, err
+		}
+	}
+	return claims, nil
+}
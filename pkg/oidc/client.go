@@ -1,10 +1,11 @@
 package oidc
 
 import (
+	"net/http"
+	"net/url"
 	"time"
 
-	"github.com/formancehq/auth/pkg/delegatedauth"
-	"github.com/zitadel/oidc/v2/pkg/client/rp"
+	auth "github.com/formancehq/auth/pkg"
 	"github.com/zitadel/oidc/v2/pkg/oidc"
 	"github.com/zitadel/oidc/v2/pkg/op"
 )
@@ -17,17 +18,32 @@ type Client interface {
 	GetScopes() []string
 	ValidateSecret(secret string) error
 	IsTrusted() bool
+	GetAccessTokenLifetime() time.Duration
+	GetIDTokenLifetime() time.Duration
+	GetRefreshTokenLifetime() time.Duration
+	GetRefreshTokenIdleLifetime() time.Duration
+	UsesPrivateKeyJWT() bool
+	GetJWKS() *auth.JSONWebKeySet
+	GetJWKSURI() string
+	GetTLSClientAuth() *auth.TLSClientAuth
+	IsDPoPRequired() bool
+	GetGrantTypes() []string
+	GetTokenExchangePolicy() *auth.TokenExchangePolicy
+	IsPARRequired() bool
 }
 
 type clientFacade struct {
-	Client       Client
-	relyingParty rp.RelyingParty
+	Client    Client
+	lifetimes TokenLifetimes
+	// scopes are the concrete scopes allowed for the client, its patterns and implied scopes being expanded
+	scopes []string
 }
 
-func NewClientFacade(client Client, relyingParty rp.RelyingParty) *clientFacade {
+func NewClientFacade(client Client, lifetimes TokenLifetimes) *clientFacade {
 	return &clientFacade{
-		Client:       client,
-		relyingParty: relyingParty,
+		Client:    client,
+		lifetimes: lifetimes.ForClient(client),
+		scopes:    client.GetScopes(),
 	}
 }
 
@@ -54,13 +70,21 @@ func (c *clientFacade) ApplicationType() op.ApplicationType {
 	return op.ApplicationTypeWeb
 }
 
-// AuthMethod must return the authentication method (client_secret_basic, client_secret_post, none, private_key_jwt)
+// AuthMethod must return the authentication method (client_secret_basic, client_secret_post, none, private_key_jwt,
+// tls_client_auth, self_signed_tls_client_auth)
 func (c *clientFacade) AuthMethod() oidc.AuthMethod {
-	authMethod := oidc.AuthMethodNone
-	if !c.Client.IsPublic() {
-		authMethod = oidc.AuthMethodBasic
+	switch {
+	case c.Client.IsPublic():
+		return oidc.AuthMethodNone
+	case c.Client.GetTLSClientAuth() != nil && c.Client.GetTLSClientAuth().SelfSigned:
+		return AuthMethodSelfSignedTLSClientAuth
+	case c.Client.GetTLSClientAuth() != nil:
+		return AuthMethodTLSClientAuth
+	case c.Client.UsesPrivateKeyJWT():
+		return oidc.AuthMethodPrivateKeyJWT
+	default:
+		return oidc.AuthMethodBasic
 	}
-	return authMethod
 }
 
 // ResponseTypes must return all allowed response types (code, id_token token, id_token)
@@ -70,6 +94,7 @@ func (c *clientFacade) ResponseTypes() []oidc.ResponseType {
 }
 
 // GrantTypes must return all allowed grant types (authorization_code, refresh_token, urn:ietf:params:oauth:grant-type:jwt-bearer)
+// the optional grant types (device_code) are enabled on the client, the token exchange by its policy
 func (c *clientFacade) GrantTypes() []oidc.GrantType {
 	grantTypes := []oidc.GrantType{
 		oidc.GrantTypeCode,
@@ -78,15 +103,21 @@ func (c *clientFacade) GrantTypes() []oidc.GrantType {
 	if !c.Client.IsPublic() {
 		grantTypes = append(grantTypes, oidc.GrantTypeClientCredentials)
 	}
+	for _, grantType := range c.Client.GetGrantTypes() {
+		grantTypes = append(grantTypes, oidc.GrantType(grantType))
+	}
+	if c.Client.GetTokenExchangePolicy() != nil {
+		grantTypes = append(grantTypes, oidc.GrantTypeTokenExchange)
+	}
 	return grantTypes
 }
 
-// LoginURL will be called to redirect the user (agent) to the login UI
-// you could implement some logic here to redirect the users to different login UIs depending on the client
+// LoginURL will be called to redirect the user (agent) to the login UI,
+// the login page selects the upstream identity provider of the user
 func (c *clientFacade) LoginURL(id string) string {
-	return rp.AuthURL(delegatedauth.DelegatedState{
-		AuthRequestID: id,
-	}.EncodeAsUrlParam(), c.relyingParty)
+	return loginURL(url.Values{
+		authRequestIDParameter: []string{id},
+	})
 }
 
 // AccessTokenType must return the type of access token the client uses (Bearer (opaque) or JWT)
@@ -96,7 +127,7 @@ func (c *clientFacade) AccessTokenType() op.AccessTokenType {
 
 // IDTokenLifetime must return the lifetime of the client's id_tokens
 func (c *clientFacade) IDTokenLifetime() time.Duration {
-	return 1 * time.Hour
+	return c.lifetimes.IDToken
 }
 
 // DevMode enables the use of non-compliant configs such as redirect_uris (e.g. http schema for user agent client)
@@ -120,7 +151,7 @@ func (c *clientFacade) RestrictAdditionalAccessTokenScopes() func(scopes []strin
 
 // IsScopeAllowed enables Client specific custom scopes validation
 func (c *clientFacade) IsScopeAllowed(label string) bool {
-	for _, scope := range c.Client.GetScopes() {
+	for _, scope := range c.scopes {
 		if scope == label {
 			return true
 		}
@@ -141,3 +172,29 @@ func (c *clientFacade) IDTokenUserinfoClaimsAssertion() bool {
 func (c *clientFacade) ClockSkew() time.Duration {
 	return 0
 }
+
+// authenticateRequestClient authenticates the client of a request to an endpoint handled outside the library
+// (token requests, pushed authorization requests), as the library only authenticates the clients using basic auth or assertions
+func authenticateRequestClient(r *http.Request, provider op.OpenIDProvider) (op.Client, error) {
+	clientID, authenticated, err := op.ClientIDFromRequest(r, provider)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := provider.Storage().GetClientByClientID(r.Context(), clientID)
+	if err != nil {
+		return nil, oidc.ErrInvalidClient().WithParent(err)
+	}
+	// the application type of the clients is always web, the public clients are the ones without authentication
+	confidential := client.AuthMethod() != oidc.AuthMethodNone
+	if !authenticated && confidential {
+		if err := provider.Storage().AuthorizeClientIDSecret(r.Context(), clientID, r.PostForm.Get("client_secret")); err != nil {
+			return nil, oidc.ErrInvalidClient().WithParent(err)
+		}
+		authenticated = true
+	}
+	if authenticated != confidential {
+		return nil, oidc.ErrInvalidClient().WithDescription("confidential client requires authentication")
+	}
+	return client, nil
+}
@@ -0,0 +1,120 @@
+package oidc
+
+import (
+	"net/http"
+	"time"
+
+	httphelper "github.com/zitadel/oidc/v2/pkg/http"
+	"github.com/zitadel/oidc/v2/pkg/oidc"
+	"github.com/zitadel/oidc/v2/pkg/op"
+)
+
+// isClientCredentialsWithAssertion returns true for client_credentials requests authenticated with a client assertion (RFC 7523)
+func isClientCredentialsWithAssertion(r *http.Request) bool {
+	return r.URL.Path == op.DefaultEndpoints.Token.Relative() &&
+		r.FormValue("grant_type") == string(oidc.GrantTypeClientCredentials) &&
+		r.FormValue("client_assertion_type") == oidc.ClientAssertionTypeJWTAssertion
+}
+
+// clientCredentialsWithAssertion handles the client_credentials grant for the clients using private_key_jwt,
+// as the library only supports client secrets for this grant.
+func clientCredentialsWithAssertion(provider op.OpenIDProvider) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		exchanger, ok := provider.(op.JWTAuthorizationGrantExchanger)
+		if !ok || !provider.AuthMethodPrivateKeyJWTSupported() {
+			op.RequestError(w, r, oidc.ErrInvalidClient().WithDescription("auth_method private_key_jwt not supported"))
+			return
+		}
+
+		storage, ok := provider.Storage().(op.ClientCredentialsStorage)
+		if !ok {
+			op.RequestError(w, r, oidc.ErrUnsupportedGrantType().WithDescription("client_credentials grant not supported"))
+			return
+		}
+
+		request, err := op.ParseClientCredentialsRequest(r, provider.Decoder())
+		if err != nil {
+			op.RequestError(w, r, err)
+			return
+		}
+
+		client, err := op.AuthorizePrivateJWTKey(r.Context(), request.ClientAssertion, exchanger)
+		if err != nil {
+			op.RequestError(w, r, oidc.ErrInvalidClient().WithParent(err))
+			return
+		}
+		if !op.ValidateGrantType(client, oidc.GrantTypeClientCredentials) {
+			op.RequestError(w, r, oidc.ErrUnauthorizedClient())
+			return
+		}
+
+		tokenRequest, err := storage.ClientCredentialsTokenRequest(r.Context(), client.GetID(), request.Scope)
+		if err != nil {
+			op.RequestError(w, r, err)
+			return
+		}
+
+		response, err := op.CreateClientCredentialsTokenResponse(r.Context(), tokenRequest, provider, client)
+		if err != nil {
+			op.RequestError(w, r, err)
+			return
+		}
+
+		httphelper.MarshalJSON(w, response)
+	}
+}
+
+// isClientAssertion returns true for the requests authenticated with a client assertion (private_key_jwt)
+func isClientAssertion(r *http.Request) bool {
+	return r.FormValue("client_assertion_type") == oidc.ClientAssertionTypeJWTAssertion &&
+		r.FormValue("client_assertion") != ""
+}
+
+// preventClientAssertionReplay rejects the client assertions already used (RFC 7523 section 3),
+// their jti is recorded until their expiration once their signature is verified.
+// The assertions which can not be verified are left to the library, which rejects them.
+func preventClientAssertionReplay(provider op.OpenIDProvider, next http.Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !isClientAssertion(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		exchanger, ok := provider.(op.JWTAuthorizationGrantExchanger)
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+		replays, ok := provider.Storage().(ReplayStorage)
+		if !ok {
+			op.RequestError(w, r, oidc.ErrServerError().WithDescription("client assertions replay detection not supported"))
+			return
+		}
+
+		verifier := exchanger.JWTProfileVerifier(r.Context())
+		assertion, err := op.VerifyJWTAssertion(r.Context(), r.FormValue("client_assertion"), verifier)
+		if err != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		jti, _ := assertion.GetCustomClaim("jti").(string)
+		if jti == "" {
+			op.RequestError(w, r, oidc.ErrInvalidClient().WithDescription("client assertion must have a jti"))
+			return
+		}
+		// the library accepts the assertion until its expiration plus the offset of the verifier
+		unused, err := replays.UseJWTID(r.Context(), "client_assertion:"+assertion.Issuer+":"+jti,
+			assertion.GetExpiration().Add(verifier.Offset()), time.Now())
+		if err != nil {
+			op.RequestError(w, r, oidc.ErrServerError().WithParent(err))
+			return
+		}
+		if !unused {
+			op.RequestError(w, r, oidc.ErrInvalidClient().WithDescription("client assertion already used"))
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	}
+}
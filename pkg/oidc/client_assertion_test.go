@@ -0,0 +1,137 @@
+package oidc_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	auth "github.com/formancehq/auth/pkg"
+	"github.com/formancehq/auth/pkg/storage/sqlstorage"
+	"github.com/google/uuid"
+	"github.com/oauth2-proxy/mockoidc"
+	"github.com/stretchr/testify/require"
+	zoidc "github.com/zitadel/oidc/v2/pkg/oidc"
+	"github.com/zitadel/oidc/v2/pkg/op"
+	"gopkg.in/go-jose/go-jose.v2"
+	"gopkg.in/go-jose/go-jose.v2/jwt"
+)
+
+func signClientAssertion(t *testing.T, key *rsa.PrivateKey, keyID, clientID, audience string) string {
+	signer, err := jose.NewSigner(jose.SigningKey{
+		Algorithm: jose.RS256,
+		Key: jose.JSONWebKey{
+			Key:   key,
+			KeyID: keyID,
+		},
+	}, (&jose.SignerOptions{}).WithType("JWT"))
+	require.NoError(t, err)
+
+	now := time.Now()
+	assertion, err := jwt.Signed(signer).Claims(jwt.Claims{
+		Issuer:   clientID,
+		Subject:  clientID,
+		Audience: jwt.Audience{audience},
+		ID:       uuid.NewString(),
+		IssuedAt: jwt.NewNumericDate(now),
+		Expiry:   jwt.NewNumericDate(now.Add(time.Minute)),
+	}).CompactSerialize()
+	require.NoError(t, err)
+
+	return assertion
+}
+
+func requestClientCredentialsWithAssertion(t *testing.T, issuer, assertion string) *http.Response {
+	form := url.Values{
+		"grant_type":            []string{string(zoidc.GrantTypeClientCredentials)},
+		"client_assertion_type": []string{zoidc.ClientAssertionTypeJWTAssertion},
+		"client_assertion":      []string{assertion},
+	}
+	rsp, err := http.Post(issuer+op.DefaultEndpoints.Token.Relative(), "application/x-www-form-urlencoded",
+		strings.NewReader(form.Encode()))
+	require.NoError(t, err)
+	return rsp
+}
+
+func TestClientCredentialsWithPrivateKeyJWT(t *testing.T) {
+	withServer(t, func(m *mockoidc.MockOIDC, storage *sqlstorage.Storage, issuer string, provider op.OpenIDProvider) {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+
+		client := auth.NewClient(auth.ClientOptions{
+			JWKS: &auth.JSONWebKeySet{
+				JSONWebKeySet: jose.JSONWebKeySet{
+					Keys: []jose.JSONWebKey{{
+						Key:       &key.PublicKey,
+						KeyID:     "key1",
+						Algorithm: string(jose.RS256),
+						Use:       "sig",
+					}},
+				},
+			},
+		})
+		_, clear := client.GenerateNewSecret(auth.SecretCreate{})
+		require.NoError(t, storage.SaveClient(context.TODO(), client))
+
+		assertion := signClientAssertion(t, key, "key1", client.Id, issuer)
+		rsp := requestClientCredentialsWithAssertion(t, issuer, assertion)
+		require.Equal(t, http.StatusOK, rsp.StatusCode)
+
+		tokens := zoidc.AccessTokenResponse{}
+		require.NoError(t, json.NewDecoder(rsp.Body).Decode(&tokens))
+		require.NotEmpty(t, tokens.AccessToken)
+
+		// An assertion cannot be replayed
+		rsp = requestClientCredentialsWithAssertion(t, issuer, assertion)
+		require.Equal(t, http.StatusUnauthorized, rsp.StatusCode)
+
+		// An assertion signed by an unknown key is rejected
+		otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+		rsp = requestClientCredentialsWithAssertion(t, issuer, signClientAssertion(t, otherKey, "key1", client.Id, issuer))
+		require.Equal(t, http.StatusUnauthorized, rsp.StatusCode)
+
+		// The client cannot use its secret anymore
+		req, err := http.NewRequest(http.MethodPost, issuer+op.DefaultEndpoints.Token.Relative(),
+			strings.NewReader(url.Values{"grant_type": []string{string(zoidc.GrantTypeClientCredentials)}}.Encode()))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.SetBasicAuth(client.Id, clear)
+		rsp, err = http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.NotEqual(t, http.StatusOK, rsp.StatusCode)
+	})
+}
+
+func TestClientCredentialsWithPrivateKeyJWTFromJWKSURI(t *testing.T) {
+	withServer(t, func(m *mockoidc.MockOIDC, storage *sqlstorage.Storage, issuer string, provider op.OpenIDProvider) {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+
+		jwksServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, json.NewEncoder(w).Encode(jose.JSONWebKeySet{
+				Keys: []jose.JSONWebKey{{
+					Key:       &key.PublicKey,
+					KeyID:     "key1",
+					Algorithm: string(jose.RS256),
+					Use:       "sig",
+				}},
+			}))
+		}))
+		defer jwksServer.Close()
+
+		client := auth.NewClient(auth.ClientOptions{
+			JWKSURI: jwksServer.URL,
+		})
+		require.NoError(t, storage.SaveClient(context.TODO(), client))
+
+		rsp := requestClientCredentialsWithAssertion(t, issuer, signClientAssertion(t, key, "key1", client.Id, issuer))
+		require.Equal(t, http.StatusOK, rsp.StatusCode)
+	})
+}
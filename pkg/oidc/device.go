@@ -0,0 +1,206 @@
+package oidc
+
+import (
+	"context"
+	"html/template"
+	"net/http"
+	"net/url"
+	"time"
+
+	httphelper "github.com/zitadel/oidc/v2/pkg/http"
+	"github.com/zitadel/oidc/v2/pkg/oidc"
+	"github.com/zitadel/oidc/v2/pkg/op"
+)
+
+const (
+	// DeviceVerificationPath is the page where the users enter the user code displayed by their device
+	DeviceVerificationPath = "/device"
+
+	// DeviceCodeLifetime is the delay given to the user to approve a device
+	DeviceCodeLifetime = 10 * time.Minute
+	// DevicePollInterval is the minimum delay between two token requests of a device
+	DevicePollInterval = 5 * time.Second
+	// devicePollIntervalTolerance absorbs the network jitter of the devices polling at the interval
+	devicePollIntervalTolerance = time.Second
+)
+
+// DeviceAuthorizationEndpoint is the endpoint where the devices start the device authorization grant (RFC 8628)
+var DeviceAuthorizationEndpoint = op.NewEndpoint("oauth/device_authorization")
+
+type DeviceAccessTokenStorage interface {
+	// DeviceAccessTokenRequest returns the token request of a device authorization approved by the user
+	// and consumes its device code, or the error to return to the polling device (authorization_pending, slow_down, ...)
+	DeviceAccessTokenRequest(ctx context.Context, clientID, deviceCode string) (op.IDTokenRequest, error)
+}
+
+// isDeviceAccessTokenRequest returns true for the token requests of the device authorization grant
+func isDeviceAccessTokenRequest(r *http.Request) bool {
+	return r.URL.Path == op.DefaultEndpoints.Token.Relative() &&
+		r.FormValue("grant_type") == string(oidc.GrantTypeDeviceCode)
+}
+
+// deviceAccessToken handles the token requests of the device authorization grant,
+// as the library issues the tokens without client, refresh token nor id token.
+func deviceAccessToken(provider op.OpenIDProvider) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		storage, ok := provider.Storage().(DeviceAccessTokenStorage)
+		if !ok {
+			op.RequestError(w, r, oidc.ErrUnsupportedGrantType().WithDescription("device_code grant not supported"))
+			return
+		}
+
+		client, err := authenticateRequestClient(r, provider)
+		if err != nil {
+			op.RequestError(w, r, err)
+			return
+		}
+		request, err := op.ParseDeviceAccessTokenRequest(r, provider)
+		if err != nil {
+			op.RequestError(w, r, oidc.ErrInvalidRequest().WithParent(err))
+			return
+		}
+		if !op.ValidateGrantType(client, oidc.GrantTypeDeviceCode) {
+			op.RequestError(w, r, oidc.ErrUnauthorizedClient())
+			return
+		}
+
+		tokenRequest, err := storage.DeviceAccessTokenRequest(r.Context(), client.GetID(), request.DeviceCode)
+		if err != nil {
+			op.RequestError(w, r, err)
+			return
+		}
+
+		response, err := op.CreateTokenResponse(r.Context(), tokenRequest, client, provider, true, "", "")
+		if err != nil {
+			op.RequestError(w, r, err)
+			return
+		}
+
+		httphelper.MarshalJSON(w, response)
+	}
+}
+
+// deviceLoginURL returns the url of the login page for the user approving a device
+func deviceLoginURL(userCode string) string {
+	return loginURL(url.Values{
+		userCodeParameter: []string{userCode},
+	})
+}
+
+// isSameOrigin protects the verification form against cross site requests,
+// the browsers send the origin of the page which submitted the form
+func isSameOrigin(r *http.Request, issuer string) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	originURL, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	issuerURL, err := url.Parse(issuer)
+	if err != nil {
+		return false
+	}
+	return originURL.Scheme == issuerURL.Scheme && originURL.Host == issuerURL.Host
+}
+
+func renderDevicePage(w http.ResponseWriter, status int, data map[string]any) {
+	tpl := template.Must(template.New("device.tmpl").
+		ParseFS(templateFs, "templates/device.tmpl"))
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	if err := tpl.Execute(w, data); err != nil {
+		panic(err)
+	}
+}
+
+// pendingDeviceAuthorization returns the device authorization of a user code if the user can still approve it
+func pendingDeviceAuthorization(ctx context.Context, storage op.DeviceAuthorizationStorage, userCode string) (*op.DeviceAuthorizationState, bool) {
+	if userCode == "" {
+		return nil, false
+	}
+	state, err := storage.GetDeviceAuthorizationByUserCode(ctx, userCode)
+	if err != nil || state.Done || state.Denied || time.Now().After(state.Expires) {
+		return nil, false
+	}
+	return state, true
+}
+
+// deviceVerificationHandler serves the page where the user enters the user code of a device,
+// then approves the device by login in with an upstream identity provider, or denies it
+func deviceVerificationHandler(provider op.OpenIDProvider) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		storage, ok := provider.Storage().(op.DeviceAuthorizationStorage)
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		if r.Method == http.MethodGet {
+			userCode := r.URL.Query().Get("user_code")
+			data := map[string]any{
+				"UserCode": userCode,
+			}
+			if userCode != "" {
+				if state, ok := pendingDeviceAuthorization(r.Context(), storage, userCode); ok {
+					data["ClientID"] = state.ClientID
+					data["Scopes"] = state.Scopes
+				} else {
+					data["Error"] = "Invalid or expired code"
+				}
+			}
+			renderDevicePage(w, http.StatusOK, data)
+			return
+		}
+
+		if !isSameOrigin(r, provider.IssuerFromRequest(r)) {
+			renderDevicePage(w, http.StatusForbidden, map[string]any{
+				"Error": "Cross origin request",
+			})
+			return
+		}
+
+		userCode := r.PostFormValue("user_code")
+		if _, ok := pendingDeviceAuthorization(r.Context(), storage, userCode); !ok {
+			renderDevicePage(w, http.StatusBadRequest, map[string]any{
+				"UserCode": userCode,
+				"Error":    "Invalid or expired code",
+			})
+			return
+		}
+
+		if r.PostFormValue("action") == "deny" {
+			if err := storage.DenyDeviceAuthorization(r.Context(), userCode); err != nil {
+				panic(err)
+			}
+			renderDevicePage(w, http.StatusOK, map[string]any{
+				"Message": "The device has been denied, you can close this page",
+			})
+			return
+		}
+
+		http.Redirect(w, r, deviceLoginURL(userCode), http.StatusFound)
+	}
+}
+
+// deviceAuthorizationCallback approves the device once the user has logged in with an upstream identity provider
+func deviceAuthorizationCallback(w http.ResponseWriter, r *http.Request, provider op.OpenIDProvider, userCode, userID string) {
+	storage, ok := provider.Storage().(op.DeviceAuthorizationStorage)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	if _, ok := pendingDeviceAuthorization(r.Context(), storage, userCode); !ok {
+		renderDevicePage(w, http.StatusBadRequest, map[string]any{
+			"Error": "Invalid or expired code",
+		})
+		return
+	}
+	if err := storage.CompleteDeviceAuthorization(r.Context(), userCode, userID); err != nil {
+		panic(err)
+	}
+	renderDevicePage(w, http.StatusOK, map[string]any{
+		"Message": "The device has been approved, you can close this page",
+	})
+}
@@ -0,0 +1,105 @@
+package oidc_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	auth "github.com/formancehq/auth/pkg"
+	"github.com/formancehq/auth/pkg/oidc"
+	"github.com/formancehq/auth/pkg/storage/sqlstorage"
+	"github.com/oauth2-proxy/mockoidc"
+	"github.com/stretchr/testify/require"
+	zoidc "github.com/zitadel/oidc/v2/pkg/oidc"
+	"github.com/zitadel/oidc/v2/pkg/op"
+)
+
+func postForm(t *testing.T, uri string, form url.Values) (int, map[string]any) {
+	rsp, err := http.PostForm(uri, form)
+	require.NoError(t, err)
+	defer func() { _ = rsp.Body.Close() }()
+
+	body := map[string]any{}
+	require.NoError(t, json.NewDecoder(rsp.Body).Decode(&body))
+	return rsp.StatusCode, body
+}
+
+func TestDeviceAuthorizationGrant(t *testing.T) {
+	withServer(t, func(m *mockoidc.MockOIDC, storage *sqlstorage.Storage, issuer string, provider op.OpenIDProvider) {
+		client := auth.NewClient(auth.ClientOptions{
+			Public:     true,
+			Scopes:     []string{"ledger:read"},
+			GrantTypes: []string{string(zoidc.GrantTypeDeviceCode)},
+		})
+		require.NoError(t, storage.SaveClient(context.TODO(), client))
+
+		status, deviceAuthorization := postForm(t, issuer+oidc.DeviceAuthorizationEndpoint.Relative(), url.Values{
+			"client_id": []string{client.Id},
+			"scope":     []string{"openid email offline_access ledger:read"},
+		})
+		require.Equal(t, http.StatusOK, status)
+		require.Equal(t, issuer+oidc.DeviceVerificationPath, deviceAuthorization["verification_uri"])
+
+		tokenRequest := url.Values{
+			"grant_type":  []string{string(zoidc.GrantTypeDeviceCode)},
+			"client_id":   []string{client.Id},
+			"device_code": []string{deviceAuthorization["device_code"].(string)},
+		}
+		tokenURL := issuer + op.DefaultEndpoints.Token.Relative()
+
+		status, rsp := postForm(t, tokenURL, tokenRequest)
+		require.Equal(t, http.StatusBadRequest, status)
+		require.Equal(t, "authorization_pending", rsp["error"])
+
+		// The device polls faster than the interval
+		status, rsp = postForm(t, tokenURL, tokenRequest)
+		require.Equal(t, http.StatusBadRequest, status)
+		require.Equal(t, "slow_down", rsp["error"])
+
+		// The user enters the code and logs in with the delegated identity provider
+		m.QueueUser(&user{
+			MockUser: mockoidc.DefaultUser(),
+		})
+		verification, err := http.Get(deviceAuthorization["verification_uri_complete"].(string))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, verification.StatusCode)
+
+		approval, err := http.PostForm(issuer+oidc.DeviceVerificationPath, url.Values{
+			"user_code": []string{deviceAuthorization["user_code"].(string)},
+			"action":    []string{"approve"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, approval.StatusCode)
+
+		status, rsp = postForm(t, tokenURL, tokenRequest)
+		require.Equal(t, http.StatusOK, status)
+		require.NotEmpty(t, rsp["access_token"])
+		require.NotEmpty(t, rsp["refresh_token"])
+		require.NotEmpty(t, rsp["id_token"])
+
+		// The device code can be used only once
+		status, rsp = postForm(t, tokenURL, tokenRequest)
+		require.Equal(t, http.StatusBadRequest, status)
+		require.Equal(t, "invalid_grant", rsp["error"])
+	})
+}
+
+func TestDeviceAuthorizationGrantNotEnabled(t *testing.T) {
+	withServer(t, func(m *mockoidc.MockOIDC, storage *sqlstorage.Storage, issuer string, provider op.OpenIDProvider) {
+		client := auth.NewClient(auth.ClientOptions{
+			Public: true,
+		})
+		require.NoError(t, storage.SaveClient(context.TODO(), client))
+
+		rsp, err := http.Post(issuer+oidc.DeviceAuthorizationEndpoint.Relative(), "application/x-www-form-urlencoded",
+			strings.NewReader(url.Values{
+				"client_id": []string{client.Id},
+				"scope":     []string{"openid"},
+			}.Encode()))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, rsp.StatusCode)
+	})
+}
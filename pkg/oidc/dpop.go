@@ -0,0 +1,181 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/formancehq/go-libs/v3/collectionutils"
+	"github.com/zitadel/oidc/v2/pkg/oidc"
+	"gopkg.in/go-jose/go-jose.v2"
+	"gopkg.in/go-jose/go-jose.v2/jwt"
+)
+
+const (
+	DPoPHeader    = "DPoP"
+	DPoPTokenType = "DPoP"
+	// ConfirmationKeyThumbprintKey is the confirmation method of the tokens bound to a DPoP key
+	ConfirmationKeyThumbprintKey = "jkt"
+
+	dpopProofType = "dpop+jwt"
+	// dpopProofMaxAge is the delay during which a proof is accepted after its iat, its jti is remembered as long to detect replays
+	dpopProofMaxAge = 5 * time.Minute
+	// dpopProofMaxClockSkew tolerates proofs issued by clients whose clock is ahead of ours
+	dpopProofMaxClockSkew = time.Minute
+)
+
+// DPoPSigningAlgorithms are the algorithms accepted for the DPoP proofs, published in the discovery document
+var DPoPSigningAlgorithms = []jose.SignatureAlgorithm{
+	jose.RS256, jose.RS384, jose.RS512,
+	jose.PS256, jose.PS384, jose.PS512,
+	jose.ES256, jose.ES384, jose.ES512,
+	jose.EdDSA,
+}
+
+func errInvalidDPoPProof() *oidc.Error {
+	return &oidc.Error{
+		ErrorType: "invalid_dpop_proof",
+	}
+}
+
+type dpopKeyThumbprintContextKey struct{}
+
+// ContextWithDPoPKeyThumbprint stores the thumbprint of the key of a verified DPoP proof
+func ContextWithDPoPKeyThumbprint(ctx context.Context, thumbprint string) context.Context {
+	return context.WithValue(ctx, dpopKeyThumbprintContextKey{}, thumbprint)
+}
+
+// DPoPKeyThumbprintFromContext returns the thumbprint of the key of the DPoP proof of the request, empty if none
+func DPoPKeyThumbprintFromContext(ctx context.Context) string {
+	thumbprint, _ := ctx.Value(dpopKeyThumbprintContextKey{}).(string)
+	return thumbprint
+}
+
+// dpopKeyThumbprint returns the thumbprint of the DPoP key the tokens must be bound to,
+// the request is rejected if the client requires DPoP and has not sent a proof
+func dpopKeyThumbprint(ctx context.Context, client Client) (string, error) {
+	thumbprint := DPoPKeyThumbprintFromContext(ctx)
+	if thumbprint == "" && client != nil && client.IsDPoPRequired() {
+		return "", errInvalidDPoPProof().WithDescription("DPoP proof required")
+	}
+	return thumbprint, nil
+}
+
+type dpopProofClaims struct {
+	ID         string           `json:"jti"`
+	HTTPMethod string           `json:"htm"`
+	HTTPURI    string           `json:"htu"`
+	IssuedAt   *jwt.NumericDate `json:"iat"`
+}
+
+// ReplayStorage records the ids of the single use JWTs, shared by all the instances of the server
+type ReplayStorage interface {
+	// UseJWTID returns false if the id is already recorded and has not expired
+	UseJWTID(ctx context.Context, id string, expiration time.Time, now time.Time) (bool, error)
+}
+
+// dpopVerifier verifies the DPoP proofs (RFC 9449) presented on the token endpoint.
+// The ids of the accepted proofs are recorded in the storage to reject the replays.
+type dpopVerifier struct {
+	replays ReplayStorage
+}
+
+// sameURI compares the htu claim of a proof to the uri of the request, ignoring the query and fragment
+func sameURI(htu, expected string) bool {
+	parsedHTU, err := url.Parse(htu)
+	if err != nil {
+		return false
+	}
+	parsedExpected, err := url.Parse(expected)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(parsedHTU.Scheme, parsedExpected.Scheme) &&
+		strings.EqualFold(parsedHTU.Host, parsedExpected.Host) &&
+		parsedHTU.Path == parsedExpected.Path
+}
+
+// Verify validates the DPoP proof of the request for the given uri and returns the thumbprint of its key.
+// It returns an empty thumbprint if the request has no proof.
+func (v *dpopVerifier) Verify(r *http.Request, uri string, now time.Time) (string, error) {
+	values := r.Header.Values(DPoPHeader)
+	switch len(values) {
+	case 0:
+		return "", nil
+	case 1:
+	default:
+		return "", errInvalidDPoPProof().WithDescription("multiple DPoP proofs")
+	}
+
+	proof, err := jose.ParseSigned(values[0])
+	if err != nil {
+		return "", errInvalidDPoPProof().WithDescription("malformed DPoP proof").WithParent(err)
+	}
+	if len(proof.Signatures) != 1 {
+		return "", errInvalidDPoPProof().WithDescription("DPoP proof must have a single signature")
+	}
+
+	header := proof.Signatures[0].Protected
+	if typ, _ := header.ExtraHeaders[jose.HeaderType].(string); typ != dpopProofType {
+		return "", errInvalidDPoPProof().WithDescription("invalid DPoP proof type '%s'", typ)
+	}
+	if !collectionutils.Contains(DPoPSigningAlgorithms, jose.SignatureAlgorithm(header.Algorithm)) {
+		return "", errInvalidDPoPProof().WithDescription("unsupported DPoP proof algorithm '%s'", header.Algorithm)
+	}
+	if header.JSONWebKey == nil || !header.JSONWebKey.IsPublic() {
+		return "", errInvalidDPoPProof().WithDescription("DPoP proof must embed a public key")
+	}
+
+	payload, err := proof.Verify(header.JSONWebKey)
+	if err != nil {
+		return "", errInvalidDPoPProof().WithDescription("invalid DPoP proof signature").WithParent(err)
+	}
+
+	claims := dpopProofClaims{}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return "", errInvalidDPoPProof().WithDescription("malformed DPoP proof claims").WithParent(err)
+	}
+	switch {
+	case claims.ID == "":
+		return "", errInvalidDPoPProof().WithDescription("missing jti")
+	case claims.HTTPMethod != r.Method:
+		return "", errInvalidDPoPProof().WithDescription("htm does not match the request method")
+	case !sameURI(claims.HTTPURI, uri):
+		return "", errInvalidDPoPProof().WithDescription("htu does not match the request uri")
+	case claims.IssuedAt == nil:
+		return "", errInvalidDPoPProof().WithDescription("missing iat")
+	}
+	issuedAt := claims.IssuedAt.Time()
+	if issuedAt.Before(now.Add(-dpopProofMaxAge)) || issuedAt.After(now.Add(dpopProofMaxClockSkew)) {
+		return "", errInvalidDPoPProof().WithDescription("DPoP proof expired or issued in the future")
+	}
+
+	thumbprint, err := header.JSONWebKey.Thumbprint(crypto.SHA256)
+	if err != nil {
+		return "", errInvalidDPoPProof().WithParent(err)
+	}
+	keyThumbprint := base64.RawURLEncoding.EncodeToString(thumbprint)
+
+	// the proof is remembered as long as its iat is accepted
+	unused, err := v.replays.UseJWTID(r.Context(), "dpop:"+keyThumbprint+":"+claims.ID,
+		issuedAt.Add(dpopProofMaxAge), now)
+	if err != nil {
+		return "", oidc.ErrServerError().WithParent(err)
+	}
+	if !unused {
+		return "", errInvalidDPoPProof().WithDescription("DPoP proof already used")
+	}
+
+	return keyThumbprint, nil
+}
+
+func newDPoPVerifier(replays ReplayStorage) *dpopVerifier {
+	return &dpopVerifier{
+		replays: replays,
+	}
+}
@@ -0,0 +1,143 @@
+package oidc_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	auth "github.com/formancehq/auth/pkg"
+	"github.com/formancehq/auth/pkg/oidc"
+	"github.com/formancehq/auth/pkg/storage/sqlstorage"
+	"github.com/google/uuid"
+	"github.com/oauth2-proxy/mockoidc"
+	"github.com/stretchr/testify/require"
+	zoidc "github.com/zitadel/oidc/v2/pkg/oidc"
+	"github.com/zitadel/oidc/v2/pkg/op"
+	"gopkg.in/go-jose/go-jose.v2"
+	"gopkg.in/go-jose/go-jose.v2/jwt"
+)
+
+type dpopProofClaims struct {
+	ID         string           `json:"jti"`
+	HTTPMethod string           `json:"htm"`
+	HTTPURI    string           `json:"htu"`
+	IssuedAt   *jwt.NumericDate `json:"iat"`
+}
+
+func signDPoPProof(t *testing.T, key *ecdsa.PrivateKey, method, uri string) string {
+	signer, err := jose.NewSigner(jose.SigningKey{
+		Algorithm: jose.ES256,
+		Key:       key,
+	}, (&jose.SignerOptions{EmbedJWK: true}).WithType("dpop+jwt"))
+	require.NoError(t, err)
+
+	proof, err := jwt.Signed(signer).Claims(dpopProofClaims{
+		ID:         uuid.NewString(),
+		HTTPMethod: method,
+		HTTPURI:    uri,
+		IssuedAt:   jwt.NewNumericDate(time.Now()),
+	}).CompactSerialize()
+	require.NoError(t, err)
+
+	return proof
+}
+
+func dpopKeyThumbprint(t *testing.T, key *ecdsa.PrivateKey) string {
+	thumbprint, err := (&jose.JSONWebKey{Key: &key.PublicKey}).Thumbprint(crypto.SHA256)
+	require.NoError(t, err)
+	return base64.RawURLEncoding.EncodeToString(thumbprint)
+}
+
+func requestClientCredentialsWithDPoP(t *testing.T, tokenURL, clientID, clientSecret string, proofs ...string) *http.Response {
+	form := url.Values{
+		"grant_type": []string{string(zoidc.GrantTypeClientCredentials)},
+	}
+	req, err := http.NewRequest(http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+	require.NoError(t, err)
+	req.SetBasicAuth(clientID, clientSecret)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	for _, proof := range proofs {
+		req.Header.Add(oidc.DPoPHeader, proof)
+	}
+
+	rsp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	return rsp
+}
+
+func TestClientCredentialsWithDPoP(t *testing.T) {
+	withServer(t, func(m *mockoidc.MockOIDC, storage *sqlstorage.Storage, issuer string, provider op.OpenIDProvider) {
+		client := auth.NewClient(auth.ClientOptions{
+			RequireDPoP: true,
+		})
+		_, clear := client.GenerateNewSecret(auth.SecretCreate{})
+		require.NoError(t, storage.SaveClient(context.TODO(), client))
+
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		tokenURL := issuer + op.DefaultEndpoints.Token.Relative()
+
+		proof := signDPoPProof(t, key, http.MethodPost, tokenURL)
+		rsp := requestClientCredentialsWithDPoP(t, tokenURL, client.Id, clear, proof)
+		require.Equal(t, http.StatusOK, rsp.StatusCode)
+
+		token := zoidc.AccessTokenResponse{}
+		require.NoError(t, json.NewDecoder(rsp.Body).Decode(&token))
+		require.Equal(t, oidc.DPoPTokenType, token.TokenType)
+
+		accessToken, err := jwt.ParseSigned(token.AccessToken)
+		require.NoError(t, err)
+		claims := map[string]any{}
+		require.NoError(t, accessToken.UnsafeClaimsWithoutVerification(&claims))
+		require.Equal(t, map[string]any{
+			oidc.ConfirmationKeyThumbprintKey: dpopKeyThumbprint(t, key),
+		}, claims[oidc.ConfirmationClaim])
+
+		// A proof can be used only once
+		rsp = requestClientCredentialsWithDPoP(t, tokenURL, client.Id, clear, proof)
+		require.Equal(t, http.StatusBadRequest, rsp.StatusCode)
+
+		// The proof must be issued for the token endpoint
+		rsp = requestClientCredentialsWithDPoP(t, tokenURL, client.Id, clear,
+			signDPoPProof(t, key, http.MethodPost, issuer+"/other"))
+		require.Equal(t, http.StatusBadRequest, rsp.StatusCode)
+
+		// The client requires DPoP
+		rsp = requestClientCredentialsWithDPoP(t, tokenURL, client.Id, clear)
+		require.Equal(t, http.StatusBadRequest, rsp.StatusCode)
+	})
+}
+
+func TestDPoPDiscovery(t *testing.T) {
+	withServer(t, func(m *mockoidc.MockOIDC, storage *sqlstorage.Storage, issuer string, provider op.OpenIDProvider) {
+		rsp, err := http.Get(issuer + zoidc.DiscoveryEndpoint)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, rsp.StatusCode)
+
+		discovery := map[string]any{}
+		require.NoError(t, json.NewDecoder(rsp.Body).Decode(&discovery))
+		require.Contains(t, discovery, "dpop_signing_alg_values_supported")
+	})
+}
+
+func TestDPoPBoundClaims(t *testing.T) {
+	t.Parallel()
+
+	storageFacade := oidc.NewStorageFacade(nil, nil, nil, oidc.DefaultTokenLifetimes, oidc.MTLSConfig{}, nil, nil)
+
+	ctx := oidc.ContextWithDPoPKeyThumbprint(context.Background(), "thumbprint")
+	claims, err := storageFacade.GetPrivateClaimsFromScopes(ctx, "", "client1", []string{"scope1"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{
+		oidc.ConfirmationKeyThumbprintKey: "thumbprint",
+	}, claims[oidc.ConfirmationClaim])
+}
@@ -2,37 +2,14 @@ package oidc
 
 import (
 	"context"
-	"fmt"
 	"net/http"
 	"time"
 
 	httphelper "github.com/zitadel/oidc/v2/pkg/http"
 	"github.com/zitadel/oidc/v2/pkg/oidc"
 	"github.com/zitadel/oidc/v2/pkg/op"
-	"gopkg.in/go-jose/go-jose.v2"
 )
 
-type openIDKeySet struct {
-	jose.JSONWebKeySet
-}
-
-// VerifySignature implements the oidc.KeySet interface
-// providing an implementation for the keys stored in the OP Storage interface
-func (o *openIDKeySet) VerifySignature(ctx context.Context, jws *jose.JSONWebSignature) ([]byte, error) {
-	keyID, alg := oidc.GetKeyIDAndAlg(jws)
-
-	var (
-		key jose.JSONWebKey
-		err error
-	)
-	key, err = oidc.FindMatchingKey(keyID, oidc.KeyUseSignature, alg, o.Keys...)
-	if err != nil {
-		return nil, fmt.Errorf("invalid signature: %w", err)
-	}
-
-	return jws.Verify(&key)
-}
-
 func VerifyJWTAssertion(ctx context.Context, assertion string, v JWTProfileVerifier) (*oidc.JWTTokenRequest, error) {
 	request := new(oidc.JWTTokenRequest)
 
@@ -49,9 +26,12 @@ func VerifyJWTAssertion(ctx context.Context, assertion string, v JWTProfileVerif
 		return nil, err
 	}
 
-	accessTokenVerifier := op.NewAccessTokenVerifier(v.DelegatedIssuer(), &openIDKeySet{
-		v.JSONWebKeySet(),
-	})
+	// the assertion is an access token of one of the identity providers
+	keySet, err := v.KeySet(ctx, request.Issuer)
+	if err != nil {
+		return nil, err
+	}
+	accessTokenVerifier := op.NewAccessTokenVerifier(request.Issuer, keySet)
 	if _, err := op.VerifyAccessToken[*oidc.TokenClaims](ctx, assertion, accessTokenVerifier); err != nil {
 		return nil, err
 	}
@@ -61,8 +41,8 @@ func VerifyJWTAssertion(ctx context.Context, assertion string, v JWTProfileVerif
 
 type JWTProfileVerifier interface {
 	oidc.Verifier
-	DelegatedIssuer() string
-	JSONWebKeySet() jose.JSONWebKeySet
+	// KeySet returns the keys of the identity provider of an issuer
+	KeySet(ctx context.Context, issuer string) (oidc.KeySet, error)
 }
 
 type JWTAuthorizationGrantExchanger interface {
@@ -90,7 +70,7 @@ func grantTypeBearer(p JWTAuthorizationGrantExchanger) http.HandlerFunc {
 			}
 			client = c.(*clientFacade)
 			if !client.Client.IsPublic() {
-				if err := client.Client.ValidateSecret(clientSecret); err != nil {
+				if err := p.Storage().AuthorizeClientIDSecret(r.Context(), clientID, clientSecret); err != nil {
 					op.RequestError(w, r, err)
 					return
 				}
@@ -103,20 +83,20 @@ func grantTypeBearer(p JWTAuthorizationGrantExchanger) http.HandlerFunc {
 			return
 		}
 
-		tokenRequest.Scopes, err = p.Storage().ValidateJWTProfileScopes(r.Context(), tokenRequest.Issuer, profileRequest.Scope)
+		tokens, err := ParseAssertion(profileRequest.Assertion)
 		if err != nil {
 			op.RequestError(w, r, err)
 			return
 		}
 
-		tokens, err := ParseAssertion(profileRequest.Assertion)
+		// the scopes of the assertion are granted if the policy allows them
+		tokenRequest.Scopes, err = p.Storage().ValidateJWTProfileScopes(ContextWithUpstreamClaims(r.Context(), tokens.Claims),
+			tokenRequest.Subject, tokens.Scopes)
 		if err != nil {
 			op.RequestError(w, r, err)
 			return
 		}
 
-		tokenRequest.Scopes = tokens.Scopes
-
 		resp, err := CreateJWTTokenResponse(r.Context(), tokenRequest, p, client)
 		if err != nil {
 			op.RequestError(w, r, err)
@@ -0,0 +1,121 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	httphelper "github.com/zitadel/oidc/v2/pkg/http"
+	"github.com/zitadel/oidc/v2/pkg/oidc"
+	"github.com/zitadel/oidc/v2/pkg/op"
+)
+
+const (
+	TokenTypeHintAccessToken  = "access_token"
+	TokenTypeHintRefreshToken = "refresh_token"
+)
+
+// IntrospectionStorage is implemented by the storage to introspect the tokens (RFC 7662).
+// The methods return false if the token is unknown, expired, revoked or not visible to the calling client,
+// in which case the response must not be used.
+type IntrospectionStorage interface {
+	IntrospectAccessToken(ctx context.Context, response *oidc.IntrospectionResponse, tokenID, clientID string) (bool, error)
+	IntrospectRefreshToken(ctx context.Context, response *oidc.IntrospectionResponse, token, clientID string) (bool, error)
+}
+
+// accessTokenID extracts the id of an access token, either a signed JWT or an encrypted opaque token
+func accessTokenID(ctx context.Context, provider op.OpenIDProvider, token string) (string, bool) {
+	tokenIDSubject, err := provider.Crypto().Decrypt(token)
+	if err == nil {
+		tokenID, _, ok := strings.Cut(tokenIDSubject, ":")
+		return tokenID, ok
+	}
+
+	claims, err := op.VerifyAccessToken[*oidc.AccessTokenClaims](ctx, token, provider.AccessTokenVerifier(ctx))
+	if err != nil {
+		return "", false
+	}
+	return claims.JWTID, true
+}
+
+// introspect looks up the token, starting with the type given by the hint.
+// As specified by the RFC, the other type is tried if the token is not found.
+func introspect(ctx context.Context, provider op.OpenIDProvider, storage IntrospectionStorage,
+	response *oidc.IntrospectionResponse, token, tokenTypeHint, clientID string) (bool, error) {
+
+	lookupAccessToken := func() (bool, error) {
+		tokenID, ok := accessTokenID(ctx, provider, token)
+		if !ok {
+			return false, nil
+		}
+		return storage.IntrospectAccessToken(ctx, response, tokenID, clientID)
+	}
+	lookupRefreshToken := func() (bool, error) {
+		return storage.IntrospectRefreshToken(ctx, response, token, clientID)
+	}
+
+	lookups := []func() (bool, error){lookupAccessToken, lookupRefreshToken}
+	if tokenTypeHint == TokenTypeHintRefreshToken {
+		lookups = []func() (bool, error){lookupRefreshToken, lookupAccessToken}
+	}
+
+	for _, lookup := range lookups {
+		active, err := lookup()
+		if err != nil {
+			return false, err
+		}
+		if active {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// introspectionHandler replaces the introspection endpoint of the library,
+// which marks as active every token accepted by the storage and ignores refresh tokens.
+func introspectionHandler(provider op.OpenIDProvider) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		storage, ok := provider.Storage().(IntrospectionStorage)
+		if !ok {
+			op.RequestError(w, r, oidc.ErrServerError().WithDescription("introspection not supported"))
+			return
+		}
+
+		clientID, authenticated, err := op.ClientIDFromRequest(r, provider)
+		if err != nil {
+			op.RequestError(w, r, err)
+			return
+		}
+		if !authenticated {
+			// client_secret_post or mutual TLS authentication
+			clientSecret := r.Form.Get("client_secret")
+			if clientSecret == "" && ClientCertificateFromContext(r.Context()) == nil {
+				op.RequestError(w, r, oidc.ErrInvalidClient().WithParent(op.ErrNoClientCredentials))
+				return
+			}
+			if err := provider.Storage().AuthorizeClientIDSecret(r.Context(), clientID, clientSecret); err != nil {
+				op.RequestError(w, r, oidc.ErrInvalidClient().WithParent(err))
+				return
+			}
+		}
+
+		token := r.Form.Get("token")
+		if token == "" {
+			op.RequestError(w, r, oidc.ErrInvalidRequest().WithDescription("token missing"))
+			return
+		}
+
+		response := &oidc.IntrospectionResponse{}
+		active, err := introspect(r.Context(), provider, storage, response, token, r.Form.Get("token_type_hint"), clientID)
+		if err != nil {
+			op.RequestError(w, r, oidc.ErrServerError().WithParent(err))
+			return
+		}
+		if !active {
+			// Inactive tokens must not disclose any information
+			response = &oidc.IntrospectionResponse{}
+		}
+
+		httphelper.MarshalJSON(w, response)
+	}
+}
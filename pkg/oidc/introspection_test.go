@@ -0,0 +1,96 @@
+package oidc_test
+
+import (
+	"testing"
+	"time"
+
+	auth "github.com/formancehq/auth/pkg"
+	"github.com/formancehq/auth/pkg/oidc"
+	"github.com/formancehq/go-libs/v3/logging"
+	"github.com/stretchr/testify/require"
+	zoidc "github.com/zitadel/oidc/v2/pkg/oidc"
+)
+
+func TestIntrospection(t *testing.T) {
+	t.Parallel()
+
+	ctx := logging.TestingContext()
+	store, storageFacade := newTestStorageFacade(t, nil)
+	introspectionStorage := storageFacade.(oidc.IntrospectionStorage)
+
+	client := auth.NewClient(auth.ClientOptions{})
+	require.NoError(t, store.SaveClient(ctx, client))
+	otherClient := auth.NewClient(auth.ClientOptions{})
+	require.NoError(t, store.SaveClient(ctx, otherClient))
+	trustedClient := auth.NewClient(auth.ClientOptions{
+		Trusted: true,
+	})
+	require.NoError(t, store.SaveClient(ctx, trustedClient))
+
+	// Client credentials token
+	tokenID, _, err := storageFacade.CreateAccessToken(ctx, &auth.AuthRequest{
+		ApplicationID: client.Id,
+		Scopes:        []string{"ledger:read"},
+	})
+	require.NoError(t, err)
+
+	response := &zoidc.IntrospectionResponse{}
+	active, err := introspectionStorage.IntrospectAccessToken(ctx, response, tokenID, client.Id)
+	require.NoError(t, err)
+	require.True(t, active)
+	require.Equal(t, client.Id, response.Subject)
+	require.Equal(t, client.Id, response.ClientID)
+	require.Equal(t, tokenID, response.JWTID)
+	require.Equal(t, zoidc.BearerToken, response.TokenType)
+	require.Equal(t, zoidc.SpaceDelimitedArray{"ledger:read"}, response.Scope)
+	require.NotZero(t, response.Expiration)
+	require.NotZero(t, response.IssuedAt)
+
+	active, err = introspectionStorage.IntrospectAccessToken(ctx, &zoidc.IntrospectionResponse{}, tokenID, otherClient.Id)
+	require.NoError(t, err)
+	require.False(t, active, "a client must not introspect the tokens of another client")
+
+	active, err = introspectionStorage.IntrospectAccessToken(ctx, &zoidc.IntrospectionResponse{}, tokenID, trustedClient.Id)
+	require.NoError(t, err)
+	require.True(t, active)
+
+	// Expired token
+	require.NoError(t, store.SaveAccessToken(ctx, &auth.AccessToken{
+		ID:            "expired",
+		CreatedAt:     time.Now().Add(-2 * time.Hour),
+		ApplicationID: client.Id,
+		Expiration:    time.Now().Add(-time.Hour),
+	}))
+	active, err = introspectionStorage.IntrospectAccessToken(ctx, &zoidc.IntrospectionResponse{}, "expired", client.Id)
+	require.NoError(t, err)
+	require.False(t, active)
+
+	// Refresh token
+	user := &auth.User{
+		ID: "user",
+		UserOptions: auth.UserOptions{
+			Subject: "subject",
+			Email:   "user@example.com",
+		},
+	}
+	require.NoError(t, store.SaveUser(ctx, user))
+
+	_, refreshToken, _, err := storageFacade.CreateAccessAndRefreshTokens(ctx, &auth.AuthRequest{
+		ApplicationID: client.Id,
+		UserID:        user.ID,
+		Scopes:        []string{zoidc.ScopeOpenID, zoidc.ScopeEmail, zoidc.ScopeOfflineAccess},
+	}, "")
+	require.NoError(t, err)
+
+	response = &zoidc.IntrospectionResponse{}
+	active, err = introspectionStorage.IntrospectRefreshToken(ctx, response, refreshToken, client.Id)
+	require.NoError(t, err)
+	require.True(t, active)
+	require.Equal(t, user.ID, response.Subject)
+	require.Equal(t, user.Email, response.Email)
+	require.Equal(t, oidc.TokenTypeHintRefreshToken, response.TokenType)
+
+	active, err = introspectionStorage.IntrospectRefreshToken(ctx, &zoidc.IntrospectionResponse{}, "unknown", client.Id)
+	require.NoError(t, err)
+	require.False(t, active)
+}
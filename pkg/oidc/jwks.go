@@ -0,0 +1,141 @@
+package oidc
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"gopkg.in/go-jose/go-jose.v2"
+)
+
+const (
+	// jwksCacheTTL is the delay after which the key set of a client is fetched again
+	jwksCacheTTL = 5 * time.Minute
+	// jwksMinRefreshInterval limits the fetches triggered by unknown key ids, to avoid hammering the clients endpoints
+	jwksMinRefreshInterval = 30 * time.Second
+	// jwksMaxSize bounds the size of the key sets read from the clients endpoints
+	jwksMaxSize = 1 << 20
+)
+
+// findKey returns the signature key with the given id.
+// If the assertion does not specify a key id, the key set must contain a single signature key.
+func findKey(keys []jose.JSONWebKey, keyID string) (*jose.JSONWebKey, bool) {
+	var found *jose.JSONWebKey
+	for i, key := range keys {
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+		if keyID != "" && key.KeyID == keyID {
+			return &keys[i], true
+		}
+		if keyID == "" {
+			if found != nil {
+				return nil, false
+			}
+			found = &keys[i]
+		}
+	}
+	return found, found != nil
+}
+
+type jwksCacheEntry struct {
+	keySet    jose.JSONWebKeySet
+	fetchedAt time.Time
+}
+
+// jwksCache caches the key sets fetched from the jwks_uri of the clients
+type jwksCache struct {
+	httpClient *http.Client
+	mu         sync.Mutex
+	entries    map[string]jwksCacheEntry
+}
+
+func (c *jwksCache) fetch(ctx context.Context, uri string) (*jose.JSONWebKeySet, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	rsp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rsp.Body.Close()
+	}()
+
+	if rsp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d fetching %s", rsp.StatusCode, uri)
+	}
+
+	keySet := jose.JSONWebKeySet{}
+	if err := json.NewDecoder(io.LimitReader(rsp.Body, jwksMaxSize)).Decode(&keySet); err != nil {
+		return nil, fmt.Errorf("decoding key set fetched from %s: %w", uri, err)
+	}
+
+	return &keySet, nil
+}
+
+// keys returns the keys published at the uri.
+// The key set is fetched again if it is stale or if it does not contain the expected key, in case the client has rotated its keys.
+func (c *jwksCache) keys(ctx context.Context, uri string, contains func(keys []jose.JSONWebKey) bool) ([]jose.JSONWebKey, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[uri]
+	c.mu.Unlock()
+
+	now := time.Now()
+	if ok && now.Sub(entry.fetchedAt) < jwksCacheTTL &&
+		(contains(entry.keySet.Keys) || now.Sub(entry.fetchedAt) < jwksMinRefreshInterval) {
+		return entry.keySet.Keys, nil
+	}
+
+	keySet, err := c.fetch(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.entries[uri] = jwksCacheEntry{
+		keySet:    *keySet,
+		fetchedAt: now,
+	}
+	c.mu.Unlock()
+
+	return keySet.Keys, nil
+}
+
+// GetKey returns the key with the given id from the key set published at the uri
+func (c *jwksCache) GetKey(ctx context.Context, uri, keyID string) (*jose.JSONWebKey, error) {
+	keys, err := c.keys(ctx, uri, func(keys []jose.JSONWebKey) bool {
+		_, found := findKey(keys, keyID)
+		return found
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	key, found := findKey(keys, keyID)
+	if !found {
+		return nil, fmt.Errorf("key '%s' not found", keyID)
+	}
+	return key, nil
+}
+
+// GetCertificateKeys returns the keys published at the uri, fetched again if the certificate is not registered
+func (c *jwksCache) GetCertificateKeys(ctx context.Context, uri string, certificate *x509.Certificate) ([]jose.JSONWebKey, error) {
+	return c.keys(ctx, uri, func(keys []jose.JSONWebKey) bool {
+		return hasCertificate(keys, certificate)
+	})
+}
+
+func newJWKSCache(httpClient *http.Client) *jwksCache {
+	return &jwksCache{
+		httpClient: httpClient,
+		entries:    map[string]jwksCacheEntry{},
+	}
+}
@@ -0,0 +1,111 @@
+package oidc_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	auth "github.com/formancehq/auth/pkg"
+	"github.com/formancehq/auth/pkg/oidc"
+	"github.com/formancehq/go-libs/v3/logging"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-jose/go-jose.v2"
+)
+
+func TestGetKeyByIDAndClientID(t *testing.T) {
+	t.Parallel()
+
+	key1, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	key2, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	fetches := atomic.Int32{}
+	jwksServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		require.NoError(t, json.NewEncoder(w).Encode(jose.JSONWebKeySet{
+			Keys: []jose.JSONWebKey{{
+				Key:   &key2.PublicKey,
+				KeyID: "remote",
+			}},
+		}))
+	}))
+	defer jwksServer.Close()
+
+	// The key sets are read up to a limit
+	largeJWKSServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"keys":[` + strings.Repeat(" ", 2<<20) + `]}`))
+	}))
+	defer largeJWKSServer.Close()
+
+	storageFacade := oidc.NewStorageFacade(nil, nil, nil, oidc.DefaultTokenLifetimes, oidc.MTLSConfig{}, nil, nil,
+		auth.StaticClient{
+			ClientOptions: auth.ClientOptions{
+				Id: "inline",
+				JWKS: &auth.JSONWebKeySet{
+					JSONWebKeySet: jose.JSONWebKeySet{
+						Keys: []jose.JSONWebKey{
+							{Key: &key1.PublicKey, KeyID: "enc", Use: "enc"},
+							{Key: &key1.PublicKey, KeyID: "sig", Use: "sig"},
+						},
+					},
+				},
+			},
+		},
+		auth.StaticClient{
+			ClientOptions: auth.ClientOptions{
+				Id:      "remote",
+				JWKSURI: jwksServer.URL,
+			},
+		},
+		auth.StaticClient{
+			ClientOptions: auth.ClientOptions{
+				Id:      "large",
+				JWKSURI: largeJWKSServer.URL,
+			},
+		},
+		auth.StaticClient{
+			ClientOptions: auth.ClientOptions{
+				Id: "secret",
+			},
+		},
+	)
+	ctx := logging.TestingContext()
+
+	key, err := storageFacade.GetKeyByIDAndClientID(ctx, "sig", "inline")
+	require.NoError(t, err)
+	require.Equal(t, &key1.PublicKey, key.Key)
+
+	// Without key id, the single signature key is used
+	key, err = storageFacade.GetKeyByIDAndClientID(ctx, "", "inline")
+	require.NoError(t, err)
+	require.Equal(t, "sig", key.KeyID)
+
+	_, err = storageFacade.GetKeyByIDAndClientID(ctx, "enc", "inline")
+	require.Error(t, err)
+
+	key, err = storageFacade.GetKeyByIDAndClientID(ctx, "remote", "remote")
+	require.NoError(t, err)
+	require.Equal(t, &key2.PublicKey, key.Key)
+
+	// The key set is cached
+	_, err = storageFacade.GetKeyByIDAndClientID(ctx, "remote", "remote")
+	require.NoError(t, err)
+	require.EqualValues(t, 1, fetches.Load())
+
+	// Unknown keys do not trigger a fetch on each request
+	_, err = storageFacade.GetKeyByIDAndClientID(ctx, "unknown", "remote")
+	require.Error(t, err)
+	require.EqualValues(t, 1, fetches.Load())
+
+	_, err = storageFacade.GetKeyByIDAndClientID(ctx, "", "large")
+	require.Error(t, err)
+
+	_, err = storageFacade.GetKeyByIDAndClientID(ctx, "", "secret")
+	require.Error(t, err)
+}
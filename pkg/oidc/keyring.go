@@ -0,0 +1,324 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"sync"
+
+	oidclib "github.com/formancehq/go-libs/v3/oidc"
+	josev4 "github.com/go-jose/go-jose/v4"
+	"github.com/pkg/errors"
+	"github.com/zitadel/oidc/v2/pkg/op"
+	"gopkg.in/go-jose/go-jose.v2"
+)
+
+type signingKey struct {
+	id        string
+	algorithm jose.SignatureAlgorithm
+	key       crypto.Signer
+}
+
+func (s *signingKey) SignatureAlgorithm() jose.SignatureAlgorithm {
+	return s.algorithm
+}
+
+func (s *signingKey) Key() interface{} {
+	return s.key
+}
+
+func (s *signingKey) ID() string {
+	return s.id
+}
+
+type publicKey struct {
+	id        string
+	algorithm jose.SignatureAlgorithm
+	key       crypto.PublicKey
+}
+
+func (s *publicKey) ID() string {
+	return s.id
+}
+
+func (s *publicKey) Algorithm() jose.SignatureAlgorithm {
+	return s.algorithm
+}
+
+func (s *publicKey) Use() string {
+	return "sig"
+}
+
+func (s *publicKey) Key() interface{} {
+	return s.key
+}
+
+// KeyRing holds all the keys published in the JWKS.
+// Exactly one of them is active and used to sign new tokens,
+// the others are only kept to verify tokens signed before a rotation.
+type KeyRing struct {
+	mu     sync.RWMutex
+	active *signingKey
+	keys   []*publicKey
+}
+
+// SigningKey returns the key currently used to sign tokens
+func (r *KeyRing) SigningKey() *signingKey {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.active
+}
+
+// PublicKeys returns all the keys published in the JWKS, the active one first
+func (r *KeyRing) PublicKeys() []op.Key {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ret := make([]op.Key, 0, len(r.keys))
+	for _, key := range r.keys {
+		ret = append(ret, key)
+	}
+	return ret
+}
+
+// SignatureAlgorithms returns the distinct algorithms of the published keys
+func (r *KeyRing) SignatureAlgorithms() []jose.SignatureAlgorithm {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ret := make([]jose.SignatureAlgorithm, 0)
+l:
+	for _, key := range r.keys {
+		for _, algorithm := range ret {
+			if algorithm == key.algorithm {
+				continue l
+			}
+		}
+		ret = append(ret, key.algorithm)
+	}
+	return ret
+}
+
+// VerifySignature implements the go-libs oidc.KeySet interface
+// so the tokens signed by any published key are accepted by the API
+func (r *KeyRing) VerifySignature(ctx context.Context, jws *josev4.JSONWebSignature) ([]byte, error) {
+	keyID, alg := oidclib.GetKeyIDAndAlg(jws)
+
+	r.mu.RLock()
+	keys := make([]josev4.JSONWebKey, 0, len(r.keys))
+	for _, key := range r.keys {
+		keys = append(keys, josev4.JSONWebKey{
+			Key:       key.key,
+			KeyID:     key.id,
+			Algorithm: string(key.algorithm),
+			Use:       oidclib.KeyUseSignature,
+		})
+	}
+	r.mu.RUnlock()
+
+	key, err := oidclib.FindMatchingKey(keyID, oidclib.KeyUseSignature, alg, keys...)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature: %w", err)
+	}
+
+	return jws.Verify(&key)
+}
+
+// Set replaces the content of the key ring
+func (r *KeyRing) Set(active crypto.Signer, verificationKeys ...crypto.PublicKey) error {
+	pub, err := newPublicKey(active.Public())
+	if err != nil {
+		return err
+	}
+
+	keys := []*publicKey{pub}
+l:
+	for _, key := range verificationKeys {
+		verificationKey, err := newPublicKey(key)
+		if err != nil {
+			return err
+		}
+		for _, existingKey := range keys {
+			if existingKey.id == verificationKey.id {
+				continue l
+			}
+		}
+		keys = append(keys, verificationKey)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.active = &signingKey{
+		id:        pub.id,
+		algorithm: pub.algorithm,
+		key:       active,
+	}
+	r.keys = keys
+
+	return nil
+}
+
+var _ oidclib.KeySet = (*KeyRing)(nil)
+
+func NewKeyRing(active crypto.Signer, verificationKeys ...crypto.PublicKey) (*KeyRing, error) {
+	ring := &KeyRing{}
+	if err := ring.Set(active, verificationKeys...); err != nil {
+		return nil, err
+	}
+
+	return ring, nil
+}
+
+func newPublicKey(key crypto.PublicKey) (*publicKey, error) {
+	algorithm, err := SignatureAlgorithm(key)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := KeyThumbprint(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &publicKey{
+		id:        id,
+		algorithm: algorithm,
+		key:       key,
+	}, nil
+}
+
+// SupportedSignatureAlgorithms lists the algorithms which can be used to sign tokens
+var SupportedSignatureAlgorithms = []jose.SignatureAlgorithm{
+	jose.RS256,
+	jose.ES256,
+	jose.ES384,
+	jose.EdDSA,
+}
+
+// supportedSignatureAlgorithmNames returns the names of the supported algorithms, as expected by the verifiers
+func supportedSignatureAlgorithmNames() []string {
+	ret := make([]string, 0, len(SupportedSignatureAlgorithms))
+	for _, algorithm := range SupportedSignatureAlgorithms {
+		ret = append(ret, string(algorithm))
+	}
+	return ret
+}
+
+// SignatureAlgorithm returns the algorithm used to sign tokens with the given key
+func SignatureAlgorithm(key crypto.PublicKey) (jose.SignatureAlgorithm, error) {
+	switch key := key.(type) {
+	case *rsa.PublicKey:
+		return jose.RS256, nil
+	case *ecdsa.PublicKey:
+		switch key.Curve {
+		case elliptic.P256():
+			return jose.ES256, nil
+		case elliptic.P384():
+			return jose.ES384, nil
+		default:
+			return "", fmt.Errorf("unsupported elliptic curve: %s", key.Curve.Params().Name)
+		}
+	case ed25519.PublicKey:
+		return jose.EdDSA, nil
+	default:
+		return "", fmt.Errorf("unsupported key type: %T", key)
+	}
+}
+
+// GenerateSigningKey generates a new private key for the given algorithm
+func GenerateSigningKey(algorithm jose.SignatureAlgorithm) (crypto.Signer, error) {
+	switch algorithm {
+	case jose.RS256:
+		return rsa.GenerateKey(rand.Reader, 2048)
+	case jose.ES256:
+		return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case jose.ES384:
+		return ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	case jose.EdDSA:
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		return key, err
+	default:
+		return nil, fmt.Errorf("unsupported signature algorithm: %s", algorithm)
+	}
+}
+
+// KeyThumbprint computes the RFC 7638 thumbprint of a public key, used as key id
+func KeyThumbprint(key crypto.PublicKey) (string, error) {
+	jwk := jose.JSONWebKey{
+		Key: key,
+	}
+	thumbprint, err := jwk.Thumbprint(crypto.SHA256)
+	if err != nil {
+		return "", errors.Wrap(err, "computing key thumbprint")
+	}
+	return base64.RawURLEncoding.EncodeToString(thumbprint), nil
+}
+
+// ParsePrivateKey parses a PEM encoded private key.
+// PKCS#1 and SEC 1 encodings are accepted as well as PKCS#8.
+func ParsePrivateKey(data string) (crypto.Signer, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return nil, errors.New("invalid signing key, cannot parse as PEM")
+	}
+
+	var (
+		key any
+		err error
+	)
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type: %s", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported key type: %T", key)
+	}
+	if _, err := SignatureAlgorithm(signer.Public()); err != nil {
+		return nil, err
+	}
+
+	return signer, nil
+}
+
+// ParsePublicKey parses a PEM encoded key and returns its public part.
+// Both private and public keys are accepted.
+func ParsePublicKey(data string) (crypto.PublicKey, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return nil, errors.New("invalid key, cannot parse as PEM")
+	}
+
+	switch block.Type {
+	case "PUBLIC KEY":
+		return x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	default:
+		key, err := ParsePrivateKey(data)
+		if err != nil {
+			return nil, err
+		}
+		return key.Public(), nil
+	}
+}
@@ -0,0 +1,109 @@
+package oidc_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/formancehq/auth/pkg/oidc"
+	josev4 "github.com/go-jose/go-jose/v4"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-jose/go-jose.v2"
+)
+
+func signWithKey(t *testing.T, key crypto.Signer, keyID string) *josev4.JSONWebSignature {
+	algorithm, err := oidc.SignatureAlgorithm(key.Public())
+	require.NoError(t, err)
+
+	signer, err := josev4.NewSigner(josev4.SigningKey{
+		Algorithm: josev4.SignatureAlgorithm(algorithm),
+		Key: josev4.JSONWebKey{
+			Key:   key,
+			KeyID: keyID,
+		},
+	}, nil)
+	require.NoError(t, err)
+
+	jws, err := signer.Sign([]byte(`{"sub":"foo"}`))
+	require.NoError(t, err)
+
+	serialized, err := jws.CompactSerialize()
+	require.NoError(t, err)
+
+	jws, err = josev4.ParseSigned(serialized, []josev4.SignatureAlgorithm{josev4.SignatureAlgorithm(algorithm)})
+	require.NoError(t, err)
+
+	return jws
+}
+
+func TestKeyRing(t *testing.T) {
+	t.Parallel()
+
+	activeKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	previousKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	unknownKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	keyRing, err := oidc.NewKeyRing(activeKey, previousKey.Public(), previousKey.Public())
+	require.NoError(t, err)
+
+	activeKeyID, err := oidc.KeyThumbprint(activeKey.Public())
+	require.NoError(t, err)
+	previousKeyID, err := oidc.KeyThumbprint(previousKey.Public())
+	require.NoError(t, err)
+	require.NotEqual(t, activeKeyID, previousKeyID)
+
+	require.Equal(t, activeKeyID, keyRing.SigningKey().ID())
+
+	publicKeys := keyRing.PublicKeys()
+	require.Len(t, publicKeys, 2)
+	require.Equal(t, activeKeyID, publicKeys[0].ID())
+	require.Equal(t, previousKeyID, publicKeys[1].ID())
+
+	payload, err := keyRing.VerifySignature(context.Background(), signWithKey(t, activeKey, activeKeyID))
+	require.NoError(t, err)
+	require.Equal(t, `{"sub":"foo"}`, string(payload))
+
+	_, err = keyRing.VerifySignature(context.Background(), signWithKey(t, previousKey, previousKeyID))
+	require.NoError(t, err)
+
+	_, err = keyRing.VerifySignature(context.Background(), signWithKey(t, unknownKey, previousKeyID))
+	require.Error(t, err)
+}
+
+func TestKeyRingAlgorithms(t *testing.T) {
+	t.Parallel()
+
+	for _, algorithm := range oidc.SupportedSignatureAlgorithms {
+		algorithm := algorithm
+		t.Run(string(algorithm), func(t *testing.T) {
+			t.Parallel()
+
+			key, err := oidc.GenerateSigningKey(algorithm)
+			require.NoError(t, err)
+
+			data, err := x509.MarshalPKCS8PrivateKey(key)
+			require.NoError(t, err)
+
+			parsedKey, err := oidc.ParsePrivateKey(string(pem.EncodeToMemory(&pem.Block{
+				Type:  "PRIVATE KEY",
+				Bytes: data,
+			})))
+			require.NoError(t, err)
+
+			keyRing, err := oidc.NewKeyRing(parsedKey)
+			require.NoError(t, err)
+			require.Equal(t, []jose.SignatureAlgorithm{algorithm}, keyRing.SignatureAlgorithms())
+			require.Equal(t, algorithm, keyRing.SigningKey().SignatureAlgorithm())
+
+			_, err = keyRing.VerifySignature(context.Background(), signWithKey(t, parsedKey, keyRing.SigningKey().ID()))
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package oidc_test
+
+import (
+	"testing"
+	"time"
+
+	auth "github.com/formancehq/auth/pkg"
+	"github.com/formancehq/auth/pkg/oidc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenLifetimesForClient(t *testing.T) {
+	t.Parallel()
+
+	client := auth.NewClient(auth.ClientOptions{
+		AccessTokenLifetime:      60,
+		RefreshTokenIdleLifetime: 600,
+	})
+
+	lifetimes := oidc.DefaultTokenLifetimes.ForClient(client)
+	require.Equal(t, oidc.TokenLifetimes{
+		AccessToken2Legged: time.Minute,
+		AccessToken3Legged: time.Minute,
+		IDToken:            oidc.ExpirationIDToken,
+		RefreshToken:       oidc.ExpirationRefreshToken,
+		RefreshTokenIdle:   10 * time.Minute,
+	}, lifetimes)
+
+	require.Equal(t, oidc.DefaultTokenLifetimes, oidc.DefaultTokenLifetimes.ForClient(auth.NewClient(auth.ClientOptions{})))
+}
+
+func TestTokenLifetimesValidate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, oidc.DefaultTokenLifetimes.Validate())
+
+	lifetimes := oidc.DefaultTokenLifetimes
+	lifetimes.AccessToken3Legged = 0
+	require.Error(t, lifetimes.Validate())
+
+	lifetimes = oidc.DefaultTokenLifetimes
+	lifetimes.IDToken = -time.Minute
+	require.Error(t, lifetimes.Validate())
+
+	lifetimes = oidc.DefaultTokenLifetimes
+	lifetimes.RefreshTokenIdle = -time.Minute
+	require.Error(t, lifetimes.Validate())
+}
@@ -0,0 +1,135 @@
+package oidc
+
+import (
+	"context"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/formancehq/auth/pkg/delegatedauth"
+	"github.com/zitadel/oidc/v2/pkg/client/rp"
+)
+
+const (
+	// LoginPath is the page redirecting the users to their upstream identity provider, or listing the providers to choose from
+	LoginPath = "/login"
+
+	// IdentityProviderHintParameter is the parameter of the authorization requests selecting the upstream identity provider
+	IdentityProviderHintParameter = "idp_hint"
+
+	authRequestIDParameter    = "authRequestID"
+	userCodeParameter         = "user_code"
+	identityProviderParameter = "idp"
+)
+
+type identityProviderHintContextKey struct{}
+
+// ContextWithIdentityProviderHint stores the upstream identity provider requested with the idp_hint parameter
+func ContextWithIdentityProviderHint(ctx context.Context, hint string) context.Context {
+	return context.WithValue(ctx, identityProviderHintContextKey{}, hint)
+}
+
+// IdentityProviderHintFromContext returns the upstream identity provider requested with the idp_hint parameter, empty if none
+func IdentityProviderHintFromContext(ctx context.Context) string {
+	hint, _ := ctx.Value(identityProviderHintContextKey{}).(string)
+	return hint
+}
+
+// loginURL returns the url of the login page relative to the authorization and device verification endpoints,
+// which are served beside it under the path of the issuer
+func loginURL(query url.Values) string {
+	return strings.TrimPrefix(LoginPath, "/") + "?" + query.Encode()
+}
+
+type loginProvider struct {
+	Label string
+	URL   string
+}
+
+func renderLoginPage(w http.ResponseWriter, status int, data map[string]any) {
+	tpl := template.Must(template.New("login.tmpl").
+		ParseFS(templateFs, "templates/login.tmpl"))
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	if err := tpl.Execute(w, data); err != nil {
+		panic(err)
+	}
+}
+
+// loginHandler redirects the user logging in for an auth request or a device to an upstream identity provider:
+// the one chosen on the page, requested with the idp_hint parameter, matching the domain of the login_hint,
+// or the only one. The page lists the identity providers otherwise.
+func loginHandler(storage Storage, providers *delegatedauth.Providers) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		state := delegatedauth.DelegatedState{
+			AuthRequestID:  query.Get(authRequestIDParameter),
+			DeviceUserCode: query.Get(userCodeParameter),
+		}
+
+		var idpHint, loginHint string
+		switch {
+		case state.AuthRequestID != "":
+			authRequest, err := storage.FindAuthRequest(r.Context(), state.AuthRequestID)
+			if err != nil {
+				renderLoginPage(w, http.StatusBadRequest, map[string]any{
+					"Error": "Invalid or expired login request",
+				})
+				return
+			}
+			idpHint, loginHint = authRequest.IdentityProviderHint, authRequest.LoginHint
+		case state.DeviceUserCode == "":
+			renderLoginPage(w, http.StatusBadRequest, map[string]any{
+				"Error": "Invalid login request",
+			})
+			return
+		}
+		if chosen := query.Get(identityProviderParameter); chosen != "" {
+			idpHint = chosen
+		}
+
+		configs, err := providers.List(r.Context())
+		if err != nil {
+			panic(err)
+		}
+		if len(configs) == 0 {
+			renderLoginPage(w, http.StatusServiceUnavailable, map[string]any{
+				"Error": "No identity provider configured",
+			})
+			return
+		}
+
+		config, ok := delegatedauth.Select(configs, idpHint, loginHint)
+		if !ok {
+			loginProviders := make([]loginProvider, 0, len(configs))
+			for _, config := range configs {
+				providerQuery := url.Values{}
+				for key, values := range query {
+					providerQuery[key] = values
+				}
+				providerQuery.Set(identityProviderParameter, config.Name)
+				loginProviders = append(loginProviders, loginProvider{
+					Label: config.Label(),
+					URL:   loginURL(providerQuery),
+				})
+			}
+			renderLoginPage(w, http.StatusOK, map[string]any{
+				"Providers": loginProviders,
+			})
+			return
+		}
+
+		_, relyingParty, err := providers.Get(r.Context(), config.Name)
+		if err != nil {
+			panic(err)
+		}
+		state.Provider = config.Name
+
+		opts := make([]rp.AuthURLOpt, 0)
+		if loginHint != "" {
+			opts = append(opts, rp.AuthURLOpt(rp.WithURLParam("login_hint", loginHint)))
+		}
+		http.Redirect(w, r, rp.AuthURL(state.EncodeAsUrlParam(), relyingParty, opts...), http.StatusFound)
+	}
+}
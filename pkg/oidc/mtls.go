@@ -0,0 +1,230 @@
+package oidc
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"time"
+
+	auth "github.com/formancehq/auth/pkg"
+	"github.com/zitadel/oidc/v2/pkg/oidc"
+	"github.com/zitadel/oidc/v2/pkg/op"
+	"gopkg.in/go-jose/go-jose.v2"
+)
+
+const (
+	AuthMethodTLSClientAuth           oidc.AuthMethod = "tls_client_auth"
+	AuthMethodSelfSignedTLSClientAuth oidc.AuthMethod = "self_signed_tls_client_auth"
+
+	// ConfirmationClaim holds the thumbprint of the certificate a token is bound to
+	ConfirmationClaim                    = "cnf"
+	ConfirmationCertificateThumbprintKey = "x5t#S256"
+)
+
+// MTLSConfig configures the mutual TLS client authentication and the certificate-bound tokens (RFC 8705)
+type MTLSConfig struct {
+	// ClientCertificateHeader is the header holding the client certificate when TLS is terminated by a proxy.
+	// The certificate is expected PEM encoded, optionally url-encoded, or base64 DER encoded.
+	// The proxy must always overwrite the header, as its value is trusted.
+	ClientCertificateHeader string
+	// TrustedProxies are the networks of the proxies the header is accepted from
+	TrustedProxies []netip.Prefix
+	// ClientCAs are the authorities used to validate the certificates with tls_client_auth, the system pool if nil
+	ClientCAs *x509.CertPool
+}
+
+type clientCertificateContextKey struct{}
+
+// ContextWithClientCertificate stores the certificate chain presented by the client, the leaf first
+func ContextWithClientCertificate(ctx context.Context, chain []*x509.Certificate) context.Context {
+	return context.WithValue(ctx, clientCertificateContextKey{}, chain)
+}
+
+// ClientCertificateFromContext returns the certificate chain presented by the client, nil if none
+func ClientCertificateFromContext(ctx context.Context) []*x509.Certificate {
+	chain, _ := ctx.Value(clientCertificateContextKey{}).([]*x509.Certificate)
+	return chain
+}
+
+// parseClientCertificateHeader parses a certificate forwarded by a proxy
+func parseClientCertificateHeader(value string) ([]*x509.Certificate, error) {
+	if strings.Contains(value, "%") {
+		unescaped, err := url.QueryUnescape(value)
+		if err != nil {
+			return nil, err
+		}
+		value = unescaped
+	}
+
+	data := []byte(value)
+	if !bytes.Contains(data, []byte("-----BEGIN")) {
+		der, err := base64.StdEncoding.DecodeString(strings.TrimSpace(value))
+		if err != nil {
+			return nil, errors.New("certificate is neither PEM nor base64 encoded")
+		}
+		return x509.ParseCertificates(der)
+	}
+
+	chain := make([]*x509.Certificate, 0)
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		chain = append(chain, certificate)
+	}
+	if len(chain) == 0 {
+		return nil, errors.New("no certificate found")
+	}
+	return chain, nil
+}
+
+// isTrustedProxy checks if a request comes from a proxy allowed to forward the client certificates
+func (c MTLSConfig) isTrustedProxy(r *http.Request) bool {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range c.TrustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClientCertificateMiddleware makes the certificate presented by the client available to the storage.
+// The certificate is read from the TLS connection, or from the header if TLS is terminated by a trusted proxy.
+// The header is ignored on the TLS connections and on the requests which do not come from a trusted proxy.
+func ClientCertificateMiddleware(config MTLSConfig) func(http.Handler) http.Handler {
+	header := config.ClientCertificateHeader
+	return func(handler http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case r.TLS != nil:
+				if len(r.TLS.PeerCertificates) > 0 {
+					r = r.WithContext(ContextWithClientCertificate(r.Context(), r.TLS.PeerCertificates))
+				}
+			case header != "" && r.Header.Get(header) != "" && config.isTrustedProxy(r):
+				chain, err := parseClientCertificateHeader(r.Header.Get(header))
+				if err != nil {
+					op.RequestError(w, r, oidc.ErrInvalidRequest().WithDescription("invalid client certificate: %s", err))
+					return
+				}
+				r = r.WithContext(ContextWithClientCertificate(r.Context(), chain))
+			}
+			handler.ServeHTTP(w, r)
+		})
+	}
+}
+
+// confirmation returns the cnf claim binding a token to the certificate of the client and/or to its DPoP key,
+// nil if the token is not bound
+func confirmation(certificateThumbprint, dpopKeyThumbprint string) map[string]any {
+	if certificateThumbprint == "" && dpopKeyThumbprint == "" {
+		return nil
+	}
+	ret := map[string]any{}
+	if certificateThumbprint != "" {
+		ret[ConfirmationCertificateThumbprintKey] = certificateThumbprint
+	}
+	if dpopKeyThumbprint != "" {
+		ret[ConfirmationKeyThumbprintKey] = dpopKeyThumbprint
+	}
+	return ret
+}
+
+// clientCertificateThumbprint returns the thumbprint of the certificate the tokens of a client are bound to,
+// empty if the client did not present a certificate or does not authenticate with it (tls_client_auth or self_signed_tls_client_auth)
+func clientCertificateThumbprint(ctx context.Context, client Client) string {
+	chain := ClientCertificateFromContext(ctx)
+	if len(chain) == 0 || client == nil || client.GetTLSClientAuth() == nil {
+		return ""
+	}
+	return auth.CertificateThumbprint(chain[0])
+}
+
+// hasCertificate returns true if the certificate is one of the registered keys
+func hasCertificate(keys []jose.JSONWebKey, certificate *x509.Certificate) bool {
+	thumbprint := auth.CertificateThumbprint(certificate)
+	for _, key := range keys {
+		if len(key.Certificates) > 0 && key.Certificates[0].Equal(certificate) {
+			return true
+		}
+		if len(key.CertificateThumbprintSHA256) > 0 &&
+			base64.RawURLEncoding.EncodeToString(key.CertificateThumbprintSHA256) == thumbprint {
+			return true
+		}
+	}
+	return false
+}
+
+// validateClientCertificate authenticates a client with the certificate presented on the connection
+func (s *storageFacade) validateClientCertificate(ctx context.Context, client Client) error {
+	chain := ClientCertificateFromContext(ctx)
+	if len(chain) == 0 {
+		return errors.New("client certificate required")
+	}
+	certificate := chain[0]
+
+	if client.GetTLSClientAuth().SelfSigned {
+		now := time.Now()
+		if now.Before(certificate.NotBefore) || now.After(certificate.NotAfter) {
+			return errors.New("client certificate expired or not yet valid")
+		}
+
+		var keys []jose.JSONWebKey
+		switch {
+		case client.GetJWKS() != nil:
+			keys = client.GetJWKS().Keys
+		case client.GetJWKSURI() != "":
+			var err error
+			keys, err = s.jwks.GetCertificateKeys(ctx, client.GetJWKSURI(), certificate)
+			if err != nil {
+				return err
+			}
+		}
+		if !hasCertificate(keys, certificate) {
+			return errors.New("client certificate not registered")
+		}
+		return nil
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, intermediate := range chain[1:] {
+		intermediates.AddCert(intermediate)
+	}
+	if _, err := certificate.Verify(x509.VerifyOptions{
+		Roots:         s.mtls.ClientCAs,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}); err != nil {
+		return fmt.Errorf("invalid client certificate: %w", err)
+	}
+	if !client.GetTLSClientAuth().Matches(certificate) {
+		return errors.New("client certificate does not match the registered subject")
+	}
+	return nil
+}
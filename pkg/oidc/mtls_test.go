@@ -0,0 +1,265 @@
+package oidc_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"net/url"
+	"testing"
+	"time"
+
+	auth "github.com/formancehq/auth/pkg"
+	"github.com/formancehq/auth/pkg/oidc"
+	"github.com/formancehq/go-libs/v3/logging"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-jose/go-jose.v2"
+)
+
+// newTestCertificate creates a client certificate, self signed if the parent is nil
+func newTestCertificate(t *testing.T, commonName string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey, isCA bool) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: commonName, Organization: []string{"Formance"}},
+		DNSNames:              []string{commonName + ".example.com"},
+		NotBefore:             time.Now().Add(-time.Minute),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+	}
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+
+	certificate, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return certificate, key
+}
+
+func TestAuthorizeClientWithCertificate(t *testing.T) {
+	t.Parallel()
+
+	ca, caKey := newTestCertificate(t, "ca", nil, nil, true)
+	clientCertificate, _ := newTestCertificate(t, "client1", ca, caKey, false)
+	selfSignedCertificate, _ := newTestCertificate(t, "client2", nil, nil, false)
+	otherSelfSignedCertificate, _ := newTestCertificate(t, "client2", nil, nil, false)
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(ca)
+
+	storageFacade := oidc.NewStorageFacade(nil, nil, nil, oidc.DefaultTokenLifetimes, oidc.MTLSConfig{
+		ClientCAs: clientCAs,
+	}, nil, nil,
+		auth.StaticClient{
+			ClientOptions: auth.ClientOptions{
+				Id: "subject",
+				TLSClientAuth: &auth.TLSClientAuth{
+					SubjectDN: "CN=client1,O=Formance",
+				},
+			},
+		},
+		auth.StaticClient{
+			ClientOptions: auth.ClientOptions{
+				Id: "san",
+				TLSClientAuth: &auth.TLSClientAuth{
+					SANDNS: "client1.example.com",
+				},
+			},
+		},
+		auth.StaticClient{
+			ClientOptions: auth.ClientOptions{
+				Id: "self-signed",
+				TLSClientAuth: &auth.TLSClientAuth{
+					SelfSigned: true,
+				},
+				JWKS: &auth.JSONWebKeySet{
+					JSONWebKeySet: jose.JSONWebKeySet{
+						Keys: []jose.JSONWebKey{{
+							Key:          selfSignedCertificate.PublicKey,
+							Certificates: []*x509.Certificate{selfSignedCertificate},
+						}},
+					},
+				},
+			},
+		},
+		auth.StaticClient{
+			ClientOptions: auth.ClientOptions{
+				Id: "secret",
+			},
+			Secrets: []string{"secret"},
+		},
+	)
+
+	type testCase struct {
+		name        string
+		clientID    string
+		certificate *x509.Certificate
+		expectError bool
+	}
+	for _, tc := range []testCase{
+		{
+			name:        "subject dn",
+			clientID:    "subject",
+			certificate: clientCertificate,
+		},
+		{
+			name:        "san dns",
+			clientID:    "san",
+			certificate: clientCertificate,
+		},
+		{
+			name:        "certificate not issued by a trusted authority",
+			clientID:    "subject",
+			certificate: selfSignedCertificate,
+			expectError: true,
+		},
+		{
+			name:        "missing certificate",
+			clientID:    "subject",
+			expectError: true,
+		},
+		{
+			name:        "self signed",
+			clientID:    "self-signed",
+			certificate: selfSignedCertificate,
+		},
+		{
+			name:        "self signed not registered",
+			clientID:    "self-signed",
+			certificate: otherSelfSignedCertificate,
+			expectError: true,
+		},
+		{
+			name:        "client using secrets",
+			clientID:    "secret",
+			certificate: clientCertificate,
+			expectError: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := logging.TestingContext()
+			if tc.certificate != nil {
+				ctx = oidc.ContextWithClientCertificate(ctx, []*x509.Certificate{tc.certificate})
+			}
+
+			err := storageFacade.AuthorizeClientIDSecret(ctx, tc.clientID, "")
+			if tc.expectError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCertificateBoundClaims(t *testing.T) {
+	t.Parallel()
+
+	certificate, _ := newTestCertificate(t, "client1", nil, nil, false)
+	storageFacade := oidc.NewStorageFacade(nil, nil, nil, oidc.DefaultTokenLifetimes, oidc.MTLSConfig{}, nil, nil,
+		auth.StaticClient{
+			ClientOptions: auth.ClientOptions{
+				Id: "client1",
+				TLSClientAuth: &auth.TLSClientAuth{
+					SubjectDN: "CN=client1",
+				},
+			},
+		},
+		auth.StaticClient{
+			ClientOptions: auth.ClientOptions{
+				Id: "secret",
+			},
+			Secrets: []string{"secret"},
+		},
+	)
+
+	claims, err := storageFacade.GetPrivateClaimsFromScopes(context.Background(), "", "client1", []string{"scope1"})
+	require.NoError(t, err)
+	require.NotContains(t, claims, oidc.ConfirmationClaim)
+
+	ctx := oidc.ContextWithClientCertificate(context.Background(), []*x509.Certificate{certificate})
+	claims, err = storageFacade.GetPrivateClaimsFromScopes(ctx, "", "client1", []string{"scope1"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{
+		oidc.ConfirmationCertificateThumbprintKey: auth.CertificateThumbprint(certificate),
+	}, claims[oidc.ConfirmationClaim])
+
+	// the tokens of a client authenticating with a secret are not bound to the certificate it may present
+	claims, err = storageFacade.GetPrivateClaimsFromScopes(ctx, "", "secret", []string{"scope1"})
+	require.NoError(t, err)
+	require.NotContains(t, claims, oidc.ConfirmationClaim)
+}
+
+func TestClientCertificateMiddleware(t *testing.T) {
+	t.Parallel()
+
+	certificate, _ := newTestCertificate(t, "client1", nil, nil, false)
+	encoded := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate.Raw}))
+
+	var received []*x509.Certificate
+	// the requests built by httptest come from 192.0.2.1
+	handler := oidc.ClientCertificateMiddleware(oidc.MTLSConfig{
+		ClientCertificateHeader: "X-Client-Cert",
+		TrustedProxies:          []netip.Prefix{netip.MustParsePrefix("192.0.2.0/24")},
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = oidc.ClientCertificateFromContext(r.Context())
+	}))
+
+	for _, value := range []string{encoded, url.QueryEscape(encoded)} {
+		received = nil
+		req := httptest.NewRequest(http.MethodPost, "/oauth/token", nil)
+		req.Header.Set("X-Client-Cert", value)
+		rsp := httptest.NewRecorder()
+		handler.ServeHTTP(rsp, req)
+		require.Equal(t, http.StatusOK, rsp.Code)
+		require.Len(t, received, 1)
+		require.True(t, received[0].Equal(certificate))
+	}
+
+	received = nil
+	req := httptest.NewRequest(http.MethodPost, "/oauth/token", nil)
+	rsp := httptest.NewRecorder()
+	handler.ServeHTTP(rsp, req)
+	require.Equal(t, http.StatusOK, rsp.Code)
+	require.Nil(t, received)
+
+	req = httptest.NewRequest(http.MethodPost, "/oauth/token", nil)
+	req.Header.Set("X-Client-Cert", "not a certificate")
+	rsp = httptest.NewRecorder()
+	handler.ServeHTTP(rsp, req)
+	require.Equal(t, http.StatusBadRequest, rsp.Code)
+
+	// the header is ignored when the request does not come from a trusted proxy
+	received = nil
+	req = httptest.NewRequest(http.MethodPost, "/oauth/token", nil)
+	req.RemoteAddr = "198.51.100.1:1234"
+	req.Header.Set("X-Client-Cert", encoded)
+	rsp = httptest.NewRecorder()
+	handler.ServeHTTP(rsp, req)
+	require.Equal(t, http.StatusOK, rsp.Code)
+	require.Nil(t, received)
+
+	// the header is ignored when TLS is terminated by the server
+	req = httptest.NewRequest(http.MethodPost, "https://example.com/oauth/token", nil)
+	req.Header.Set("X-Client-Cert", encoded)
+	rsp = httptest.NewRecorder()
+	handler.ServeHTTP(rsp, req)
+	require.Equal(t, http.StatusOK, rsp.Code)
+	require.Nil(t, received)
+}
@@ -3,15 +3,18 @@ package oidc_test
 import (
 	"bytes"
 	"context"
+	"crypto"
 	"crypto/rand"
 	"crypto/rsa"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"net/http/httptest"
 	"net/http/httputil"
 	"net/url"
+	"strings"
 	"testing"
 	"time"
 
@@ -19,6 +22,7 @@ import (
 	"github.com/uptrace/bun"
 
 	"github.com/formancehq/go-libs/v3/logging"
+	"github.com/formancehq/go-libs/v3/publish"
 	"github.com/go-chi/chi/v5"
 
 	"github.com/formancehq/go-libs/v3/bun/bunconnect"
@@ -39,6 +43,7 @@ import (
 
 type user struct {
 	*mockoidc.MockUser
+	groups []string
 }
 
 func (u *user) Userinfo(scope []string) ([]byte, error) {
@@ -52,10 +57,20 @@ func (u *user) Userinfo(scope []string) ([]byte, error) {
 		return nil, err
 	}
 	m["sub"] = u.Subject
+	if u.groups != nil {
+		m["groups"] = u.groups
+	}
 	return json.Marshal(m)
 }
 
 func withServer(t *testing.T, fn func(m *mockoidc.MockOIDC, storage *sqlstorage.Storage, issuer string, provider op.OpenIDProvider)) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	withServerAndKey(t, key, fn)
+}
+
+func withServerAndKey(t *testing.T, key crypto.Signer, fn func(m *mockoidc.MockOIDC, storage *sqlstorage.Storage, issuer string, provider op.OpenIDProvider)) {
 	t.Parallel()
 
 	// Create a mock OIDC server which will always return a default user
@@ -72,14 +87,6 @@ func withServer(t *testing.T, fn func(m *mockoidc.MockOIDC, storage *sqlstorage.
 	// Compute server url, it will be the "issuer" of our oidc provider
 	serverUrl := fmt.Sprintf("http://%s", l.Addr().String())
 
-	// As our oidc provider, is also a relying party (it delegates authentication), we need to construct a relying party
-	// with information from the mock
-	cl := &http.Client{}
-	cl.Transport = RoundTripper{http.DefaultTransport}
-	serverRelyingParty, err := rp.NewRelyingPartyOIDC(mockOIDC.Issuer(), mockOIDC.ClientID, mockOIDC.ClientSecret,
-		fmt.Sprintf("%s/authorize/callback", serverUrl), []string{"openid", "email"}, rp.WithHTTPClient(cl))
-	require.NoError(t, err)
-
 	hooks := make([]bun.QueryHook, 0)
 	if testing.Verbose() {
 		hooks = append(hooks, bundebug.NewQueryHook())
@@ -94,25 +101,33 @@ func withServer(t *testing.T, fn func(m *mockoidc.MockOIDC, storage *sqlstorage.
 
 	require.NoError(t, sqlstorage.Migrate(context.TODO(), db))
 
-	storage := sqlstorage.New(db)
-
-	key, _ := rsa.GenerateKey(rand.Reader, 2048)
-	storageFacade := oidc.NewStorageFacade(storage, serverRelyingParty, key)
+	storage := sqlstorage.New(db, nil)
 
-	keySet, err := oidc.ReadKeySet(http.DefaultClient, context.Background(), delegatedauth.Config{
+	// As our oidc provider, is also a relying party (it delegates authentication), we need to construct
+	// the identity providers with information from the mock
+	cl := &http.Client{}
+	cl.Transport = RoundTripper{http.DefaultTransport}
+	providers := delegatedauth.NewProviders(cl, fmt.Sprintf("%s/authorize/callback", serverUrl), storage, delegatedauth.Config{
+		Name:         auth.DefaultIdentityProvider,
 		Issuer:       mockOIDC.Issuer(),
 		ClientID:     mockOIDC.ClientID,
 		ClientSecret: mockOIDC.ClientSecret,
+		GroupSync: delegatedauth.GroupSync{
+			Claim: "groups",
+		},
 	})
+
+	keyRing, err := oidc.NewKeyRing(key)
 	require.NoError(t, err)
+	storageFacade := oidc.NewStorageFacade(storage, providers, keyRing, oidc.DefaultTokenLifetimes, oidc.MTLSConfig{}, publish.NoOpPublisher, nil)
 
 	// Construct our oidc provider
-	provider, err := oidc.NewOpenIDProvider(storageFacade, serverUrl, []string{serverUrl}, mockOIDC.Issuer(), keySet)
+	provider, err := oidc.NewOpenIDProvider(storageFacade, serverUrl, []string{serverUrl}, providers)
 	require.NoError(t, err)
 
 	// Create the router
 	router := chi.NewRouter()
-	oidc.AddRoutes(router, provider, storage, serverRelyingParty)
+	oidc.AddRoutes(router, provider, storage, providers)
 
 	// Create our http server for our oidc provider
 	providerHttpServer := &http.Server{
@@ -194,6 +209,352 @@ func Test3LeggedFlow(t *testing.T) {
 	})
 }
 
+func TestDisabledUser(t *testing.T) {
+	withServer(t, func(m *mockoidc.MockOIDC, storage *sqlstorage.Storage, issuer string, provider op.OpenIDProvider) {
+		callbacks := make(chan url.Values, 1)
+		clientHttpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			callbacks <- r.URL.Query()
+		}))
+		defer clientHttpServer.Close()
+
+		client := auth.NewClient(auth.ClientOptions{})
+		client.RedirectURIs.Append(clientHttpServer.URL)
+		_, clear := client.GenerateNewSecret(auth.SecretCreate{})
+		require.NoError(t, storage.SaveClient(context.TODO(), client))
+
+		clientRelyingParty, err := rp.NewRelyingPartyOIDC(issuer, client.Id, clear, client.RedirectURIs[0], []string{"openid", "email"})
+		require.NoError(t, err)
+
+		login := func() url.Values {
+			m.QueueUser(&user{
+				MockUser: mockoidc.DefaultUser(),
+			})
+			rsp, err := http.Get(rp.AuthURL("", clientRelyingParty))
+			require.NoError(t, err)
+			require.Equal(t, http.StatusOK, rsp.StatusCode)
+			select {
+			case query := <-callbacks:
+				return query
+			default:
+				require.Fail(t, "callback was expected")
+				return nil
+			}
+		}
+
+		// The user is created on its first login
+		require.NotEmpty(t, login().Get("code"))
+		loggedUser, err := storage.FindUserBySubject(context.TODO(), auth.DefaultIdentityProvider, mockoidc.DefaultUser().Subject)
+		require.NoError(t, err)
+		require.NotNil(t, loggedUser.LastLoginAt)
+
+		// A disabled user is refused
+		loggedUser.Disabled = true
+		require.NoError(t, storage.UpdateUser(context.TODO(), loggedUser))
+
+		query := login()
+		require.Empty(t, query.Get("code"))
+		require.Equal(t, "access_denied", query.Get("error"))
+	})
+}
+
+func TestRoles(t *testing.T) {
+	withServer(t, func(m *mockoidc.MockOIDC, storage *sqlstorage.Storage, issuer string, provider op.OpenIDProvider) {
+		codeChan := make(chan string, 1)
+		clientHttpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			codeChan <- r.URL.Query().Get("code")
+		}))
+		defer clientHttpServer.Close()
+
+		client := auth.NewClient(auth.ClientOptions{
+			Scopes: []string{"ledger:read", "ledger:write"},
+		})
+		client.RedirectURIs.Append(clientHttpServer.URL)
+		_, clientSecret := client.GenerateNewSecret(auth.SecretCreate{})
+		require.NoError(t, storage.SaveClient(context.TODO(), client))
+
+		analyst := auth.NewRole(auth.RoleOptions{
+			Name:   "analyst",
+			Scopes: []string{"ledger:read"},
+		})
+		require.NoError(t, storage.SaveRole(context.TODO(), analyst))
+		analysts := auth.NewGroup(auth.GroupOptions{
+			Name:  "analysts",
+			Roles: []string{analyst.ID},
+		})
+		require.NoError(t, storage.SaveGroup(context.TODO(), analysts))
+
+		clientRelyingParty, err := rp.NewRelyingPartyOIDC(issuer, client.Id, clientSecret, client.RedirectURIs[0],
+			[]string{"openid", "email", "ledger:read", "ledger:write"})
+		require.NoError(t, err)
+
+		m.QueueUser(&user{
+			MockUser: mockoidc.DefaultUser(),
+		})
+		rsp, err := http.Get(rp.AuthURL("", clientRelyingParty))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, rsp.StatusCode)
+
+		var code string
+		select {
+		case code = <-codeChan:
+		default:
+			require.Fail(t, "code was expected")
+		}
+
+		// The user logged in is an analyst, the scopes are restricted when the code is exchanged
+		loggedUser, err := storage.FindUserBySubject(context.TODO(), auth.DefaultIdentityProvider, mockoidc.DefaultUser().Subject)
+		require.NoError(t, err)
+		require.NoError(t, storage.AddGroupMember(context.TODO(), analysts.ID, loggedUser.ID))
+
+		tokens, err := rp.CodeExchange[*zoidc.IDTokenClaims](context.TODO(), code, clientRelyingParty)
+		require.NoError(t, err)
+		require.Equal(t, []any{"analysts"}, tokens.IDTokenClaims.Claims[oidc.GroupsClaim])
+		require.Equal(t, []any{"analyst"}, tokens.IDTokenClaims.Claims[oidc.RolesClaim])
+
+		resourceServerClient := auth.NewClient(auth.ClientOptions{
+			Trusted: true,
+		})
+		_, clear := resourceServerClient.GenerateNewSecret(auth.SecretCreate{})
+		require.NoError(t, storage.SaveClient(context.TODO(), resourceServerClient))
+
+		resourceServer, err := rs.NewResourceServerClientCredentials(issuer, resourceServerClient.Id, clear)
+		require.NoError(t, err)
+
+		introspection, err := rs.Introspect(context.TODO(), resourceServer, tokens.AccessToken)
+		require.NoError(t, err)
+		require.True(t, introspection.Active)
+		require.Equal(t, zoidc.SpaceDelimitedArray{"openid", "email", "ledger:read"}, introspection.Scope)
+
+		// The scopes are restricted the same way for the tokens obtained with an assertion of the upstream issuer
+		assertion, err := m.Keypair.SignJWT(jwt.MapClaims{
+			"aud": []string{m.Issuer()},
+			"exp": time.Now().Add(5 * time.Minute).Unix(),
+			"iss": m.Issuer(),
+			"sub": mockoidc.DefaultUser().Subject,
+		})
+		require.NoError(t, err)
+		req, err := http.NewRequest(http.MethodPost, clientRelyingParty.OAuthConfig().Endpoint.TokenURL,
+			strings.NewReader(url.Values{
+				"grant_type": []string{string(zoidc.GrantTypeBearer)},
+				"assertion":  []string{assertion},
+				"scope":      []string{"openid email ledger:read ledger:write"},
+			}.Encode()))
+		require.NoError(t, err)
+		req.SetBasicAuth(client.Id, clientSecret)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rsp, err = http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, rsp.StatusCode)
+		bearerTokens := zoidc.AccessTokenResponse{}
+		require.NoError(t, json.NewDecoder(rsp.Body).Decode(&bearerTokens))
+
+		introspection, err = rs.Introspect(context.TODO(), resourceServer, bearerTokens.AccessToken)
+		require.NoError(t, err)
+		require.True(t, introspection.Active)
+		require.Equal(t, zoidc.SpaceDelimitedArray{"openid", "email", "ledger:read"}, introspection.Scope)
+	})
+}
+
+func TestGroupSync(t *testing.T) {
+	withServer(t, func(m *mockoidc.MockOIDC, storage *sqlstorage.Storage, issuer string, provider op.OpenIDProvider) {
+		callbacks := make(chan url.Values, 1)
+		clientHttpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			callbacks <- r.URL.Query()
+		}))
+		defer clientHttpServer.Close()
+
+		client := auth.NewClient(auth.ClientOptions{})
+		client.RedirectURIs.Append(clientHttpServer.URL)
+		_, clear := client.GenerateNewSecret(auth.SecretCreate{})
+		require.NoError(t, storage.SaveClient(context.TODO(), client))
+
+		clientRelyingParty, err := rp.NewRelyingPartyOIDC(issuer, client.Id, clear, client.RedirectURIs[0], []string{"openid", "email"})
+		require.NoError(t, err)
+
+		analysts := auth.NewGroup(auth.GroupOptions{Name: "analysts"})
+		require.NoError(t, storage.SaveGroup(context.TODO(), analysts))
+		operators := auth.NewGroup(auth.GroupOptions{Name: "operators"})
+		require.NoError(t, storage.SaveGroup(context.TODO(), operators))
+
+		login := func(groups ...string) []string {
+			m.QueueUser(&user{
+				MockUser: mockoidc.DefaultUser(),
+				groups:   groups,
+			})
+			rsp, err := http.Get(rp.AuthURL("", clientRelyingParty))
+			require.NoError(t, err)
+			require.Equal(t, http.StatusOK, rsp.StatusCode)
+			select {
+			case query := <-callbacks:
+				require.NotEmpty(t, query.Get("code"))
+			default:
+				require.Fail(t, "callback was expected")
+			}
+
+			loggedUser, err := storage.FindUserBySubject(context.TODO(), auth.DefaultIdentityProvider, mockoidc.DefaultUser().Subject)
+			require.NoError(t, err)
+			userGroups, err := storage.ListUserGroups(context.TODO(), loggedUser.ID)
+			require.NoError(t, err)
+			names := make([]string, 0)
+			for _, group := range userGroups {
+				names = append(names, group.Name)
+			}
+			return names
+		}
+
+		// The unknown groups are ignored
+		require.Equal(t, []string{"analysts"}, login("analysts", "unknown"))
+
+		// The memberships added through the api are kept
+		loggedUser, err := storage.FindUserBySubject(context.TODO(), auth.DefaultIdentityProvider, mockoidc.DefaultUser().Subject)
+		require.NoError(t, err)
+		require.NoError(t, storage.AddGroupMember(context.TODO(), operators.ID, loggedUser.ID))
+
+		// The stale memberships are removed
+		require.Equal(t, []string{"operators"}, login())
+	})
+}
+
+func TestIdentityProviders(t *testing.T) {
+	withServer(t, func(m *mockoidc.MockOIDC, storage *sqlstorage.Storage, issuer string, provider op.OpenIDProvider) {
+		partnerOIDC, err := mockoidc.Run()
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, partnerOIDC.Shutdown())
+		}()
+		require.NoError(t, storage.SaveIdentityProvider(context.TODO(), auth.NewIdentityProvider(auth.IdentityProviderOptions{
+			Name:         "partner",
+			DisplayName:  "Partner",
+			Issuer:       partnerOIDC.Issuer(),
+			ClientID:     partnerOIDC.ClientID,
+			ClientSecret: partnerOIDC.ClientSecret,
+			Domains:      []string{"partner.com"},
+		})))
+
+		callbacks := make(chan url.Values, 1)
+		clientHttpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			callbacks <- r.URL.Query()
+		}))
+		defer clientHttpServer.Close()
+
+		client := auth.NewClient(auth.ClientOptions{})
+		client.RedirectURIs.Append(clientHttpServer.URL)
+		_, clear := client.GenerateNewSecret(auth.SecretCreate{})
+		require.NoError(t, storage.SaveClient(context.TODO(), client))
+
+		clientRelyingParty, err := rp.NewRelyingPartyOIDC(issuer, client.Id, clear, client.RedirectURIs[0], []string{"openid", "email"})
+		require.NoError(t, err)
+
+		// Without hint, the users choose their identity provider
+		rsp, err := http.Get(rp.AuthURL("", clientRelyingParty))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, rsp.StatusCode)
+		body, err := io.ReadAll(rsp.Body)
+		require.NoError(t, err)
+		require.Contains(t, string(body), "Partner")
+		require.Contains(t, string(body), auth.DefaultIdentityProvider)
+		require.Empty(t, callbacks)
+
+		login := func(opts ...rp.AuthURLOpt) {
+			partnerOIDC.QueueUser(&user{
+				MockUser: mockoidc.DefaultUser(),
+			})
+			rsp, err := http.Get(rp.AuthURL("", clientRelyingParty, opts...))
+			require.NoError(t, err)
+			require.Equal(t, http.StatusOK, rsp.StatusCode)
+			select {
+			case query := <-callbacks:
+				require.NotEmpty(t, query.Get("code"))
+			default:
+				require.Fail(t, "callback was expected")
+			}
+		}
+
+		// The identity provider is requested with the idp_hint parameter
+		login(rp.AuthURLOpt(rp.WithURLParam(oidc.IdentityProviderHintParameter, "partner")))
+
+		// The users are identified by their subject at their identity provider
+		partnerUser, err := storage.FindUserBySubject(context.TODO(), "partner", mockoidc.DefaultUser().Subject)
+		require.NoError(t, err)
+		require.Equal(t, "partner", partnerUser.Provider)
+		_, err = storage.FindUserBySubject(context.TODO(), auth.DefaultIdentityProvider, mockoidc.DefaultUser().Subject)
+		require.Error(t, err)
+
+		// The identity provider is routed from the domain of the login_hint parameter
+		login(rp.AuthURLOpt(rp.WithURLParam("login_hint", "john@partner.com")))
+		loggedUser, err := storage.FindUserBySubject(context.TODO(), "partner", mockoidc.DefaultUser().Subject)
+		require.NoError(t, err)
+		require.Equal(t, partnerUser.ID, loggedUser.ID)
+	})
+}
+
+func TestProvisioning(t *testing.T) {
+	withServer(t, func(m *mockoidc.MockOIDC, storage *sqlstorage.Storage, issuer string, provider op.OpenIDProvider) {
+		partnerOIDC, err := mockoidc.Run()
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, partnerOIDC.Shutdown())
+		}()
+		require.NoError(t, storage.SaveIdentityProvider(context.TODO(), auth.NewIdentityProvider(auth.IdentityProviderOptions{
+			Name:         "partner",
+			Issuer:       partnerOIDC.Issuer(),
+			ClientID:     partnerOIDC.ClientID,
+			ClientSecret: partnerOIDC.ClientSecret,
+			Provisioning: auth.Provisioning{
+				DenyUnknownUsers: true,
+			},
+		})))
+
+		callbacks := make(chan url.Values, 1)
+		clientHttpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			callbacks <- r.URL.Query()
+		}))
+		defer clientHttpServer.Close()
+
+		client := auth.NewClient(auth.ClientOptions{})
+		client.RedirectURIs.Append(clientHttpServer.URL)
+		_, clear := client.GenerateNewSecret(auth.SecretCreate{})
+		require.NoError(t, storage.SaveClient(context.TODO(), client))
+
+		clientRelyingParty, err := rp.NewRelyingPartyOIDC(issuer, client.Id, clear, client.RedirectURIs[0], []string{"openid", "email"})
+		require.NoError(t, err)
+
+		login := func() *http.Response {
+			partnerOIDC.QueueUser(&user{
+				MockUser: mockoidc.DefaultUser(),
+			})
+			rsp, err := http.Get(rp.AuthURL("", clientRelyingParty,
+				rp.AuthURLOpt(rp.WithURLParam(oidc.IdentityProviderHintParameter, "partner"))))
+			require.NoError(t, err)
+			return rsp
+		}
+
+		// The unknown users are rejected with an error page
+		rsp := login()
+		require.Equal(t, http.StatusForbidden, rsp.StatusCode)
+		body, err := io.ReadAll(rsp.Body)
+		require.NoError(t, err)
+		require.Contains(t, string(body), "Access denied")
+		require.Empty(t, callbacks)
+		_, err = storage.FindUserBySubject(context.TODO(), "partner", mockoidc.DefaultUser().Subject)
+		require.Error(t, err)
+
+		// The users created beforehand can log in
+		require.NoError(t, storage.SaveUser(context.TODO(), auth.NewUser(auth.UserOptions{
+			Provider: "partner",
+			Subject:  mockoidc.DefaultUser().Subject,
+		})))
+		rsp = login()
+		require.Equal(t, http.StatusOK, rsp.StatusCode)
+		select {
+		case query := <-callbacks:
+			require.NotEmpty(t, query.Get("code"))
+		default:
+			require.Fail(t, "callback was expected")
+		}
+	})
+}
+
 type RoundTripper struct {
 	http.RoundTripper
 }
@@ -282,3 +643,46 @@ func TestClientCredentials(t *testing.T) {
 		require.Equal(t, time.Until(token.Expiry).Round(oidc.ExpirationToken2Legged), oidc.ExpirationToken2Legged)
 	})
 }
+
+func TestSigningAlgorithms(t *testing.T) {
+	t.Parallel()
+
+	for _, algorithm := range oidc.SupportedSignatureAlgorithms {
+		algorithm := algorithm
+		t.Run(string(algorithm), func(t *testing.T) {
+			key, err := oidc.GenerateSigningKey(algorithm)
+			require.NoError(t, err)
+
+			withServerAndKey(t, key, func(m *mockoidc.MockOIDC, storage *sqlstorage.Storage, issuer string, provider op.OpenIDProvider) {
+				client := auth.NewClient(auth.ClientOptions{})
+				_, clear := client.GenerateNewSecret(auth.SecretCreate{})
+				require.NoError(t, storage.SaveClient(context.TODO(), client))
+
+				clientRelyingParty, err := rp.NewRelyingPartyOIDC(issuer, client.Id, clear, "", []string{"openid"})
+				require.NoError(t, err)
+
+				clientCredentialsConfig := clientcredentials.Config{
+					ClientID:     client.Id,
+					ClientSecret: clear,
+					TokenURL:     clientRelyingParty.OAuthConfig().Endpoint.TokenURL,
+				}
+				token, err := clientCredentialsConfig.Token(context.Background())
+				require.NoError(t, err)
+
+				// The server verifies its own tokens whatever the algorithm of its signing key
+				req, err := http.NewRequest(http.MethodPost, provider.IntrospectionEndpoint().Absolute(issuer),
+					strings.NewReader(url.Values{"token": {token.AccessToken}}.Encode()))
+				require.NoError(t, err)
+				req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+				req.SetBasicAuth(client.Id, clear)
+				rsp, err := http.DefaultClient.Do(req)
+				require.NoError(t, err)
+				require.Equal(t, http.StatusOK, rsp.StatusCode)
+
+				introspection := map[string]any{}
+				require.NoError(t, json.NewDecoder(rsp.Body).Decode(&introspection))
+				require.Equal(t, true, introspection["active"])
+			})
+		})
+	}
+}
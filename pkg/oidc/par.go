@@ -0,0 +1,151 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	httphelper "github.com/zitadel/oidc/v2/pkg/http"
+	"github.com/zitadel/oidc/v2/pkg/oidc"
+	"github.com/zitadel/oidc/v2/pkg/op"
+)
+
+const (
+	// RequestURIPrefix is the prefix of the request_uri returned for the pushed authorization requests
+	RequestURIPrefix = "urn:ietf:params:oauth:request_uri:"
+	// PushedAuthRequestLifetime is the delay given to the client to start the authorization with its request_uri
+	PushedAuthRequestLifetime = time.Minute
+)
+
+// PushedAuthorizationRequestEndpoint is the endpoint where the clients push their authorization requests (RFC 9126)
+var PushedAuthorizationRequestEndpoint = op.NewEndpoint("par")
+
+type PushedAuthRequestStorage interface {
+	// StorePushedAuthRequest stores a validated authorization request, its request_uri is valid until the expiration
+	StorePushedAuthRequest(ctx context.Context, authReq *oidc.AuthRequest, expiration time.Time) (op.AuthRequest, error)
+	// UsePushedAuthRequest returns the pushed authorization request of a client and invalidates its request_uri
+	UsePushedAuthRequest(ctx context.Context, clientID, id string) (op.AuthRequest, error)
+}
+
+type pushedAuthorizationResponse struct {
+	RequestURI string `json:"request_uri"`
+	ExpiresIn  int64  `json:"expires_in"`
+}
+
+// pushedAuthorizationRequest handles the par endpoint: the authenticated clients push the parameters of their authorization
+// requests and obtain a request_uri, which is then the only parameter sent to the authorization endpoint with the client_id
+func pushedAuthorizationRequest(provider op.OpenIDProvider) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		storage, ok := provider.Storage().(PushedAuthRequestStorage)
+		if !ok {
+			op.RequestError(w, r, oidc.ErrServerError().WithDescription("pushed authorization requests not supported"))
+			return
+		}
+
+		client, err := authenticateRequestClient(r, provider)
+		if err != nil {
+			op.RequestError(w, r, err)
+			return
+		}
+		if r.PostForm.Get("request_uri") != "" {
+			op.RequestError(w, r, oidc.ErrInvalidRequest().WithDescription("request_uri is not allowed"))
+			return
+		}
+		resources, err := parseResources(r)
+		if err != nil {
+			op.RequestError(w, r, err)
+			return
+		}
+
+		authReq, err := op.ParseAuthorizeRequest(r, provider.Decoder())
+		if err != nil {
+			op.RequestError(w, r, err)
+			return
+		}
+		if authReq.RequestParam != "" {
+			if !provider.RequestObjectSupported() {
+				op.RequestError(w, r, oidc.ErrRequestNotSupported())
+				return
+			}
+			authReq, err = op.ParseRequestObject(r.Context(), authReq, provider.Storage(), op.IssuerFromContext(r.Context()))
+			if err != nil {
+				op.RequestError(w, r, err)
+				return
+			}
+		}
+		if authReq.ClientID != client.GetID() {
+			op.RequestError(w, r, oidc.ErrInvalidRequest().WithDescription("client_id does not match the authenticated client"))
+			return
+		}
+		if authReq.RedirectURI == "" {
+			op.RequestError(w, r, oidc.ErrInvalidRequest().WithDescription("redirect_uri is required"))
+			return
+		}
+		if _, err := op.ValidateAuthRequestClient(r.Context(), authReq, client, provider.IDTokenHintVerifier(r.Context())); err != nil {
+			op.RequestError(w, r, err)
+			return
+		}
+
+		ctx := ContextWithIdentityProviderHint(ContextWithResources(r.Context(), resources), r.PostForm.Get(IdentityProviderHintParameter))
+		request, err := storage.StorePushedAuthRequest(ctx, authReq, time.Now().Add(PushedAuthRequestLifetime))
+		if err != nil {
+			op.RequestError(w, r, oidc.DefaultToServerError(err, "unable to save auth request"))
+			return
+		}
+
+		httphelper.MarshalJSONWithStatus(w, pushedAuthorizationResponse{
+			RequestURI: RequestURIPrefix + request.GetID(),
+			ExpiresIn:  int64(PushedAuthRequestLifetime.Seconds()),
+		}, http.StatusCreated)
+	}
+}
+
+// authorize starts the authorizations of the pushed authorization requests, and rejects the other ones
+// for the clients requiring them. The remaining requests are handled by the library, with their idp_hint in the context.
+func authorize(provider op.OpenIDProvider, next http.Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			op.AuthRequestError(w, r, nil, oidc.ErrInvalidRequest().WithParent(err), provider.Encoder())
+			return
+		}
+		clientID := r.Form.Get("client_id")
+		requestURI := r.Form.Get("request_uri")
+
+		if requestURI == "" {
+			if clientID != "" {
+				client, err := provider.Storage().GetClientByClientID(r.Context(), clientID)
+				if facade, ok := client.(*clientFacade); err == nil && ok && facade.Client.IsPARRequired() {
+					op.AuthRequestError(w, r, nil, oidc.ErrInvalidRequest().
+						WithDescription("the client requires pushed authorization requests"), provider.Encoder())
+					return
+				}
+			}
+			next.ServeHTTP(w, r.WithContext(ContextWithIdentityProviderHint(r.Context(), r.Form.Get(IdentityProviderHintParameter))))
+			return
+		}
+
+		storage, ok := provider.Storage().(PushedAuthRequestStorage)
+		if !ok {
+			op.AuthRequestError(w, r, nil, oidc.ErrRequestNotSupported(), provider.Encoder())
+			return
+		}
+		id, found := strings.CutPrefix(requestURI, RequestURIPrefix)
+		if !found || clientID == "" {
+			op.AuthRequestError(w, r, nil, oidc.ErrInvalidRequest().WithDescription("invalid request_uri"), provider.Encoder())
+			return
+		}
+		client, err := provider.Storage().GetClientByClientID(r.Context(), clientID)
+		if err != nil {
+			op.AuthRequestError(w, r, nil, oidc.ErrInvalidRequest().WithParent(err), provider.Encoder())
+			return
+		}
+		request, err := storage.UsePushedAuthRequest(r.Context(), clientID, id)
+		if err != nil {
+			op.AuthRequestError(w, r, nil, err, provider.Encoder())
+			return
+		}
+
+		op.RedirectToLogin(request.GetID(), client, w, r)
+	}
+}
@@ -0,0 +1,93 @@
+package oidc_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	auth "github.com/formancehq/auth/pkg"
+	"github.com/formancehq/auth/pkg/oidc"
+	"github.com/formancehq/auth/pkg/storage/sqlstorage"
+	"github.com/oauth2-proxy/mockoidc"
+	"github.com/stretchr/testify/require"
+	"github.com/zitadel/oidc/v2/pkg/op"
+)
+
+func TestPushedAuthorizationRequest(t *testing.T) {
+	withServer(t, func(m *mockoidc.MockOIDC, storage *sqlstorage.Storage, issuer string, provider op.OpenIDProvider) {
+		codeChan := make(chan string, 1)
+		clientHttpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			codeChan <- r.URL.Query().Get("code")
+		}))
+		defer clientHttpServer.Close()
+
+		client := auth.NewClient(auth.ClientOptions{
+			RequirePAR: true,
+		})
+		client.RedirectURIs.Append(clientHttpServer.URL)
+		_, clear := client.GenerateNewSecret(auth.SecretCreate{})
+		require.NoError(t, storage.SaveClient(context.TODO(), client))
+
+		authorizationParameters := url.Values{
+			"client_id":     []string{client.Id},
+			"response_type": []string{"code"},
+			"redirect_uri":  []string{clientHttpServer.URL},
+			"scope":         []string{"openid email"},
+			"state":         []string{"state"},
+		}
+		authorizationURL := issuer + op.DefaultEndpoints.Authorization.Relative()
+
+		// The client requires the pushed authorization requests
+		rsp, err := http.Get(authorizationURL + "?" + authorizationParameters.Encode())
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, rsp.StatusCode)
+
+		parURL := issuer + oidc.PushedAuthorizationRequestEndpoint.Relative()
+		form := url.Values{"client_secret": []string{clear}}
+		for key, values := range authorizationParameters {
+			form[key] = values
+		}
+		status, pushed := postForm(t, parURL, form)
+		require.Equal(t, http.StatusCreated, status)
+		require.True(t, strings.HasPrefix(pushed["request_uri"].(string), oidc.RequestURIPrefix))
+		require.EqualValues(t, oidc.PushedAuthRequestLifetime.Seconds(), pushed["expires_in"])
+
+		m.QueueUser(&user{
+			MockUser: mockoidc.DefaultUser(),
+		})
+		requestURL := authorizationURL + "?" + url.Values{
+			"client_id":   []string{client.Id},
+			"request_uri": []string{pushed["request_uri"].(string)},
+		}.Encode()
+		rsp, err = http.Get(requestURL)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, rsp.StatusCode)
+
+		select {
+		case code := <-codeChan:
+			require.NotEmpty(t, code)
+		default:
+			require.Fail(t, "code was expected")
+		}
+
+		// The request_uri can be used only once
+		rsp, err = http.Get(requestURL)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, rsp.StatusCode)
+
+		// The client must be authenticated
+		form.Set("client_secret", "invalid")
+		status, _ = postForm(t, parURL, form)
+		require.NotEqual(t, http.StatusCreated, status)
+
+		// The redirect uri must be registered
+		form.Set("client_secret", clear)
+		form.Set("redirect_uri", "http://example.com/callback")
+		status, body := postForm(t, parURL, form)
+		require.Equal(t, http.StatusBadRequest, status)
+		require.Equal(t, "invalid_request", body["error"])
+	})
+}
@@ -0,0 +1,225 @@
+package oidc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	auth "github.com/formancehq/auth/pkg"
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/ext"
+)
+
+// PolicyInput describes a grant of scopes evaluated by a ScopePolicy
+type PolicyInput struct {
+	// Client is the client the scopes are granted to, nil if unknown like for the JWT profile grant
+	Client Client
+	// User is the user the scopes are granted for, nil for the client credentials grant
+	User *auth.User
+	// Claims are the claims asserted by the upstream identity provider about the user, if any
+	Claims map[string]any
+	// Scopes are the requested scopes, their patterns and implied scopes being expanded
+	Scopes []string
+	// RemoteAddr is the IP address of the request, empty if unknown
+	RemoteAddr string
+	Time       time.Time
+}
+
+// ScopePolicy decides which of the requested scopes are granted
+type ScopePolicy interface {
+	GrantScopes(ctx context.Context, input PolicyInput) ([]string, error)
+}
+
+// ScopePolicyFunc allows the use of a function as a ScopePolicy
+type ScopePolicyFunc func(ctx context.Context, input PolicyInput) ([]string, error)
+
+func (f ScopePolicyFunc) GrantScopes(ctx context.Context, input PolicyInput) ([]string, error) {
+	return f(ctx, input)
+}
+
+// PolicyRule restricts the grant of some scopes to a condition, the rules are declared in the configuration file
+type PolicyRule struct {
+	// Scopes are the scopes the rule applies to, wildcards are allowed, all the scopes if empty
+	Scopes []string `json:"scopes" yaml:"scopes"`
+	// Condition is a CEL expression which must evaluate to true for the scopes to be granted.
+	// The expression can use the variables client, user, claims, scope, scopes, ip and now.
+	Condition string `json:"condition" yaml:"condition"`
+}
+
+type compiledRule struct {
+	PolicyRule
+	program cel.Program
+}
+
+type rulesPolicy struct {
+	rules []compiledRule
+}
+
+// NewRulesPolicy compiles the rules into a ScopePolicy.
+// A requested scope is granted if the conditions of all the rules applying to it evaluate to true,
+// a condition failing to evaluate, like one reading a missing claim, denies the scope.
+func NewRulesPolicy(rules ...PolicyRule) (ScopePolicy, error) {
+	env, err := cel.NewEnv(
+		cel.Variable("client", cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable("user", cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable("claims", cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable("scope", cel.StringType),
+		cel.Variable("scopes", cel.ListType(cel.StringType)),
+		cel.Variable("ip", cel.StringType),
+		cel.Variable("now", cel.TimestampType),
+		ext.Strings(),
+		ext.Network(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := &rulesPolicy{}
+	for i, rule := range rules {
+		for _, scope := range rule.Scopes {
+			if err := auth.ValidateScopeName(scope); err != nil {
+				return nil, fmt.Errorf("policy rule %d: %w", i, err)
+			}
+		}
+		ast, issues := env.Compile(rule.Condition)
+		if issues.Err() != nil {
+			return nil, fmt.Errorf("policy rule %d: %w", i, issues.Err())
+		}
+		if ast.OutputType() != cel.BoolType {
+			return nil, fmt.Errorf("policy rule %d: condition must evaluate to a bool, got %s", i, ast.OutputType())
+		}
+		program, err := env.Program(ast)
+		if err != nil {
+			return nil, fmt.Errorf("policy rule %d: %w", i, err)
+		}
+		policy.rules = append(policy.rules, compiledRule{
+			PolicyRule: rule,
+			program:    program,
+		})
+	}
+	return policy, nil
+}
+
+func (p *rulesPolicy) GrantScopes(ctx context.Context, input PolicyInput) ([]string, error) {
+	vars := map[string]any{
+		"client": map[string]any{},
+		"user":   map[string]any{},
+		"claims": map[string]any{},
+		"scopes": input.Scopes,
+		"ip":     input.RemoteAddr,
+		"now":    input.Time,
+	}
+	if input.Client != nil {
+		vars["client"] = map[string]any{
+			"id":      input.Client.GetID(),
+			"public":  input.Client.IsPublic(),
+			"trusted": input.Client.IsTrusted(),
+			"scopes":  input.Client.GetScopes(),
+		}
+	}
+	if input.User != nil {
+		vars["user"] = map[string]any{
+			"id":      input.User.ID,
+			"subject": input.User.Subject,
+			"email":   input.User.Email,
+		}
+	}
+	if input.Claims != nil {
+		vars["claims"] = input.Claims
+	}
+
+	granted := make([]string, 0)
+	for _, scope := range input.Scopes {
+		vars["scope"] = scope
+		if p.allows(ctx, scope, vars) {
+			granted = append(granted, scope)
+		}
+	}
+	return granted, nil
+}
+
+func (p *rulesPolicy) allows(ctx context.Context, scope string, vars map[string]any) bool {
+	for _, rule := range p.rules {
+		if len(rule.Scopes) > 0 && !auth.MatchAnyScope(rule.Scopes, scope) {
+			continue
+		}
+		out, _, err := rule.program.ContextEval(ctx, vars)
+		if err != nil {
+			return false
+		}
+		if allowed, ok := out.Value().(bool); !ok || !allowed {
+			return false
+		}
+	}
+	return true
+}
+
+type ScopePolicyStorage interface {
+	// GrantScopes returns the scopes granted by the policy to a client for a user authenticated upstream
+	GrantScopes(ctx context.Context, clientID string, user *auth.User, claims map[string]any, scopes []string) ([]string, error)
+}
+
+// GrantScopes implements the ScopePolicyStorage interface
+// it will be called once the user of an authorization request has logged in
+func (s *storageFacade) GrantScopes(ctx context.Context, clientID string, user *auth.User, claims map[string]any, scopes []string) ([]string, error) {
+	client, err := s.findClient(ctx, clientID)
+	if err != nil {
+		return nil, err
+	}
+	return s.grantScopes(ctx, client, user, claims, scopes)
+}
+
+// grantScopes evaluates the policy, all the scopes are granted without policy
+func (s *storageFacade) grantScopes(ctx context.Context, client Client, user *auth.User, claims map[string]any, scopes []string) ([]string, error) {
+	if s.policy == nil {
+		return scopes, nil
+	}
+	return s.policy.GrantScopes(ctx, PolicyInput{
+		Client:     client,
+		User:       user,
+		Claims:     claims,
+		Scopes:     scopes,
+		RemoteAddr: RemoteAddrFromContext(ctx),
+		Time:       time.Now(),
+	})
+}
+
+type upstreamClaimsContextKey struct{}
+
+// ContextWithUpstreamClaims stores the claims asserted by the upstream identity provider about the user of the request
+func ContextWithUpstreamClaims(ctx context.Context, claims map[string]any) context.Context {
+	return context.WithValue(ctx, upstreamClaimsContextKey{}, claims)
+}
+
+// UpstreamClaimsFromContext returns the claims asserted by the upstream identity provider, nil if none
+func UpstreamClaimsFromContext(ctx context.Context) map[string]any {
+	claims, _ := ctx.Value(upstreamClaimsContextKey{}).(map[string]any)
+	return claims
+}
+
+type remoteAddrContextKey struct{}
+
+// ContextWithRemoteAddr stores the IP address of the request
+func ContextWithRemoteAddr(ctx context.Context, addr string) context.Context {
+	return context.WithValue(ctx, remoteAddrContextKey{}, addr)
+}
+
+// RemoteAddrFromContext returns the IP address of the request, empty if unknown
+func RemoteAddrFromContext(ctx context.Context) string {
+	addr, _ := ctx.Value(remoteAddrContextKey{}).(string)
+	return addr
+}
+
+// RemoteAddrMiddleware stores the IP address of the requests in their context for the policy.
+// The forwarding headers are not trusted, a proxy in front of the server has to rewrite the remote address.
+func RemoteAddrMiddleware(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		addr, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			addr = r.RemoteAddr
+		}
+		h.ServeHTTP(w, r.WithContext(ContextWithRemoteAddr(r.Context(), addr)))
+	})
+}
@@ -0,0 +1,160 @@
+package oidc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	auth "github.com/formancehq/auth/pkg"
+	"github.com/formancehq/auth/pkg/oidc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRulesPolicy(t *testing.T) {
+	t.Parallel()
+
+	client := &auth.StaticClient{
+		ClientOptions: auth.ClientOptions{
+			Id:     "client",
+			Scopes: []string{"ledger:*"},
+		},
+	}
+	user := &auth.User{
+		ID: "user",
+		UserOptions: auth.UserOptions{
+			Subject: "subject",
+			Email:   "user@example.com",
+		},
+	}
+
+	type testCase struct {
+		name     string
+		rules    []oidc.PolicyRule
+		input    oidc.PolicyInput
+		expected []string
+	}
+	for _, tc := range []testCase{
+		{
+			name: "no rules",
+			input: oidc.PolicyInput{
+				Scopes: []string{"ledger:read", "ledger:write"},
+			},
+			expected: []string{"ledger:read", "ledger:write"},
+		},
+		{
+			name: "condition on the user",
+			rules: []oidc.PolicyRule{{
+				Scopes:    []string{"ledger:write"},
+				Condition: `user.email.endsWith("@formance.com")`,
+			}},
+			input: oidc.PolicyInput{
+				User:   user,
+				Scopes: []string{"ledger:read", "ledger:write"},
+			},
+			expected: []string{"ledger:read"},
+		},
+		{
+			name: "condition on the upstream claims",
+			rules: []oidc.PolicyRule{{
+				Scopes:    []string{"ledger:*"},
+				Condition: `"admins" in claims.groups`,
+			}},
+			input: oidc.PolicyInput{
+				User:   user,
+				Claims: map[string]any{"groups": []any{"admins"}},
+				Scopes: []string{"ledger:read", "ledger:write", "openid"},
+			},
+			expected: []string{"ledger:read", "ledger:write", "openid"},
+		},
+		{
+			name: "missing claim",
+			rules: []oidc.PolicyRule{{
+				Scopes:    []string{"ledger:*"},
+				Condition: `"admins" in claims.groups`,
+			}},
+			input: oidc.PolicyInput{
+				User:   user,
+				Scopes: []string{"ledger:read", "openid"},
+			},
+			expected: []string{"openid"},
+		},
+		{
+			name: "condition on the client and the ip",
+			rules: []oidc.PolicyRule{{
+				Condition: `client.id == "client" && cidr("10.0.0.0/8").containsIP(ip)`,
+			}},
+			input: oidc.PolicyInput{
+				Client:     client,
+				Scopes:     []string{"ledger:read"},
+				RemoteAddr: "10.1.2.3",
+			},
+			expected: []string{"ledger:read"},
+		},
+		{
+			name: "denied ip",
+			rules: []oidc.PolicyRule{{
+				Condition: `cidr("10.0.0.0/8").containsIP(ip)`,
+			}},
+			input: oidc.PolicyInput{
+				Client:     client,
+				Scopes:     []string{"ledger:read"},
+				RemoteAddr: "192.168.1.1",
+			},
+			expected: []string{},
+		},
+		{
+			name: "condition on the time and the scope",
+			rules: []oidc.PolicyRule{{
+				Condition: `scope != "ledger:write" || now.getHours("UTC") < 12`,
+			}},
+			input: oidc.PolicyInput{
+				Scopes: []string{"ledger:read", "ledger:write"},
+				Time:   time.Date(2024, time.January, 1, 18, 0, 0, 0, time.UTC),
+			},
+			expected: []string{"ledger:read"},
+		},
+		{
+			name: "all the applying rules must allow the scope",
+			rules: []oidc.PolicyRule{
+				{
+					Condition: `true`,
+				},
+				{
+					Scopes:    []string{"ledger:write"},
+					Condition: `false`,
+				},
+			},
+			input: oidc.PolicyInput{
+				Scopes: []string{"ledger:read", "ledger:write"},
+			},
+			expected: []string{"ledger:read"},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			policy, err := oidc.NewRulesPolicy(tc.rules...)
+			require.NoError(t, err)
+
+			granted, err := policy.GrantScopes(context.Background(), tc.input)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, granted)
+		})
+	}
+}
+
+func TestRulesPolicyInvalidCondition(t *testing.T) {
+	t.Parallel()
+
+	for _, condition := range []string{
+		`user.email ==`,
+		`user.email`,
+		`unknown == "value"`,
+	} {
+		_, err := oidc.NewRulesPolicy(oidc.PolicyRule{
+			Condition: condition,
+		})
+		require.Error(t, err, condition)
+	}
+}
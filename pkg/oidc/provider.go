@@ -1,15 +1,18 @@
 package oidc
 
 import (
+	"context"
 	"crypto/sha256"
+	"errors"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
+	"github.com/formancehq/auth/pkg/delegatedauth"
 	"github.com/zitadel/oidc/v2/pkg/oidc"
 	"github.com/zitadel/oidc/v2/pkg/op"
 	"golang.org/x/text/language"
-	"gopkg.in/go-jose/go-jose.v2"
 )
 
 const (
@@ -17,19 +20,14 @@ const (
 )
 
 type verifier struct {
-	issuer          string
-	mat             time.Duration
-	offset          time.Duration
-	jsonWebKeySet   jose.JSONWebKeySet
-	delegatedIssuer string
+	issuer    string
+	mat       time.Duration
+	offset    time.Duration
+	providers *delegatedauth.Providers
 }
 
-func (v verifier) DelegatedIssuer() string {
-	return v.delegatedIssuer
-}
-
-func (v verifier) JSONWebKeySet() jose.JSONWebKeySet {
-	return v.jsonWebKeySet
+func (v verifier) KeySet(ctx context.Context, issuer string) (oidc.KeySet, error) {
+	return upstreamKeySet(ctx, v.providers, issuer)
 }
 
 func (v verifier) Issuer() string {
@@ -46,24 +44,36 @@ func (v verifier) Offset() time.Duration {
 
 type provider struct {
 	op.OpenIDProvider
-	delegatedIssuerJsonWebKeySet jose.JSONWebKeySet
-	delegatedIssuer              string
-	trustedIssuers               []string
+	providers      *delegatedauth.Providers
+	trustedIssuers []string
 }
 
 func (p provider) JWTProfileVerifier(issuer string) JWTProfileVerifier {
 	return &verifier{
-		issuer:          issuer,
-		delegatedIssuer: p.delegatedIssuer,
-		mat:             time.Hour,
-		offset:          0,
-		jsonWebKeySet:   p.delegatedIssuerJsonWebKeySet,
+		issuer:    issuer,
+		mat:       time.Hour,
+		offset:    0,
+		providers: p.providers,
+	}
+}
+
+// upstreamKeySet returns the keys of the identity provider of an issuer,
+// the tokens of the issuers matching no identity provider are rejected
+func upstreamKeySet(ctx context.Context, providers *delegatedauth.Providers, issuer string) (oidc.KeySet, error) {
+	if providers == nil {
+		return nil, oidc.ErrInvalidGrant().WithDescription("untrusted issuer %s", issuer)
+	}
+	keySet, err := providers.KeySet(ctx, issuer)
+	if errors.Is(err, delegatedauth.ErrUnknownProvider) {
+		return nil, oidc.ErrInvalidGrant().WithDescription("untrusted issuer %s", issuer)
 	}
+	return keySet, err
 }
 
 var _ JWTAuthorizationGrantExchanger = (*provider)(nil)
 
-func NewOpenIDProvider(storage op.Storage, issuer string, trustedIssuers []string, delegatedIssuer string, delegatedIssuerJsonWebKeySet *jose.JSONWebKeySet) (op.OpenIDProvider, error) {
+// NewOpenIDProvider creates the provider, the tokens of the identity providers are accepted by the jwt bearer and token exchange grants
+func NewOpenIDProvider(storage op.Storage, issuer string, trustedIssuers []string, providers *delegatedauth.Providers) (op.OpenIDProvider, error) {
 	var p op.OpenIDProvider
 
 	parsedIssuer, err := url.Parse(issuer)
@@ -71,27 +81,108 @@ func NewOpenIDProvider(storage op.Storage, issuer string, trustedIssuers []strin
 		return nil, err
 	}
 
-	interceptors := make([]op.Option, 0)
-	if delegatedIssuer != "" {
-		interceptors = append(interceptors, op.WithHttpInterceptors(func(handler http.Handler) http.Handler {
+	replays, ok := storage.(ReplayStorage)
+	if !ok {
+		return nil, errors.New("the storage must record the ids of the single use JWTs")
+	}
+	dpop := newDPoPVerifier(replays)
+	interceptors := []op.Option{
+		op.WithHttpInterceptors(func(handler http.Handler) http.Handler {
+			return preventClientAssertionReplay(p, handler)
+		}),
+		op.WithHttpInterceptors(func(handler http.Handler) http.Handler {
 			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-				// Intercept token requests with grant_type of type bearer assertion
-				// as the library does not implement what we needs
-				if r.URL.Path == op.DefaultEndpoints.Token.Relative() &&
-					r.FormValue("grant_type") == string(oidc.GrantTypeBearer) {
-					grantTypeBearer(&provider{
-						trustedIssuers:               trustedIssuers,
-						OpenIDProvider:               p,
-						delegatedIssuerJsonWebKeySet: *delegatedIssuerJsonWebKeySet,
-						delegatedIssuer:              delegatedIssuer,
+				switch r.URL.Path {
+				case oidc.DiscoveryEndpoint:
+					scopes, err := supportedScopes(r.Context(), p)
+					if err != nil {
+						op.RequestError(w, r, err)
+						return
+					}
+					rewriteJSONResponse(handler, w, r, func(body map[string]any) {
+						body["scopes_supported"] = scopes
+						body["dpop_signing_alg_values_supported"] = DPoPSigningAlgorithms
+						body["pushed_authorization_request_endpoint"] = PushedAuthorizationRequestEndpoint.Absolute(op.IssuerFromContext(r.Context()))
+					})
+					return
+				case op.DefaultEndpoints.Token.Relative():
+					keyThumbprint, err := dpop.Verify(r, p.TokenEndpoint().Absolute(op.IssuerFromContext(r.Context())), time.Now())
+					if err != nil {
+						op.RequestError(w, r, err)
+						return
+					}
+					if keyThumbprint != "" {
+						// the tokens are bound to the key of the proof, their type is DPoP instead of Bearer
+						rewriteJSONResponse(handler, w, r.WithContext(ContextWithDPoPKeyThumbprint(r.Context(), keyThumbprint)),
+							func(body map[string]any) {
+								if _, ok := body["access_token"]; ok {
+									body["token_type"] = DPoPTokenType
+								}
+							})
+						return
+					}
+				}
+				handler.ServeHTTP(w, r)
+			})
+		}),
+		op.WithHttpInterceptors(func(handler http.Handler) http.Handler {
+			return withResources(p, handler)
+		}),
+		op.WithHttpInterceptors(func(handler http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == op.DefaultEndpoints.Introspection.Relative() {
+					introspectionHandler(p).ServeHTTP(w, r)
+					return
+				}
+				if r.URL.Path == op.DefaultEndpoints.Authorization.Relative() {
+					authorize(p, handler).ServeHTTP(w, r)
+					return
+				}
+				if isClientCredentialsWithAssertion(r) {
+					clientCredentialsWithAssertion(p).ServeHTTP(w, r)
+					return
+				}
+				if isDeviceAccessTokenRequest(r) {
+					deviceAccessToken(p).ServeHTTP(w, r)
+					return
+				}
+				if isTokenExchangeRequest(r) {
+					tokenExchange(p, exchangedTokenVerifier{
+						provider:  p,
+						providers: providers,
 					}).ServeHTTP(w, r)
 					return
 				}
 				handler.ServeHTTP(w, r)
 			})
-
-		}))
+		}),
 	}
+	interceptors = append(interceptors, op.WithHttpInterceptors(func(handler http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Intercept token requests with grant_type of type bearer assertion
+			// as the library does not implement what we needs
+			if r.URL.Path == op.DefaultEndpoints.Token.Relative() &&
+				r.FormValue("grant_type") == string(oidc.GrantTypeBearer) {
+				grantTypeBearer(&provider{
+					trustedIssuers: trustedIssuers,
+					OpenIDProvider: p,
+					providers:      providers,
+				}).ServeHTTP(w, r)
+				return
+			}
+			handler.ServeHTTP(w, r)
+		})
+
+	}))
+
+	interceptors = append(interceptors, op.WithCustomDeviceAuthorizationEndpoint(DeviceAuthorizationEndpoint))
+
+	// The verifiers of the library only accept RS256 by default, while the signing keys can use any supported algorithm.
+	// The signatures are checked against the published keys, so a token is only accepted with the algorithm of its key.
+	interceptors = append(interceptors,
+		op.WithAccessTokenVerifierOpts(op.WithSupportedAccessTokenSigningAlgorithms(supportedSignatureAlgorithmNames()...)),
+		op.WithIDTokenHintVerifierOpts(op.WithSupportedIDTokenHintSigningAlgorithms(supportedSignatureAlgorithmNames()...)),
+	)
 
 	if parsedIssuer.Scheme == "http" {
 		interceptors = append(interceptors, op.WithAllowInsecure())
@@ -109,6 +200,12 @@ func NewOpenIDProvider(storage op.Storage, issuer string, trustedIssuers []strin
 		GrantTypeRefreshToken:    true,
 		RequestObjectSupported:   true,
 		SupportedUILocales:       []language.Tag{language.English},
+		DeviceAuthorization: op.DeviceAuthorizationConfig{
+			Lifetime:     DeviceCodeLifetime,
+			PollInterval: DevicePollInterval,
+			UserFormPath: strings.TrimSuffix(parsedIssuer.Path, "/") + DeviceVerificationPath,
+			UserCode:     op.UserCodeBase20,
+		},
 	}, storage, interceptors...)
 	return p, err
 }
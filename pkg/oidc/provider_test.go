@@ -0,0 +1,85 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	auth "github.com/formancehq/auth/pkg"
+	"github.com/formancehq/auth/pkg/delegatedauth"
+	"github.com/golang-jwt/jwt"
+	"github.com/oauth2-proxy/mockoidc"
+	"github.com/stretchr/testify/require"
+	"github.com/zitadel/oidc/v2/pkg/oidc"
+)
+
+func TestUpstreamTokenVerifiers(t *testing.T) {
+	t.Parallel()
+
+	m, err := mockoidc.Run()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = m.Shutdown() })
+
+	// The identity provider is managed with the api, no issuer is configured with the delegated issuer flags
+	store := &upstreamUsersStorage{
+		identityProviders: []auth.IdentityProvider{*auth.NewIdentityProvider(auth.IdentityProviderOptions{
+			Name:         "partner",
+			Issuer:       m.Issuer(),
+			ClientID:     m.ClientID,
+			ClientSecret: m.ClientSecret,
+		})},
+	}
+	providers := delegatedauth.NewProviders(http.DefaultClient, "http://localhost/authorize/callback", store)
+
+	const issuer = "http://localhost:8080"
+	signWith := func(keypair *mockoidc.Keypair, tokenIssuer string) string {
+		token, err := keypair.SignJWT(jwt.MapClaims{
+			"iss": tokenIssuer,
+			"sub": "alice",
+			"aud": []string{issuer},
+			"exp": time.Now().Add(time.Minute).Unix(),
+		})
+		require.NoError(t, err)
+		return token
+	}
+	sign := func(tokenIssuer string) string {
+		return signWith(m.Keypair, tokenIssuer)
+	}
+
+	// The assertions of the jwt bearer grant are verified with the keys of the identity provider of their issuer
+	request, err := VerifyJWTAssertion(context.Background(), sign(m.Issuer()), provider{
+		providers: providers,
+	}.JWTProfileVerifier(issuer))
+	require.NoError(t, err)
+	require.Equal(t, "alice", request.Subject)
+
+	_, err = VerifyJWTAssertion(context.Background(), sign("https://accounts.other.com"), provider{
+		providers: providers,
+	}.JWTProfileVerifier(issuer))
+	require.Error(t, err)
+
+	// The same way for the subject tokens of the token exchange grant
+	_, subject, _, err := exchangedTokenVerifier{
+		providers: providers,
+	}.Verify(context.Background(), sign(m.Issuer()), oidc.AccessTokenType)
+	require.NoError(t, err)
+	require.Equal(t, "alice", subject)
+
+	_, _, _, err = exchangedTokenVerifier{
+		providers: providers,
+	}.Verify(context.Background(), sign("https://accounts.other.com"), oidc.AccessTokenType)
+	require.Error(t, err)
+
+	// The tokens signed with a key the identity provider does not publish are rejected
+	otherKeypair, err := mockoidc.RandomKeypair(2048)
+	require.NoError(t, err)
+	_, err = VerifyJWTAssertion(context.Background(), signWith(otherKeypair, m.Issuer()), provider{
+		providers: providers,
+	}.JWTProfileVerifier(issuer))
+	require.Error(t, err)
+
+	// No upstream token is accepted without identity providers
+	_, _, _, err = exchangedTokenVerifier{}.Verify(context.Background(), sign(m.Issuer()), oidc.AccessTokenType)
+	require.Error(t, err)
+}
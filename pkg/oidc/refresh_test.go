@@ -0,0 +1,76 @@
+package oidc_test
+
+import (
+	"testing"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+	auth "github.com/formancehq/auth/pkg"
+	"github.com/formancehq/auth/pkg/events"
+	"github.com/formancehq/auth/pkg/oidc"
+	"github.com/formancehq/auth/pkg/storage"
+	"github.com/formancehq/auth/pkg/storage/sqlstorage"
+	"github.com/formancehq/go-libs/v3/bun/bunconnect"
+	"github.com/formancehq/go-libs/v3/logging"
+	"github.com/formancehq/go-libs/v3/publish"
+	"github.com/stretchr/testify/require"
+	"github.com/zitadel/oidc/v2/pkg/op"
+	"gopkg.in/go-jose/go-jose.v2"
+)
+
+func newTestStorageFacade(t *testing.T, publisher message.Publisher) (*sqlstorage.Storage, op.Storage) {
+	ctx := logging.TestingContext()
+
+	db, err := bunconnect.OpenSQLDB(ctx, bunconnect.ConnectionOptions{
+		DatabaseSourceName: srv.NewDatabase(t).ConnString(),
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	require.NoError(t, sqlstorage.Migrate(ctx, db))
+
+	key, err := oidc.GenerateSigningKey(jose.RS256)
+	require.NoError(t, err)
+	keyRing, err := oidc.NewKeyRing(key)
+	require.NoError(t, err)
+
+	store := sqlstorage.New(db, nil)
+	return store, oidc.NewStorageFacade(store, nil, keyRing, oidc.DefaultTokenLifetimes, oidc.MTLSConfig{}, publisher, nil)
+}
+
+func TestRefreshTokenReuse(t *testing.T) {
+	t.Parallel()
+
+	ctx := logging.TestingContext()
+	publisher := publish.InMemory()
+	store, storageFacade := newTestStorageFacade(t, publisher)
+
+	client := auth.NewClient(auth.ClientOptions{})
+	require.NoError(t, store.SaveClient(ctx, client))
+
+	_, refreshToken, _, err := storageFacade.CreateAccessAndRefreshTokens(ctx, &auth.AuthRequest{
+		ApplicationID: client.Id,
+		UserID:        "user",
+	}, "")
+	require.NoError(t, err)
+
+	// First renewal, rotates the refresh token
+	request, err := storageFacade.TokenRequestByRefreshToken(ctx, refreshToken)
+	require.NoError(t, err)
+	accessToken, renewedRefreshToken, _, err := storageFacade.CreateAccessAndRefreshTokens(ctx, request, refreshToken)
+	require.NoError(t, err)
+	require.NotEqual(t, refreshToken, renewedRefreshToken)
+
+	// Replay of the rotated token, the whole family must be revoked
+	_, err = storageFacade.TokenRequestByRefreshToken(ctx, refreshToken)
+	require.Error(t, err)
+
+	_, err = storageFacade.TokenRequestByRefreshToken(ctx, renewedRefreshToken)
+	require.Error(t, err)
+	_, err = store.FindAccessToken(ctx, accessToken)
+	require.ErrorIs(t, err, storage.ErrNotFound)
+
+	messages := publisher.AllMessages()[events.TopicAuth]
+	require.Len(t, messages, 1)
+	_, event, err := publish.UnmarshalMessage(messages[0])
+	require.NoError(t, err)
+	require.Equal(t, events.EventTypeRefreshTokenReused, event.Type)
+}
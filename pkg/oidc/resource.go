@@ -0,0 +1,111 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	auth "github.com/formancehq/auth/pkg"
+	"github.com/formancehq/auth/pkg/storage"
+	"github.com/formancehq/go-libs/v3/collectionutils"
+	"github.com/zitadel/oidc/v2/pkg/oidc"
+	"github.com/zitadel/oidc/v2/pkg/op"
+)
+
+// ResourceParameter is the parameter of the authorization and token requests indicating the resource servers
+// the access tokens are requested for (RFC 8707)
+const ResourceParameter = "resource"
+
+type resourcesContextKey struct{}
+
+// ContextWithResources stores the resources requested with the resource parameter
+func ContextWithResources(ctx context.Context, resources []string) context.Context {
+	return context.WithValue(ctx, resourcesContextKey{}, resources)
+}
+
+// ResourcesFromContext returns the resources requested with the resource parameter, empty if none
+func ResourcesFromContext(ctx context.Context) []string {
+	resources, _ := ctx.Value(resourcesContextKey{}).([]string)
+	return resources
+}
+
+// isResourceRequest returns true for the requests accepting the resource parameter handled by the library
+func isResourceRequest(r *http.Request) bool {
+	return r.URL.Path == op.DefaultEndpoints.Authorization.Relative() ||
+		r.URL.Path == op.DefaultEndpoints.Token.Relative()
+}
+
+// parseResources reads the resource parameters of a request, the library ignores them
+func parseResources(r *http.Request) ([]string, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, oidc.ErrInvalidRequest().WithParent(err)
+	}
+	resources := r.Form[ResourceParameter]
+	for _, resource := range resources {
+		if err := auth.ValidateResourceIdentifier(resource); err != nil {
+			return nil, errInvalidTarget().WithDescription("%s", err)
+		}
+	}
+	return resources, nil
+}
+
+// withResources stores the resources of the authorization and token requests in their context,
+// so the storage can restrict the tokens to them
+func withResources(provider op.OpenIDProvider, next http.Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !isResourceRequest(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		resources, err := parseResources(r)
+		if err != nil {
+			if r.URL.Path == op.DefaultEndpoints.Authorization.Relative() {
+				op.AuthRequestError(w, r, nil, err, provider.Encoder())
+				return
+			}
+			op.RequestError(w, r, err)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(ContextWithResources(r.Context(), resources)))
+	}
+}
+
+// checkGrantedResources checks that the resources requested on the token endpoint have been granted
+func checkGrantedResources(granted, requested []string) error {
+	for _, resource := range requested {
+		if !collectionutils.Contains(granted, resource) {
+			return errInvalidTarget().WithDescription("resource %s has not been granted", resource)
+		}
+	}
+	return nil
+}
+
+// resourceServers returns the registered resource servers of the requested resources
+func (s *storageFacade) resourceServers(ctx context.Context, resources []string) ([]*auth.ResourceServer, error) {
+	ret := make([]*auth.ResourceServer, 0, len(resources))
+	for _, resource := range resources {
+		resourceServer, err := s.FindResourceServerByIdentifier(ctx, resource)
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				return nil, errInvalidTarget().WithDescription("unknown resource %s", resource)
+			}
+			return nil, err
+		}
+		ret = append(ret, resourceServer)
+	}
+	return ret, nil
+}
+
+// restrictToResources sets the requested resources as audiences of the request and narrows its scopes to them
+func (s *storageFacade) restrictToResources(ctx context.Context, request *auth.AuthRequest, resources []string) error {
+	if len(resources) == 0 {
+		return nil
+	}
+	resourceServers, err := s.resourceServers(ctx, resources)
+	if err != nil {
+		return err
+	}
+	request.Resources = resources
+	request.Scopes = auth.NarrowScopesToResources(request.Scopes, resourceServers)
+	return nil
+}
@@ -0,0 +1,69 @@
+package oidc_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	auth "github.com/formancehq/auth/pkg"
+	"github.com/formancehq/auth/pkg/storage/sqlstorage"
+	"github.com/oauth2-proxy/mockoidc"
+	"github.com/stretchr/testify/require"
+	zoidc "github.com/zitadel/oidc/v2/pkg/oidc"
+	"github.com/zitadel/oidc/v2/pkg/op"
+)
+
+func TestResourceIndicators(t *testing.T) {
+	withServer(t, func(m *mockoidc.MockOIDC, storage *sqlstorage.Storage, issuer string, provider op.OpenIDProvider) {
+		ledger := auth.NewResourceServer(auth.ResourceServerOptions{
+			Name:       "ledger",
+			Identifier: "https://ledger.formance.com",
+			Scopes:     []string{"ledger:read", "ledger:write"},
+		})
+		require.NoError(t, storage.SaveResourceServer(context.TODO(), ledger))
+
+		client := auth.NewClient(auth.ClientOptions{
+			Scopes: []string{"ledger:read", "payments:read"},
+		})
+		_, clear := client.GenerateNewSecret(auth.SecretCreate{})
+		require.NoError(t, storage.SaveClient(context.TODO(), client))
+
+		tokenURL := issuer + op.DefaultEndpoints.Token.Relative()
+		clientCredentials := func(resources ...string) (int, map[string]any) {
+			return postForm(t, tokenURL, url.Values{
+				"grant_type":    []string{string(zoidc.GrantTypeClientCredentials)},
+				"client_id":     []string{client.Id},
+				"client_secret": []string{clear},
+				"scope":         []string{"ledger:read payments:read"},
+				"resource":      resources,
+			})
+		}
+
+		// The token is restricted to the requested resource
+		status, rsp := clientCredentials(ledger.Identifier)
+		require.Equal(t, http.StatusOK, status)
+		claims := &zoidc.AccessTokenClaims{}
+		_, err := zoidc.ParseToken(rsp["access_token"].(string), claims)
+		require.NoError(t, err)
+		require.Contains(t, claims.Audience, ledger.Identifier)
+		require.Equal(t, zoidc.SpaceDelimitedArray{"ledger:read"}, claims.Scopes)
+
+		// Without resource, the token is not restricted
+		status, rsp = clientCredentials()
+		require.Equal(t, http.StatusOK, status)
+		claims = &zoidc.AccessTokenClaims{}
+		_, err = zoidc.ParseToken(rsp["access_token"].(string), claims)
+		require.NoError(t, err)
+		require.NotContains(t, claims.Audience, ledger.Identifier)
+
+		// The resources must be registered
+		status, rsp = clientCredentials("https://payments.formance.com")
+		require.Equal(t, http.StatusBadRequest, status)
+		require.Equal(t, "invalid_target", rsp["error"])
+
+		status, rsp = clientCredentials("payments")
+		require.Equal(t, http.StatusBadRequest, status)
+		require.Equal(t, "invalid_target", rsp["error"])
+	})
+}
@@ -0,0 +1,59 @@
+package oidc
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+// responseRecorder buffers a response so it can be modified before being sent
+type responseRecorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (r *responseRecorder) Header() http.Header {
+	return r.header
+}
+
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	return r.body.Write(data)
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+}
+
+// rewriteJSONResponse serves the request and lets the caller modify the successful JSON responses of the library,
+// for the fields it does not support. The other responses are sent unmodified.
+func rewriteJSONResponse(handler http.Handler, w http.ResponseWriter, r *http.Request, rewrite func(body map[string]any)) {
+	recorder := &responseRecorder{
+		header: w.Header(),
+	}
+	handler.ServeHTTP(recorder, r)
+	if recorder.status == 0 {
+		recorder.status = http.StatusOK
+	}
+
+	data := recorder.body.Bytes()
+	if recorder.status == http.StatusOK {
+		body := map[string]any{}
+		if err := json.Unmarshal(data, &body); err == nil {
+			rewrite(body)
+			if rewritten, err := json.Marshal(body); err == nil {
+				data = rewritten
+				w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+			}
+		}
+	}
+
+	w.WriteHeader(recorder.status)
+	_, _ = w.Write(data)
+}
@@ -0,0 +1,64 @@
+package oidc
+
+import (
+	"context"
+
+	auth "github.com/formancehq/auth/pkg"
+)
+
+const (
+	// GroupsClaim lists the names of the groups of the user in the ID and access tokens
+	GroupsClaim = "groups"
+	// RolesClaim lists the names of the roles of the user in the ID and access tokens
+	RolesClaim = "roles"
+)
+
+// userRoles returns the groups of a user and the roles they grant, restricted is false if no role is defined
+func (s *storageFacade) userRoles(ctx context.Context, userID string) (groups []auth.Group, roles []auth.Role, restricted bool, err error) {
+	roles, err = s.ListRoles(ctx)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	groups, err = s.ListUserGroups(ctx, userID)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	return groups, auth.RolesOfGroups(groups, roles), len(roles) > 0, nil
+}
+
+// restrictToRoles returns the scopes granted to a user by its roles, all the scopes if no role is defined
+func (s *storageFacade) restrictToRoles(ctx context.Context, userID string, scopes []string) ([]string, error) {
+	_, roles, restricted, err := s.userRoles(ctx, userID)
+	if err != nil || !restricted {
+		return scopes, err
+	}
+	roleScopes := make([]string, 0)
+	for _, role := range roles {
+		roleScopes = append(roleScopes, role.Scopes...)
+	}
+	roleScopes, err = s.expandScopes(ctx, roleScopes)
+	if err != nil {
+		return nil, err
+	}
+	return auth.RestrictScopesToRoles(scopes, roleScopes), nil
+}
+
+// roleClaims returns the names of the groups and roles of a user, asserted in its tokens
+func (s *storageFacade) roleClaims(ctx context.Context, userID string) (map[string]any, error) {
+	groups, roles, _, err := s.userRoles(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	groupNames := make([]string, 0, len(groups))
+	for _, group := range groups {
+		groupNames = append(groupNames, group.Name)
+	}
+	roleNames := make([]string, 0, len(roles))
+	for _, role := range roles {
+		roleNames = append(roleNames, role.Name)
+	}
+	return map[string]any{
+		GroupsClaim: groupNames,
+		RolesClaim:  roleNames,
+	}, nil
+}
@@ -6,20 +6,20 @@ import (
 	"github.com/go-chi/chi/v5"
 	"go.opentelemetry.io/otel/trace"
 
-	"github.com/zitadel/oidc/v2/pkg/client/rp"
+	"github.com/formancehq/auth/pkg/delegatedauth"
 	"github.com/zitadel/oidc/v2/pkg/op"
 )
 
 const AuthorizeCallbackPath = "/authorize/callback"
 
-func AddRoutes(r chi.Router, provider op.OpenIDProvider, storage Storage, relyingParty rp.RelyingParty) {
+func AddRoutes(r chi.Router, provider op.OpenIDProvider, storage Storage, providers *delegatedauth.Providers) {
 	r.Group(func(r chi.Router) {
-		if relyingParty != nil {
+		if providers != nil {
 			r.Use(func(h http.Handler) http.Handler {
 				return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 					if r.URL.Path == AuthorizeCallbackPath {
 						if code := r.URL.Query().Get("code"); code != "" {
-							authorizeCallbackHandler(provider, storage, relyingParty).ServeHTTP(w, r)
+							authorizeCallbackHandler(provider, storage, providers).ServeHTTP(w, r)
 							return
 						} else if err := r.URL.Query().Get("error"); err != "" {
 							authorizeErrorHandler().ServeHTTP(w, r)
@@ -29,8 +29,17 @@ func AddRoutes(r chi.Router, provider op.OpenIDProvider, storage Storage, relyin
 					h.ServeHTTP(w, r)
 				})
 			})
+			r.Get(LoginPath, loginHandler(storage, providers))
+			r.Get(DeviceVerificationPath, deviceVerificationHandler(provider))
+			r.Post(DeviceVerificationPath, deviceVerificationHandler(provider))
 		}
 
+		// The library does not serve the pushed authorization requests,
+		// the issuer is set in the context as for the endpoints of the library
+		r.Post(PushedAuthorizationRequestEndpoint.Relative(),
+			op.NewIssuerInterceptor(provider.IssuerFromRequest).HandlerFunc(
+				preventClientAssertionReplay(provider, pushedAuthorizationRequest(provider))))
+
 		// Sub router is a gorilla/mux router, we need to override the span name
 		// Otherwise it would be "/*" for every path
 		r.
@@ -0,0 +1,60 @@
+package oidc
+
+import (
+	"context"
+
+	auth "github.com/formancehq/auth/pkg"
+	"github.com/zitadel/oidc/v2/pkg/op"
+)
+
+type ScopeStorage interface {
+	// ListScopes returns the registered scopes
+	ListScopes(ctx context.Context) ([]auth.Scope, error)
+}
+
+// supportedScopes returns the OpenID Connect scopes and the registered ones, published in the discovery document
+func supportedScopes(ctx context.Context, provider op.OpenIDProvider) ([]string, error) {
+	ret := append([]string{}, auth.OpenIDScopes...)
+	storage, ok := provider.Storage().(ScopeStorage)
+	if !ok {
+		return ret, nil
+	}
+	scopes, err := storage.ListScopes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, scope := range scopes {
+		ret = append(ret, scope.Name)
+	}
+	return ret, nil
+}
+
+// expandScopes replaces the patterns of the scopes by the registered scopes they match and adds the implied scopes
+func (s *storageFacade) expandScopes(ctx context.Context, scopes []string) ([]string, error) {
+	registered, err := s.ListScopes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return auth.ExpandScopes(scopes, registered), nil
+}
+
+// newClientFacade returns the facade of a client, allowing the concrete scopes granted by the scopes of the client
+func (s *storageFacade) newClientFacade(ctx context.Context, client Client) (*clientFacade, error) {
+	scopes, err := s.expandScopes(ctx, client.GetScopes())
+	if err != nil {
+		return nil, err
+	}
+	facade := NewClientFacade(client, s.lifetimes)
+	facade.scopes = scopes
+	return facade, nil
+}
+
+// expandRequestScopes adds the scopes implied by the scopes of an auth request, they are granted with them
+func (s *storageFacade) expandRequestScopes(ctx context.Context, request *auth.AuthRequest) error {
+	scopes, err := s.expandScopes(ctx, request.Scopes)
+	if err != nil {
+		return err
+	}
+	request.Scopes = scopes
+	return nil
+}
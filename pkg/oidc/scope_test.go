@@ -0,0 +1,61 @@
+package oidc_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	auth "github.com/formancehq/auth/pkg"
+	"github.com/formancehq/auth/pkg/storage/sqlstorage"
+	"github.com/oauth2-proxy/mockoidc"
+	"github.com/stretchr/testify/require"
+	zoidc "github.com/zitadel/oidc/v2/pkg/oidc"
+	"github.com/zitadel/oidc/v2/pkg/op"
+)
+
+func TestScopesDiscovery(t *testing.T) {
+	withServer(t, func(m *mockoidc.MockOIDC, storage *sqlstorage.Storage, issuer string, provider op.OpenIDProvider) {
+		require.NoError(t, storage.SaveScope(context.TODO(), auth.NewScope(auth.ScopeOptions{
+			Name:    "reports:read",
+			Service: "reports",
+		})))
+
+		rsp, err := http.Get(issuer + zoidc.DiscoveryEndpoint)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, rsp.StatusCode)
+
+		discovery := struct {
+			ScopesSupported []string `json:"scopes_supported"`
+		}{}
+		require.NoError(t, json.NewDecoder(rsp.Body).Decode(&discovery))
+		require.Contains(t, discovery.ScopesSupported, zoidc.ScopeOpenID)
+		require.Contains(t, discovery.ScopesSupported, "ledger:read")
+		require.Contains(t, discovery.ScopesSupported, "reports:read")
+	})
+}
+
+func TestScopePatterns(t *testing.T) {
+	withServer(t, func(m *mockoidc.MockOIDC, storage *sqlstorage.Storage, issuer string, provider op.OpenIDProvider) {
+		client := auth.NewClient(auth.ClientOptions{
+			Scopes: []string{"ledger:*"},
+		})
+		_, clear := client.GenerateNewSecret(auth.SecretCreate{})
+		require.NoError(t, storage.SaveClient(context.TODO(), client))
+
+		status, rsp := postForm(t, issuer+op.DefaultEndpoints.Token.Relative(), url.Values{
+			"grant_type":    []string{string(zoidc.GrantTypeClientCredentials)},
+			"client_id":     []string{client.Id},
+			"client_secret": []string{clear},
+			"scope":         []string{"ledger:write payments:read"},
+		})
+		require.Equal(t, http.StatusOK, status)
+
+		// ledger:write is granted by the pattern and implies ledger:read
+		claims := &zoidc.AccessTokenClaims{}
+		_, err := zoidc.ParseToken(rsp["access_token"].(string), claims)
+		require.NoError(t, err)
+		require.Equal(t, zoidc.SpaceDelimitedArray{"ledger:write", "ledger:read"}, claims.Scopes)
+	})
+}
@@ -4,14 +4,19 @@ import (
 	"context"
 	"crypto/rsa"
 	"fmt"
+	"net/http"
 	"strings"
 	"time"
 
+	"github.com/ThreeDotsLabs/watermill/message"
 	auth "github.com/formancehq/auth/pkg"
+	"github.com/formancehq/auth/pkg/delegatedauth"
+	"github.com/formancehq/auth/pkg/events"
 	"github.com/formancehq/auth/pkg/storage"
+	"github.com/formancehq/go-libs/v3/collectionutils"
+	"github.com/formancehq/go-libs/v3/logging"
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
-	"github.com/zitadel/oidc/v2/pkg/client/rp"
 	"github.com/zitadel/oidc/v2/pkg/oidc"
 	"github.com/zitadel/oidc/v2/pkg/op"
 	"golang.org/x/text/language"
@@ -21,19 +26,104 @@ import (
 const (
 	ExpirationToken2Legged = time.Hour
 	ExpirationToken3Legged = 5 * time.Minute
+	ExpirationIDToken      = time.Hour
+	ExpirationRefreshToken = 5 * time.Hour
 )
 
+// TokenLifetimes holds the lifetimes of the issued tokens
+type TokenLifetimes struct {
+	// AccessToken2Legged is the lifetime of access tokens issued without user (client credentials, ...)
+	AccessToken2Legged time.Duration
+	// AccessToken3Legged is the lifetime of access tokens issued for a user
+	AccessToken3Legged time.Duration
+	IDToken            time.Duration
+	// RefreshToken is the absolute lifetime of a refresh token, renewals cannot extend it
+	RefreshToken time.Duration
+	// RefreshTokenIdle is the delay after which an unused refresh token expires, zero disables it
+	RefreshTokenIdle time.Duration
+}
+
+// ForClient overrides the lifetimes with the ones configured on the client
+func (l TokenLifetimes) ForClient(client Client) TokenLifetimes {
+	if lifetime := client.GetAccessTokenLifetime(); lifetime > 0 {
+		l.AccessToken2Legged = lifetime
+		l.AccessToken3Legged = lifetime
+	}
+	if lifetime := client.GetIDTokenLifetime(); lifetime > 0 {
+		l.IDToken = lifetime
+	}
+	if lifetime := client.GetRefreshTokenLifetime(); lifetime > 0 {
+		l.RefreshToken = lifetime
+	}
+	if lifetime := client.GetRefreshTokenIdleLifetime(); lifetime > 0 {
+		l.RefreshTokenIdle = lifetime
+	}
+	return l
+}
+
+// Validate checks the lifetimes are positive, the tokens would be expired when issued otherwise
+func (l TokenLifetimes) Validate() error {
+	for _, lifetime := range []struct {
+		name  string
+		value time.Duration
+	}{
+		{"access token", l.AccessToken3Legged},
+		{"client credentials access token", l.AccessToken2Legged},
+		{"id token", l.IDToken},
+		{"refresh token", l.RefreshToken},
+	} {
+		if lifetime.value <= 0 {
+			return fmt.Errorf("invalid %s lifetime %s, it must be positive", lifetime.name, lifetime.value)
+		}
+	}
+	if l.RefreshTokenIdle < 0 {
+		return fmt.Errorf("invalid refresh token idle lifetime %s, it must be positive or zero to disable it", l.RefreshTokenIdle)
+	}
+	return nil
+}
+
+// SignedTokensLifetime returns the longest lifetime of the tokens signed with the signing keys, the access and id tokens
+func (l TokenLifetimes) SignedTokensLifetime() time.Duration {
+	return max(l.AccessToken2Legged, l.AccessToken3Legged, l.IDToken)
+}
+
+// refreshTokenExpiration returns the expiration of a refresh token, bounded by its absolute expiration
+func (l TokenLifetimes) refreshTokenExpiration(now, absoluteExpiration time.Time) time.Time {
+	if l.RefreshTokenIdle > 0 && now.Add(l.RefreshTokenIdle).Before(absoluteExpiration) {
+		return now.Add(l.RefreshTokenIdle)
+	}
+	return absoluteExpiration
+}
+
+var DefaultTokenLifetimes = TokenLifetimes{
+	AccessToken2Legged: ExpirationToken2Legged,
+	AccessToken3Legged: ExpirationToken3Legged,
+	IDToken:            ExpirationIDToken,
+	RefreshToken:       ExpirationRefreshToken,
+}
+
 type Storage interface {
 	SaveAuthRequest(ctx context.Context, request *auth.AuthRequest) error
 	FindAuthRequest(ctx context.Context, id string) (*auth.AuthRequest, error)
 	FindAuthRequestByCode(ctx context.Context, id string) (*auth.AuthRequest, error)
 	UpdateAuthRequest(ctx context.Context, request *auth.AuthRequest) error
 	UpdateAuthRequestCode(ctx context.Context, id string, code string) error
+	UseAuthRequestURI(ctx context.Context, id string, now time.Time) (bool, error)
 	DeleteAuthRequest(ctx context.Context, id string) error
 
+	UseJWTID(ctx context.Context, id string, expiration time.Time, now time.Time) (bool, error)
+
+	SaveDeviceAuthorization(ctx context.Context, authorization *auth.DeviceAuthorization) error
+	FindDeviceAuthorization(ctx context.Context, deviceCode string) (*auth.DeviceAuthorization, error)
+	FindDeviceAuthorizationByUserCode(ctx context.Context, userCode string) (*auth.DeviceAuthorization, error)
+	UpdateDeviceAuthorization(ctx context.Context, authorization *auth.DeviceAuthorization) error
+	DeleteDeviceAuthorization(ctx context.Context, id string) (bool, error)
+
 	SaveRefreshToken(ctx context.Context, token *auth.RefreshToken) error
 	FindRefreshToken(ctx context.Context, token string) (*auth.RefreshToken, error)
 	DeleteRefreshToken(ctx context.Context, token string) error
+	RotateRefreshToken(ctx context.Context, token string, rotatedAt time.Time) (bool, error)
+	RevokeRefreshTokenFamily(ctx context.Context, familyID string) error
 
 	SaveAccessToken(ctx context.Context, token *auth.AccessToken) error
 	FindAccessToken(ctx context.Context, token string) (*auth.AccessToken, error)
@@ -42,48 +132,18 @@ type Storage interface {
 	DeleteAccessTokensByRefreshToken(ctx context.Context, token string) error
 
 	FindUser(ctx context.Context, id string) (*auth.User, error)
-	FindUserBySubject(ctx context.Context, subject string) (*auth.User, error)
+	FindUserBySubject(ctx context.Context, provider, subject string) (*auth.User, error)
 	SaveUser(ctx context.Context, user *auth.User) error
+	UpdateUser(ctx context.Context, user *auth.User) error
+	ListUserGroups(ctx context.Context, userID string) ([]auth.Group, error)
+	SyncUserGroups(ctx context.Context, userID string, groupNames []string) error
+	ListRoles(ctx context.Context) ([]auth.Role, error)
+	ListIdentityProviders(ctx context.Context) ([]auth.IdentityProvider, error)
 
 	FindClient(ctx context.Context, id string) (*auth.Client, error)
-}
-
-type signingKey struct {
-	id        string
-	algorithm jose.SignatureAlgorithm
-	key       *rsa.PrivateKey
-}
-
-func (s *signingKey) SignatureAlgorithm() jose.SignatureAlgorithm {
-	return s.algorithm
-}
-
-func (s *signingKey) Key() interface{} {
-	return s.key
-}
-
-func (s *signingKey) ID() string {
-	return s.id
-}
-
-type publicKey struct {
-	signingKey
-}
-
-func (s *publicKey) ID() string {
-	return s.id
-}
-
-func (s *publicKey) Algorithm() jose.SignatureAlgorithm {
-	return s.algorithm
-}
-
-func (s *publicKey) Use() string {
-	return "sig"
-}
-
-func (s *publicKey) Key() interface{} {
-	return &s.key.PublicKey
+	FindResourceServerByIdentifier(ctx context.Context, identifier string) (*auth.ResourceServer, error)
+	ListScopes(ctx context.Context) ([]auth.Scope, error)
+	UpdateClientSecret(ctx context.Context, clientID, secretID string, update func(secret *auth.ClientSecret)) error
 }
 
 type Service struct {
@@ -108,9 +168,15 @@ func getInfoFromRequest(req op.TokenRequest) (clientID string, authTime time.Tim
 // We need to refine this forked version to make these methods optional
 type storageFacade struct {
 	Storage
-	signingKey    signingKey
-	relyingParty  rp.RelyingParty
+	// providers are the identity providers the users of the jwt bearer and token exchange grants are looked up with
+	providers     *delegatedauth.Providers
+	keyRing       *KeyRing
+	lifetimes     TokenLifetimes
+	publisher     message.Publisher
 	staticClients []auth.StaticClient
+	jwks          *jwksCache
+	mtls          MTLSConfig
+	policy        ScopePolicy
 }
 
 func (s *storageFacade) GetRefreshTokenInfo(ctx context.Context, clientID string, token string) (userID string, tokenID string, err error) {
@@ -123,15 +189,15 @@ func (s *storageFacade) GetRefreshTokenInfo(ctx context.Context, clientID string
 }
 
 func (s *storageFacade) SigningKey(ctx context.Context) (op.SigningKey, error) {
-	return &s.signingKey, nil
+	return s.keyRing.SigningKey(), nil
 }
 
 func (s *storageFacade) SignatureAlgorithms(ctx context.Context) ([]jose.SignatureAlgorithm, error) {
-	return []jose.SignatureAlgorithm{s.signingKey.algorithm}, nil
+	return s.keyRing.SignatureAlgorithms(), nil
 }
 
 func (s *storageFacade) KeySet(ctx context.Context) ([]op.Key, error) {
-	return []op.Key{&publicKey{s.signingKey}}, nil
+	return s.keyRing.PublicKeys(), nil
 }
 
 func (s *storageFacade) SetUserinfoFromScopes(ctx context.Context, userinfo *oidc.UserInfo, userID, clientID string, scopes []string) error {
@@ -146,62 +212,219 @@ func (s *storageFacade) SetUserinfoFromToken(ctx context.Context, userinfo *oidc
 	return s.setUserinfo(ctx, userinfo, token.UserID, token.Scopes)
 }
 
+// SetIntrospectionFromToken implements the op.Storage interface
+// it is only used by the introspection endpoint of the library, replaced by introspectionHandler
 func (s *storageFacade) SetIntrospectionFromToken(ctx context.Context, introspection *oidc.IntrospectionResponse, tokenID, subject, clientID string) error {
-	token, err := s.FindAccessToken(ctx, tokenID)
+	active, err := s.IntrospectAccessToken(ctx, introspection, tokenID, clientID)
 	if err != nil {
 		return err
 	}
-	ok := false
-	for _, aud := range token.Audience {
-		if aud == clientID {
-			ok = true
-			break
-		}
+	if !active {
+		return fmt.Errorf("token is not active")
 	}
-	if !ok {
-		client, err := s.findClient(ctx, clientID)
-		if err != nil {
-			return err
-		}
-		ok = client.IsTrusted()
+	return nil
+}
+
+// canIntrospect implements the introspection policy.
+// A client can introspect the tokens issued to it and the tokens it is an audience of.
+// Trusted clients, used by the resource servers, can introspect any token.
+func (s *storageFacade) canIntrospect(ctx context.Context, clientID, applicationID string, audience []string) (bool, error) {
+	if clientID == applicationID || collectionutils.Contains(audience, clientID) {
+		return true, nil
 	}
-	if !ok {
-		return fmt.Errorf("token is not valid for this client")
+	client, err := s.findClient(ctx, clientID)
+	if err != nil {
+		return false, err
+	}
+	return client.IsTrusted(), nil
+}
+
+// setIntrospection fills the fields shared by access and refresh tokens.
+// Tokens obtained with client credentials have no user, their subject is the client.
+func (s *storageFacade) setIntrospection(ctx context.Context, introspection *oidc.IntrospectionResponse,
+	applicationID, userID string, audience, scopes []string, createdAt, expiration time.Time) error {
+	introspection.Active = true
+	introspection.Scope = scopes
+	introspection.ClientID = applicationID
+	introspection.Expiration = oidc.FromTime(expiration)
+	if !createdAt.IsZero() {
+		introspection.IssuedAt = oidc.FromTime(createdAt)
+		introspection.NotBefore = oidc.FromTime(createdAt)
+	}
+	introspection.Audience = audience
+	introspection.Issuer = op.IssuerFromContext(ctx)
+
+	if userID == "" {
+		introspection.Subject = applicationID
+		return nil
+	}
+
+	userInfo := &oidc.UserInfo{}
+	if err := s.setUserinfo(ctx, userInfo, userID, scopes); err != nil {
+		return errors.Wrapf(err, "retrieving user: %s", userID)
 	}
+	introspection.SetUserInfo(userInfo)
+	introspection.Subject = userID
 
-	user, err := s.FindUser(ctx, token.UserID)
+	return nil
+}
+
+// IntrospectAccessToken implements the IntrospectionStorage interface
+func (s *storageFacade) IntrospectAccessToken(ctx context.Context, introspection *oidc.IntrospectionResponse, tokenID, clientID string) (bool, error) {
+	token, err := s.FindAccessToken(ctx, tokenID)
 	if err != nil {
-		return errors.Wrapf(err, "retrieving user: %s", token.UserID)
+		return false, storage.IgnoreNotFoundError(err)
+	}
+	if !time.Now().Before(token.Expiration) {
+		return false, nil
 	}
 
-	for _, scope := range token.Scopes {
-		switch scope {
-		case oidc.ScopeOpenID:
-			introspection.Subject = token.UserID
-		case oidc.ScopeEmail:
-			introspection.Email = user.Email
-			introspection.EmailVerified = true // TODO: Get the information
-		case oidc.ScopeProfile:
-			// TODO: Support that
-		case oidc.ScopePhone:
-			// TODO: Support that ?
+	allowed, err := s.canIntrospect(ctx, clientID, token.ApplicationID, token.Audience)
+	if err != nil || !allowed {
+		return false, err
+	}
+
+	if err := s.setIntrospection(ctx, introspection, token.ApplicationID, token.UserID,
+		token.Audience, token.Scopes, token.CreatedAt, token.Expiration); err != nil {
+		return false, err
+	}
+	introspection.TokenType = oidc.BearerToken
+	introspection.JWTID = token.ID
+	if token.DPoPKeyThumbprint != "" {
+		introspection.TokenType = DPoPTokenType
+	}
+	setIntrospectionConfirmation(introspection, token.CertificateThumbprint, token.DPoPKeyThumbprint)
+	if token.Actor != nil {
+		if introspection.Claims == nil {
+			introspection.Claims = map[string]any{}
 		}
+		introspection.Claims[ActorClaim] = token.Actor
 	}
 
-	introspection.Scope = oidc.SpaceDelimitedArray(token.Scopes)
-	introspection.ClientID = token.ApplicationID
-	introspection.Active = time.Now().After(token.Expiration)
-	return nil
+	return true, nil
+}
+
+// IntrospectRefreshToken implements the IntrospectionStorage interface
+func (s *storageFacade) IntrospectRefreshToken(ctx context.Context, introspection *oidc.IntrospectionResponse, tokenID, clientID string) (bool, error) {
+	token, err := s.FindRefreshToken(ctx, tokenID)
+	if err != nil {
+		return false, storage.IgnoreNotFoundError(err)
+	}
+	if token.IsRotated() || !time.Now().Before(token.Expiration) {
+		return false, nil
+	}
+
+	allowed, err := s.canIntrospect(ctx, clientID, token.ApplicationID, token.Audience)
+	if err != nil || !allowed {
+		return false, err
+	}
+
+	if err := s.setIntrospection(ctx, introspection, token.ApplicationID, token.UserID,
+		token.Audience, token.Scopes, token.CreatedAt, token.Expiration); err != nil {
+		return false, err
+	}
+	introspection.TokenType = TokenTypeHintRefreshToken
+	setIntrospectionConfirmation(introspection, "", token.DPoPKeyThumbprint)
+
+	return true, nil
 }
 
+// setIntrospectionConfirmation adds the cnf claim of a token bound to a certificate or a DPoP key
+func setIntrospectionConfirmation(introspection *oidc.IntrospectionResponse, certificateThumbprint, dpopKeyThumbprint string) {
+	cnf := confirmation(certificateThumbprint, dpopKeyThumbprint)
+	if cnf == nil {
+		return
+	}
+	if introspection.Claims == nil {
+		introspection.Claims = map[string]any{}
+	}
+	introspection.Claims[ConfirmationClaim] = cnf
+}
+
+// GetKeyByIDAndClientID implements the op.Storage interface
+// it will be called to verify the signature of the client assertions (private_key_jwt)
 func (s *storageFacade) GetKeyByIDAndClientID(ctx context.Context, keyID, clientID string) (*jose.JSONWebKey, error) {
-	panic("not implemented")
+	client, err := s.findClient(ctx, clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case client.GetJWKS() != nil:
+		key, found := findKey(client.GetJWKS().Keys, keyID)
+		if !found {
+			return nil, fmt.Errorf("key '%s' not found", keyID)
+		}
+		return key, nil
+	case client.GetJWKSURI() != "":
+		return s.jwks.GetKey(ctx, client.GetJWKSURI(), keyID)
+	default:
+		return nil, errors.New("no key registered for the client")
+	}
 }
 
 // CreateAuthRequest implements the op.Storage interface
 // it will be called after parsing and validation of the authentication request
 func (s *storageFacade) CreateAuthRequest(ctx context.Context, authReq *oidc.AuthRequest, userID string) (op.AuthRequest, error) {
-	request := auth.AuthRequest{
+	request := newAuthRequest(authReq)
+	request.IdentityProviderHint = IdentityProviderHintFromContext(ctx)
+	if err := s.expandRequestScopes(ctx, request); err != nil {
+		return nil, err
+	}
+	if err := s.restrictToResources(ctx, request, ResourcesFromContext(ctx)); err != nil {
+		return nil, err
+	}
+	if err := s.SaveAuthRequest(ctx, request); err != nil {
+		return nil, err
+	}
+
+	return request, nil
+}
+
+// StorePushedAuthRequest implements the PushedAuthRequestStorage interface
+// it will be called by the par endpoint after the validation of the request
+func (s *storageFacade) StorePushedAuthRequest(ctx context.Context, authReq *oidc.AuthRequest, expiration time.Time) (op.AuthRequest, error) {
+	request := newAuthRequest(authReq)
+	request.RequestURIExpiration = &expiration
+	request.IdentityProviderHint = IdentityProviderHintFromContext(ctx)
+	if err := s.expandRequestScopes(ctx, request); err != nil {
+		return nil, err
+	}
+	if err := s.restrictToResources(ctx, request, ResourcesFromContext(ctx)); err != nil {
+		return nil, err
+	}
+	if err := s.SaveAuthRequest(ctx, request); err != nil {
+		return nil, err
+	}
+
+	return request, nil
+}
+
+// UsePushedAuthRequest implements the PushedAuthRequestStorage interface
+// it will be called when a client starts an authorization with a request_uri, which can be used only once
+func (s *storageFacade) UsePushedAuthRequest(ctx context.Context, clientID, id string) (op.AuthRequest, error) {
+	request, err := s.FindAuthRequest(ctx, id)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, oidc.ErrInvalidRequest().WithDescription("invalid request_uri")
+		}
+		return nil, err
+	}
+	if request.ApplicationID != clientID {
+		return nil, oidc.ErrInvalidRequest().WithDescription("invalid request_uri")
+	}
+	used, err := s.UseAuthRequestURI(ctx, id, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if !used {
+		return nil, oidc.ErrInvalidRequest().WithDescription("request_uri has expired or has already been used")
+	}
+	return request, nil
+}
+
+func newAuthRequest(authReq *oidc.AuthRequest) *auth.AuthRequest {
+	return &auth.AuthRequest{
 		CreatedAt:     time.Now(),
 		ApplicationID: authReq.ClientID,
 		CallbackURI:   authReq.RedirectURI,
@@ -219,18 +442,29 @@ func (s *storageFacade) CreateAuthRequest(ctx context.Context, authReq *oidc.Aut
 		},
 		ID: uuid.NewString(),
 	}
-
-	if err := s.SaveAuthRequest(ctx, &request); err != nil {
-		return nil, err
-	}
-
-	return &request, nil
 }
 
 // AuthRequestByCode implements the op.Storage interface
-// it will be called after parsing and validation of the token request (in an authorization code flow)
+// it will be called after parsing and validation of the token request (in an authorization code flow),
+// the token request can restrict the tokens to some of the resources of the authorization request,
+// the scopes are restricted to the ones granted by the roles of the user
 func (s *storageFacade) AuthRequestByCode(ctx context.Context, code string) (op.AuthRequest, error) {
-	return s.FindAuthRequestByCode(ctx, code)
+	request, err := s.FindAuthRequestByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	resources := ResourcesFromContext(ctx)
+	if err := checkGrantedResources(request.Resources, resources); err != nil {
+		return nil, err
+	}
+	if err := s.restrictToResources(ctx, request, resources); err != nil {
+		return nil, err
+	}
+	request.Scopes, err = s.restrictToRoles(ctx, request.UserID, request.Scopes)
+	if err != nil {
+		return nil, err
+	}
+	return request, nil
 }
 
 // SaveAuthCode implements the op.Storage interface
@@ -243,13 +477,19 @@ func (s *storageFacade) SaveAuthCode(ctx context.Context, id string, code string
 // CreateAccessToken implements the op.Storage interface
 // it will be called for all requests able to return an access token (Authorization Code Flow, Implicit Flow, JWT Profile, ...)
 func (s *storageFacade) CreateAccessToken(ctx context.Context, request op.TokenRequest) (string, time.Time, error) {
-	var applicationID string
+	var (
+		applicationID string
+		actor         *auth.Actor
+	)
 	//if authenticated for an app (auth code / implicit flow) we must save the client_id to the token
-	authReq, ok := request.(*auth.AuthRequest)
-	if ok {
-		applicationID = authReq.ApplicationID
+	switch req := request.(type) {
+	case *auth.AuthRequest:
+		applicationID = req.ApplicationID
+	case *auth.TokenExchangeRequest:
+		applicationID = req.ApplicationID
+		actor = req.Actor
 	}
-	token, err := s.saveAccessToken(ctx, nil, applicationID, request.GetSubject(), request.GetAudience(), request.GetScopes())
+	token, err := s.saveAccessToken(ctx, nil, applicationID, request.GetSubject(), request.GetAudience(), request.GetScopes(), actor)
 	if err != nil {
 		return "", time.Time{}, err
 	}
@@ -269,7 +509,7 @@ func (s *storageFacade) CreateAccessAndRefreshTokens(ctx context.Context, reques
 			return "", "", time.Time{}, err
 		}
 		accessToken, err := s.saveAccessToken(ctx, refreshToken, applicationID, request.GetSubject(),
-			request.GetAudience(), request.GetScopes())
+			request.GetAudience(), request.GetScopes(), nil)
 		if err != nil {
 			return "", "", time.Time{}, err
 		}
@@ -282,7 +522,7 @@ func (s *storageFacade) CreateAccessAndRefreshTokens(ctx context.Context, reques
 	if err != nil {
 		return "", "", time.Time{}, err
 	}
-	accessToken, err := s.saveAccessToken(ctx, refreshToken, applicationID, request.GetSubject(), request.GetAudience(), request.GetScopes())
+	accessToken, err := s.saveAccessToken(ctx, refreshToken, applicationID, request.GetSubject(), request.GetAudience(), request.GetScopes(), nil)
 	if err != nil {
 		return "", "", time.Time{}, err
 	}
@@ -290,12 +530,45 @@ func (s *storageFacade) CreateAccessAndRefreshTokens(ctx context.Context, reques
 }
 
 // TokenRequestByRefreshToken implements the op.Storage interface
-// it will be called after parsing and validation of the refresh token request
+// it will be called after parsing and validation of the refresh token request,
+// the scopes are restricted again to the ones granted by the roles of the user, which may have changed
 func (s *storageFacade) TokenRequestByRefreshToken(ctx context.Context, refreshToken string) (op.RefreshTokenRequest, error) {
 	token, err := s.FindRefreshToken(ctx, refreshToken)
 	if err != nil {
 		return nil, fmt.Errorf("invalid refresh_token")
 	}
+	if token.IsRotated() {
+		return nil, s.refreshTokenReused(ctx, token)
+	}
+	if time.Now().After(token.Expiration) {
+		return nil, fmt.Errorf("expired refresh_token")
+	}
+	if token.DPoPKeyThumbprint != "" && token.DPoPKeyThumbprint != DPoPKeyThumbprintFromContext(ctx) {
+		return nil, errInvalidDPoPProof().WithDescription("refresh_token is bound to another DPoP key")
+	}
+	client, err := s.findClient(ctx, token.ApplicationID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := dpopKeyThumbprint(ctx, client); err != nil {
+		return nil, err
+	}
+	// the access token can be restricted to some of the resources of the grant, the renewed refresh token keeps all of them
+	if resources := ResourcesFromContext(ctx); len(resources) > 0 {
+		if err := checkGrantedResources(token.Audience, resources); err != nil {
+			return nil, err
+		}
+		resourceServers, err := s.resourceServers(ctx, resources)
+		if err != nil {
+			return nil, err
+		}
+		token.Audience = append(resources, token.ApplicationID)
+		token.Scopes = auth.NarrowScopesToResources(token.Scopes, resourceServers)
+	}
+	token.Scopes, err = s.restrictToRoles(ctx, token.UserID, token.Scopes)
+	if err != nil {
+		return nil, err
+	}
 	return auth.NewRefreshTokenRequest(*token), nil
 }
 
@@ -331,10 +604,8 @@ func (s *storageFacade) RevokeToken(ctx context.Context, tokenStr string, userID
 		if refreshToken.ApplicationID != clientID {
 			return oidc.ErrInvalidClient().WithDescription("token was not issued for this client")
 		}
-		if err := s.DeleteRefreshToken(ctx, tokenStr); err != nil {
-			return oidc.ErrServerError().WithDescription("%s", err)
-		}
-		if err := s.DeleteAccessTokensByRefreshToken(ctx, tokenStr); err != nil {
+		//revokes the whole grant, including the previously rotated tokens
+		if err := s.RevokeRefreshTokenFamily(ctx, refreshToken.FamilyID); err != nil {
 			return oidc.ErrServerError().WithDescription("%s", err)
 		}
 		return nil
@@ -367,7 +638,7 @@ func (s *storageFacade) GetClientByClientID(ctx context.Context, clientID string
 		return nil, err
 	}
 
-	return NewClientFacade(client, s.relyingParty), nil
+	return s.newClientFacade(ctx, client)
 }
 
 // AuthorizeClientIDSecret implements the op.Storage interface
@@ -377,21 +648,120 @@ func (s *storageFacade) AuthorizeClientIDSecret(ctx context.Context, clientID, c
 	if err != nil {
 		return err
 	}
-	return client.ValidateSecret(clientSecret)
+	return s.authenticateClient(ctx, client, clientSecret)
+}
+
+// authenticateClient authenticates a client with its certificate if it uses mutual TLS, with its secret otherwise
+func (s *storageFacade) authenticateClient(ctx context.Context, client Client, clientSecret string) error {
+	if client.GetTLSClientAuth() != nil {
+		return s.validateClientCertificate(ctx, client)
+	}
+	return s.validateClientSecret(ctx, client, clientSecret)
+}
+
+// validateClientSecret validates the secret and, on success, records its use and upgrades its hash if needed.
+// A failure to save the secret does not fail the authentication, it will be retried on the next use.
+func (s *storageFacade) validateClientSecret(ctx context.Context, client Client, clientSecret string) error {
+	if client.UsesPrivateKeyJWT() {
+		return errors.New("client must authenticate with a signed assertion")
+	}
+	storedClient, ok := client.(*auth.Client)
+	if !ok {
+		return client.ValidateSecret(clientSecret)
+	}
+	// the secret is looked up once, checking its hash is costly
+	secret := storedClient.FindSecret(clientSecret)
+	if secret == nil {
+		return errors.New("invalid secret")
+	}
+	if !secret.MarkUsed(clientSecret, time.Now()) {
+		return nil
+	}
+	if err := s.UpdateClientSecret(ctx, storedClient.Id, secret.ID, func(stored *auth.ClientSecret) {
+		stored.Hash = secret.Hash
+		stored.LastUsedAt = secret.LastUsedAt
+	}); err != nil {
+		logging.FromContext(ctx).Errorf("unable to update secret %s of client %s: %s", secret.ID, storedClient.Id, err)
+	}
+	return nil
 }
 
 // GetPrivateClaimsFromScopes implements the op.Storage interface
 // it will be called for the creation of a JWT access token to assert claims for custom scopes
+// and to bind the token to the certificate the client authenticated with and/or to its DPoP key
 func (s *storageFacade) GetPrivateClaimsFromScopes(ctx context.Context, userID, clientID string, scopes []string) (claims map[string]interface{}, err error) {
-	return map[string]interface{}{
+	claims = map[string]interface{}{
 		"scope": strings.Join(scopes, " "),
-	}, nil
+	}
+	certificateThumbprint := ""
+	if len(ClientCertificateFromContext(ctx)) > 0 {
+		client, err := s.findClient(ctx, clientID)
+		if err != nil {
+			return nil, err
+		}
+		certificateThumbprint = clientCertificateThumbprint(ctx, client)
+	}
+	if cnf := confirmation(certificateThumbprint, DPoPKeyThumbprintFromContext(ctx)); cnf != nil {
+		claims[ConfirmationClaim] = cnf
+	}
+	if userID != "" {
+		roleClaims, err := s.roleClaims(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range roleClaims {
+			claims[k] = v
+		}
+	}
+	return claims, nil
+}
+
+// findUpstreamUser returns the user authenticated by the identity provider of an issuer,
+// nil if no identity provider has this issuer or if the user is unknown yet
+func (s *storageFacade) findUpstreamUser(ctx context.Context, issuer, subject string) (*auth.User, error) {
+	if s.providers == nil || issuer == "" || subject == "" {
+		return nil, nil
+	}
+	configs, err := s.providers.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, config := range configs {
+		if config.Issuer != issuer {
+			continue
+		}
+		user, err := s.FindUserBySubject(ctx, config.Name, subject)
+		if err != nil {
+			return nil, storage.IgnoreNotFoundError(err)
+		}
+		return user, nil
+	}
+	return nil, nil
 }
 
 // ValidateJWTProfileScopes implements the op.Storage interface
-// it will be called to validate the scopes of a JWT Profile Authorization Grant request
+// it will be called to validate the scopes of a JWT Profile Authorization Grant request,
+// the subject of the assertion being the one of the user at the identity provider of the issuer of the assertion
 func (s *storageFacade) ValidateJWTProfileScopes(ctx context.Context, userID string, scopes []string) ([]string, error) {
-	return scopes, nil
+	claims := UpstreamClaimsFromContext(ctx)
+	issuer, _ := claims["iss"].(string)
+	user, err := s.findUpstreamUser(ctx, issuer, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user != nil && user.Disabled {
+		return nil, oidc.ErrInvalidGrant().WithDescription("user is disabled")
+	}
+	scopes, err = s.grantScopes(ctx, nil, user, claims, scopes)
+	if err != nil {
+		return nil, err
+	}
+	// a user unknown yet has no role
+	grantedUserID := ""
+	if user != nil {
+		grantedUserID = user.ID
+	}
+	return s.restrictToRoles(ctx, grantedUserID, scopes)
 }
 
 // Health implements the op.Storage interface
@@ -399,19 +769,50 @@ func (s *storageFacade) Health(ctx context.Context) error {
 	return nil
 }
 
+// clientLifetimes returns the client a token is issued to and its token lifetimes,
+// or a nil client and the server defaults if the token is not issued for a client
+func (s *storageFacade) clientLifetimes(ctx context.Context, applicationID string) (Client, TokenLifetimes, error) {
+	if applicationID == "" {
+		return nil, s.lifetimes, nil
+	}
+	client, err := s.findClient(ctx, applicationID)
+	if err != nil {
+		return nil, TokenLifetimes{}, err
+	}
+	return client, s.lifetimes.ForClient(client), nil
+}
+
 // createRefreshToken will store a refresh_token in-memory based on the provided information
 func (s *storageFacade) createRefreshToken(ctx context.Context, applicationID string, subject string,
 	audience []string, scopes []string, amr []string, authTime time.Time) (*auth.RefreshToken, error) {
+	client, lifetimes, err := s.clientLifetimes(ctx, applicationID)
+	if err != nil {
+		return nil, err
+	}
+	keyThumbprint, err := dpopKeyThumbprint(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	id := uuid.NewString()
 	token := auth.RefreshToken{
-		ID:            uuid.NewString(),
-		AuthTime:      authTime,
-		AMR:           amr,
-		ApplicationID: applicationID,
-		UserID:        subject,
-		Audience:      audience,
-		Expiration:    time.Now().Add(5 * time.Hour),
-		Scopes:        scopes,
+		ID:                 id,
+		FamilyID:           id,
+		CreatedAt:          now,
+		AuthTime:           authTime,
+		AMR:                amr,
+		ApplicationID:      applicationID,
+		UserID:             subject,
+		Audience:           audience,
+		AbsoluteExpiration: now.Add(lifetimes.RefreshToken),
+		Scopes:             scopes,
+	}
+	// the refresh tokens of confidential clients are already bound to their authentication
+	if client != nil && client.IsPublic() {
+		token.DPoPKeyThumbprint = keyThumbprint
 	}
+	token.Expiration = lifetimes.refreshTokenExpiration(now, token.AbsoluteExpiration)
 	if err := s.SaveRefreshToken(ctx, &token); err != nil {
 		return nil, err
 	}
@@ -424,15 +825,32 @@ func (s *storageFacade) renewRefreshToken(ctx context.Context, currentRefreshTok
 	if err != nil {
 		return nil, err
 	}
-	//deletes the refresh token and all access tokens which were issued based on this refresh token
-	if err := s.DeleteRefreshToken(ctx, currentRefreshToken); err != nil {
+	//marks the refresh token as rotated, it is kept to detect a replay, and deletes all access tokens which were issued based on it
+	rotated, err := s.RotateRefreshToken(ctx, currentRefreshToken, time.Now())
+	if err != nil {
 		return nil, err
 	}
+	if !rotated {
+		// the token has already been renewed, by a concurrent request or a replay
+		return nil, s.refreshTokenReused(ctx, refreshToken)
+	}
 	if err := s.DeleteAccessTokensByRefreshToken(ctx, currentRefreshToken); err != nil {
 		return nil, err
 	}
-	//creates a new refresh token based on the current one
+
+	_, lifetimes, err := s.clientLifetimes(ctx, refreshToken.ApplicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	//creates a new refresh token based on the current one, in the same family
 	refreshToken.ID = uuid.NewString()
+	refreshToken.CreatedAt = time.Now()
+	refreshToken.RotatedAt = nil
+	if refreshToken.AbsoluteExpiration.IsZero() {
+		refreshToken.AbsoluteExpiration = refreshToken.Expiration
+	}
+	refreshToken.Expiration = lifetimes.refreshTokenExpiration(time.Now(), refreshToken.AbsoluteExpiration)
 
 	if err := s.SaveRefreshToken(ctx, refreshToken); err != nil {
 		return nil, err
@@ -441,20 +859,55 @@ func (s *storageFacade) renewRefreshToken(ctx context.Context, currentRefreshTok
 	return refreshToken, nil
 }
 
+// refreshTokenReused is called when a refresh token is presented after having been rotated.
+// As either the legitimate client or an attacker holds a stolen token, the whole family is revoked
+// (see OAuth 2.0 Security Best Current Practice, section 4.14).
+func (s *storageFacade) refreshTokenReused(ctx context.Context, refreshToken *auth.RefreshToken) error {
+	logging.FromContext(ctx).WithFields(map[string]any{
+		"family": refreshToken.FamilyID,
+		"client": refreshToken.ApplicationID,
+		"user":   refreshToken.UserID,
+	}).Errorf("Refresh token reuse detected, revoking the token family")
+
+	if err := s.RevokeRefreshTokenFamily(ctx, refreshToken.FamilyID); err != nil {
+		return err
+	}
+
+	events.Publish(ctx, s.publisher, events.NewEventRefreshTokenReused(events.RefreshTokenReused{
+		FamilyID: refreshToken.FamilyID,
+		TokenID:  refreshToken.ID,
+		ClientID: refreshToken.ApplicationID,
+		UserID:   refreshToken.UserID,
+	}))
+
+	return fmt.Errorf("invalid refresh_token")
+}
+
 // accessToken will store an access_token in-memory based on the provided information
-func (s *storageFacade) saveAccessToken(ctx context.Context, refreshToken *auth.RefreshToken, applicationId, subject string, audience, scopes []string) (*auth.AccessToken, error) {
+func (s *storageFacade) saveAccessToken(ctx context.Context, refreshToken *auth.RefreshToken, applicationId, subject string, audience, scopes []string,
+	actor *auth.Actor) (*auth.AccessToken, error) {
+	client, lifetimes, err := s.clientLifetimes(ctx, applicationId)
+	if err != nil {
+		return nil, err
+	}
+	keyThumbprint, err := dpopKeyThumbprint(ctx, client)
+	if err != nil {
+		return nil, err
+	}
 
-	expiration := ExpirationToken2Legged
+	expiration := lifetimes.AccessToken2Legged
 	if subject != "" {
-		expiration = ExpirationToken3Legged
+		expiration = lifetimes.AccessToken3Legged
 	}
 
+	now := time.Now()
 	token := auth.AccessToken{
 		ID:            uuid.NewString(),
+		CreatedAt:     now,
 		ApplicationID: applicationId,
 		UserID:        subject,
 		Audience:      audience,
-		Expiration:    time.Now().Add(expiration),
+		Expiration:    now.Add(expiration),
 		Scopes:        scopes,
 		RefreshTokenID: func() string {
 			if refreshToken == nil {
@@ -462,6 +915,9 @@ func (s *storageFacade) saveAccessToken(ctx context.Context, refreshToken *auth.
 			}
 			return refreshToken.ID
 		}(),
+		CertificateThumbprint: clientCertificateThumbprint(ctx, client),
+		DPoPKeyThumbprint:     keyThumbprint,
+		Actor:                 actor,
 	}
 	if err := s.SaveAccessToken(ctx, &token); err != nil {
 		return nil, err
@@ -483,13 +939,25 @@ func (s *storageFacade) setUserinfo(ctx context.Context, userInfo *oidc.UserInfo
 			userInfo.Subject = userID
 		case oidc.ScopeEmail:
 			userInfo.Email = user.Email
-			userInfo.EmailVerified = true // TODO: Get the information
+			userInfo.EmailVerified = oidc.Bool(user.EmailVerified)
 		case oidc.ScopeProfile:
-			// TODO: Support that
-		case oidc.ScopePhone:
-			// TODO: Support that ?
+			userInfo.PreferredUsername = user.Email
+			userInfo.Name = user.DisplayName
+			userInfo.GivenName = user.GivenName
+			userInfo.FamilyName = user.FamilyName
+			if user.Locale != "" {
+				userInfo.Locale = oidc.NewLocale(language.Make(user.Locale))
+			}
 		}
 	}
+
+	roleClaims, err := s.roleClaims(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for k, v := range roleClaims {
+		userInfo.AppendClaims(k, v)
+	}
 	return nil
 }
 
@@ -502,7 +970,7 @@ func (s *storageFacade) ClientCredentials(ctx context.Context, clientID, clientS
 	if err != nil {
 		return nil, err
 	}
-	return NewClientFacade(client, s.relyingParty), client.ValidateSecret(clientSecret)
+	return NewClientFacade(client, s.lifetimes), s.authenticateClient(ctx, client, clientSecret)
 }
 
 func (s *storageFacade) ClientCredentialsTokenRequest(ctx context.Context, clientID string, scopes []string) (op.TokenRequest, error) {
@@ -512,40 +980,376 @@ func (s *storageFacade) ClientCredentialsTokenRequest(ctx context.Context, clien
 		return nil, err
 	}
 
-	allowedScopes := auth.Array[string]{}
+	clientScopes, err := s.expandScopes(ctx, client.GetScopes())
+	if err != nil {
+		return nil, err
+	}
+	// the requested scopes can be patterns too, they are granted if they are allowed for the client
+	scopes, err = s.expandScopes(ctx, scopes)
+	if err != nil {
+		return nil, err
+	}
 
-l:
+	allowedScopes := auth.Array[string]{}
 	for _, scope := range scopes {
-		for _, clientScope := range client.GetScopes() {
-			if clientScope == scope {
-				allowedScopes.Append(scope)
-				continue l
-			}
+		if collectionutils.Contains(clientScopes, scope) {
+			allowedScopes.Append(scope)
 		}
 	}
+	allowedScopes, err = s.grantScopes(ctx, client, nil, nil, allowedScopes)
+	if err != nil {
+		return nil, err
+	}
 
-	return &auth.AuthRequest{
+	request := &auth.AuthRequest{
 		ID:            uuid.NewString(),
 		CreatedAt:     time.Now(),
 		ApplicationID: clientID,
 		Scopes:        allowedScopes,
-	}, nil
+	}
+	if err := s.restrictToResources(ctx, request, ResourcesFromContext(ctx)); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// StoreDeviceAuthorization implements the op.DeviceAuthorizationStorage interface
+// it will be called on device authorization requests, the scopes are validated like the ones of an auth request
+func (s *storageFacade) StoreDeviceAuthorization(ctx context.Context, clientID, deviceCode, userCode string, expires time.Time, scopes []string) error {
+	client, err := s.findClient(ctx, clientID)
+	if err != nil {
+		return err
+	}
+	facade, err := s.newClientFacade(ctx, client)
+	if err != nil {
+		return err
+	}
+	scopes, err = op.ValidateAuthReqScopes(facade, scopes)
+	if err != nil {
+		return err
+	}
+	scopes, err = s.expandScopes(ctx, scopes)
+	if err != nil {
+		return err
+	}
+
+	return s.SaveDeviceAuthorization(ctx, &auth.DeviceAuthorization{
+		ID:            uuid.NewString(),
+		CreatedAt:     time.Now(),
+		ApplicationID: clientID,
+		DeviceCode:    deviceCode,
+		UserCode:      auth.NormalizeUserCode(userCode),
+		Scopes:        scopes,
+		Expiration:    expires,
+	})
+}
+
+func deviceAuthorizationState(authorization *auth.DeviceAuthorization) *op.DeviceAuthorizationState {
+	return &op.DeviceAuthorizationState{
+		ClientID: authorization.ApplicationID,
+		Scopes:   authorization.Scopes,
+		Expires:  authorization.Expiration,
+		Done:     authorization.Done(),
+		Subject:  authorization.UserID,
+		Denied:   authorization.Denied,
+	}
+}
+
+// GetDeviceAuthorizatonState implements the op.DeviceAuthorizationStorage interface
+func (s *storageFacade) GetDeviceAuthorizatonState(ctx context.Context, clientID, deviceCode string) (*op.DeviceAuthorizationState, error) {
+	authorization, err := s.FindDeviceAuthorization(ctx, deviceCode)
+	if err != nil {
+		return nil, err
+	}
+	if authorization.ApplicationID != clientID {
+		return nil, storage.ErrNotFound
+	}
+	return deviceAuthorizationState(authorization), nil
+}
+
+// GetDeviceAuthorizationByUserCode implements the op.DeviceAuthorizationStorage interface
+// it will be called by the verification page, the user code is normalized so the user can type it loosely
+func (s *storageFacade) GetDeviceAuthorizationByUserCode(ctx context.Context, userCode string) (*op.DeviceAuthorizationState, error) {
+	authorization, err := s.FindDeviceAuthorizationByUserCode(ctx, auth.NormalizeUserCode(userCode))
+	if err != nil {
+		return nil, err
+	}
+	return deviceAuthorizationState(authorization), nil
+}
+
+func (s *storageFacade) updatePendingDeviceAuthorization(ctx context.Context, userCode string, update func(authorization *auth.DeviceAuthorization)) error {
+	authorization, err := s.FindDeviceAuthorizationByUserCode(ctx, auth.NormalizeUserCode(userCode))
+	if err != nil {
+		return err
+	}
+	if !authorization.IsPending(time.Now()) {
+		return errors.New("device authorization is not pending")
+	}
+	update(authorization)
+	return s.UpdateDeviceAuthorization(ctx, authorization)
+}
+
+// CompleteDeviceAuthorization implements the op.DeviceAuthorizationStorage interface
+// it will be called once the user approving the device has logged in
+func (s *storageFacade) CompleteDeviceAuthorization(ctx context.Context, userCode, subject string) error {
+	return s.updatePendingDeviceAuthorization(ctx, userCode, func(authorization *auth.DeviceAuthorization) {
+		authorization.UserID = subject
+		authorization.AuthTime = time.Now()
+	})
+}
+
+// DenyDeviceAuthorization implements the op.DeviceAuthorizationStorage interface
+func (s *storageFacade) DenyDeviceAuthorization(ctx context.Context, userCode string) error {
+	return s.updatePendingDeviceAuthorization(ctx, userCode, func(authorization *auth.DeviceAuthorization) {
+		authorization.Denied = true
+	})
+}
+
+// DeviceAccessTokenRequest implements the DeviceAccessTokenStorage interface
+// it will be called on each poll of the device, the polls faster than the interval are answered with slow_down
+func (s *storageFacade) DeviceAccessTokenRequest(ctx context.Context, clientID, deviceCode string) (op.IDTokenRequest, error) {
+	authorization, err := s.FindDeviceAuthorization(ctx, deviceCode)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, oidc.ErrInvalidGrant().WithDescription("invalid device_code")
+		}
+		return nil, err
+	}
+	if authorization.ApplicationID != clientID {
+		return nil, oidc.ErrInvalidGrant().WithDescription("invalid device_code")
+	}
+
+	now := time.Now()
+	switch {
+	case authorization.Denied:
+		return nil, oidc.ErrAccessDenied()
+	case authorization.Done():
+		// the device code can be exchanged only once
+		deleted, err := s.DeleteDeviceAuthorization(ctx, authorization.ID)
+		if err != nil {
+			return nil, err
+		}
+		if !deleted {
+			return nil, oidc.ErrInvalidGrant().WithDescription("device_code already used")
+		}
+		scopes, err := s.restrictToRoles(ctx, authorization.UserID, authorization.Scopes)
+		if err != nil {
+			return nil, err
+		}
+		return &auth.AuthRequest{
+			ID:            authorization.ID,
+			CreatedAt:     authorization.CreatedAt,
+			ApplicationID: authorization.ApplicationID,
+			Scopes:        scopes,
+			ResponseType:  oidc.ResponseTypeCode,
+			UserID:        authorization.UserID,
+			AuthTime:      authorization.AuthTime,
+		}, nil
+	case now.After(authorization.Expiration):
+		return nil, oidc.ErrExpiredDeviceCode()
+	}
+
+	tooFast := authorization.LastPolledAt != nil &&
+		now.Sub(*authorization.LastPolledAt) < DevicePollInterval-devicePollIntervalTolerance
+	authorization.LastPolledAt = &now
+	if err := s.UpdateDeviceAuthorization(ctx, authorization); err != nil {
+		return nil, err
+	}
+	if tooFast {
+		return nil, oidc.ErrSlowDown()
+	}
+	return nil, oidc.ErrAuthorizationPending()
+}
+
+// exchangedToken is the subject or actor token of a token exchange request
+type exchangedToken struct {
+	userID   string
+	clientID string
+	scopes   []string
+}
+
+// identity returns the user of the token, or the client of the tokens obtained with client credentials
+func (t exchangedToken) identity() string {
+	if t.userID != "" {
+		return t.userID
+	}
+	return t.clientID
+}
+
+// exchangedToken checks that a subject or actor token is issued by an issuer allowed by the policy.
+// The tokens issued by this server must still be active, the users of the other issuers must be known.
+func (s *storageFacade) exchangedToken(ctx context.Context, policy *auth.TokenExchangePolicy, tokenIDOrToken string,
+	claims map[string]any) (*exchangedToken, error) {
+	issuer, _ := claims["iss"].(string)
+	if len(policy.SubjectTokenIssuers) == 0 && issuer != op.IssuerFromContext(ctx) ||
+		len(policy.SubjectTokenIssuers) > 0 && !collectionutils.Contains(policy.SubjectTokenIssuers, issuer) {
+		return nil, oidc.ErrInvalidGrant().WithDescription("issuer %s is not allowed", issuer)
+	}
+
+	if issuer == op.IssuerFromContext(ctx) {
+		token, err := s.FindAccessToken(ctx, tokenIDOrToken)
+		if err := storage.IgnoreNotFoundError(err); err != nil {
+			return nil, err
+		}
+		if token == nil || !time.Now().Before(token.Expiration) {
+			return nil, oidc.ErrInvalidGrant().WithDescription("token is not active")
+		}
+		return &exchangedToken{
+			userID:   token.UserID,
+			clientID: token.ApplicationID,
+			scopes:   token.Scopes,
+		}, nil
+	}
+
+	ret := &exchangedToken{}
+	ret.clientID, _ = claims["client_id"].(string)
+	if scope, ok := claims["scope"].(string); ok {
+		ret.scopes = strings.Fields(scope)
+	}
+	subject, _ := claims["sub"].(string)
+	user, err := s.findUpstreamUser(ctx, issuer, subject)
+	if err != nil {
+		return nil, err
+	}
+	if user != nil && user.Disabled {
+		return nil, oidc.ErrInvalidGrant().WithDescription("user is disabled")
+	}
+	if user != nil {
+		ret.userID = user.ID
+	}
+	return ret, nil
+}
+
+// ValidateTokenExchangeRequest implements the op.TokenExchangeStorage interface
+// it applies the token exchange policy of the client and computes the actor of the issued token
+func (s *storageFacade) ValidateTokenExchangeRequest(ctx context.Context, request op.TokenExchangeRequest) error {
+	tokenRequest, ok := request.(*auth.TokenExchangeRequest)
+	if !ok {
+		return oidc.ErrServerError().WithDescription("unexpected token exchange request")
+	}
+	client, err := s.findClient(ctx, tokenRequest.ApplicationID)
+	if err != nil {
+		return oidc.ErrInvalidClient().WithParent(err)
+	}
+	policy := client.GetTokenExchangePolicy()
+	if policy == nil {
+		return oidc.ErrUnauthorizedClient()
+	}
+
+	// only access tokens are issued, the exchanged tokens must not be renewed with a refresh token
+	switch tokenRequest.RequestedTokenType {
+	case "":
+		tokenRequest.SetRequestedTokenType(oidc.AccessTokenType)
+	case oidc.AccessTokenType:
+	default:
+		return oidc.ErrInvalidRequest().WithDescription("requested_token_type is not supported")
+	}
+
+	subject, err := s.exchangedToken(ctx, policy, tokenRequest.SubjectTokenIDOrToken, tokenRequest.SubjectTokenClaims)
+	if err != nil {
+		return err
+	}
+	if subject.userID == "" {
+		return oidc.ErrInvalidGrant().WithDescription("subject_token is not issued to a known user")
+	}
+	tokenRequest.SetSubject(subject.userID)
+
+	// the previous actors of the subject token are kept to record the delegation chain
+	previousActor := auth.ActorFromClaim(tokenRequest.SubjectTokenClaims[ActorClaim])
+	switch {
+	case tokenRequest.ActorTokenIDOrToken != "":
+		actor, err := s.exchangedToken(ctx, policy, tokenRequest.ActorTokenIDOrToken, tokenRequest.ActorTokenClaims)
+		if err != nil {
+			return err
+		}
+		if actor.identity() == "" {
+			return oidc.ErrInvalidGrant().WithDescription("actor_token has no subject")
+		}
+		tokenRequest.Actor = &auth.Actor{Subject: actor.identity(), Actor: previousActor}
+	case policy.Impersonation:
+		tokenRequest.Actor = previousActor
+	default:
+		tokenRequest.Actor = &auth.Actor{Subject: client.GetID(), Actor: previousActor}
+	}
+	if mayAct := auth.ActorFromClaim(tokenRequest.SubjectTokenClaims[MayActClaim]); mayAct != nil &&
+		(tokenRequest.Actor == nil || tokenRequest.Actor.Subject != mayAct.Subject) {
+		return oidc.ErrInvalidGrant().WithDescription("the actor is not allowed to act for the subject")
+	}
+
+	for _, audience := range tokenRequest.Audience {
+		if audience != client.GetID() && !collectionutils.Contains(policy.Audiences, audience) {
+			return errInvalidTarget().WithDescription("audience %s is not allowed", audience)
+		}
+	}
+
+	// the issued token is down-scoped: its scopes are a subset of the scopes of the subject token
+	allowedScope := func(scope string) bool {
+		return collectionutils.Contains(subject.scopes, scope) &&
+			(len(policy.Scopes) == 0 || auth.MatchAnyScope(policy.Scopes, scope))
+	}
+	if len(tokenRequest.Scopes) == 0 {
+		tokenRequest.SetCurrentScopes(collectionutils.Filter(subject.scopes, allowedScope))
+		return nil
+	}
+	for _, scope := range tokenRequest.Scopes {
+		if !allowedScope(scope) {
+			return oidc.ErrInvalidScope().WithDescription("scope %s is not allowed", scope)
+		}
+	}
+	return nil
+}
+
+// CreateTokenExchangeRequest implements the op.TokenExchangeStorage interface
+// the requests are not stored, the issued access token records the client and the actor
+func (s *storageFacade) CreateTokenExchangeRequest(ctx context.Context, request op.TokenExchangeRequest) error {
+	logging.FromContext(ctx).WithFields(map[string]any{
+		"client":        request.GetClientID(),
+		"user":          request.GetSubject(),
+		"subjectIssuer": request.GetExchangeSubjectTokenClaims()["iss"],
+	}).Debugf("Exchanging token")
+	return nil
+}
+
+// GetPrivateClaimsFromTokenExchangeRequest implements the op.TokenExchangeStorage interface
+// it will be called for the creation of a JWT access token obtained by token exchange, to add the act claim
+func (s *storageFacade) GetPrivateClaimsFromTokenExchangeRequest(ctx context.Context, request op.TokenExchangeRequest) (map[string]any, error) {
+	claims, err := s.GetPrivateClaimsFromScopes(ctx, request.GetSubject(), request.GetClientID(), request.GetScopes())
+	if err != nil {
+		return nil, err
+	}
+	if tokenRequest, ok := request.(*auth.TokenExchangeRequest); ok && tokenRequest.Actor != nil {
+		claims[ActorClaim] = tokenRequest.Actor
+	}
+	return claims, nil
+}
+
+// SetUserinfoFromTokenExchangeRequest implements the op.TokenExchangeStorage interface
+func (s *storageFacade) SetUserinfoFromTokenExchangeRequest(ctx context.Context, userinfo *oidc.UserInfo, request op.TokenExchangeRequest) error {
+	return s.setUserinfo(ctx, userinfo, request.GetSubject(), request.GetScopes())
 }
 
 var _ op.Storage = (*storageFacade)(nil)
 var _ op.ClientCredentialsStorage = (*storageFacade)(nil)
-
-func NewStorageFacade(storage Storage, rp rp.RelyingParty, privateKey *rsa.PrivateKey, staticClients ...auth.StaticClient) *storageFacade {
+var _ op.DeviceAuthorizationStorage = (*storageFacade)(nil)
+var _ DeviceAccessTokenStorage = (*storageFacade)(nil)
+var _ op.TokenExchangeStorage = (*storageFacade)(nil)
+var _ PushedAuthRequestStorage = (*storageFacade)(nil)
+var _ IntrospectionStorage = (*storageFacade)(nil)
+var _ ScopePolicyStorage = (*storageFacade)(nil)
+
+func NewStorageFacade(storage Storage, providers *delegatedauth.Providers, keyRing *KeyRing, lifetimes TokenLifetimes, mtls MTLSConfig,
+	publisher message.Publisher, policy ScopePolicy, staticClients ...auth.StaticClient) *storageFacade {
 	return &storageFacade{
-		Storage: storage,
-		signingKey: signingKey{
-			id:        KeyID,
-			algorithm: "RS256",
-			key:       privateKey,
-		},
-		relyingParty:  rp,
+		Storage:       storage,
+		providers:     providers,
+		keyRing:       keyRing,
+		lifetimes:     lifetimes,
+		publisher:     publisher,
 		staticClients: staticClients,
+		jwks: newJWKSCache(&http.Client{
+			Timeout: 10 * time.Second,
+		}),
+		mtls:   mtls,
+		policy: policy,
 	}
 }
-
-const KeyID = "id"
@@ -0,0 +1,77 @@
+package oidc
+
+import (
+	"context"
+	"testing"
+
+	auth "github.com/formancehq/auth/pkg"
+	"github.com/formancehq/auth/pkg/delegatedauth"
+	"github.com/formancehq/auth/pkg/storage"
+	"github.com/stretchr/testify/require"
+)
+
+type upstreamUsersStorage struct {
+	Storage
+	identityProviders []auth.IdentityProvider
+	users             []auth.User
+}
+
+func (s *upstreamUsersStorage) ListIdentityProviders(ctx context.Context) ([]auth.IdentityProvider, error) {
+	return s.identityProviders, nil
+}
+
+func (s *upstreamUsersStorage) FindUserBySubject(ctx context.Context, provider, subject string) (*auth.User, error) {
+	for _, user := range s.users {
+		if user.Provider == provider && user.Subject == subject {
+			return &user, nil
+		}
+	}
+	return nil, storage.ErrNotFound
+}
+
+func TestFindUpstreamUser(t *testing.T) {
+	t.Parallel()
+
+	store := &upstreamUsersStorage{
+		identityProviders: []auth.IdentityProvider{*auth.NewIdentityProvider(auth.IdentityProviderOptions{
+			Name:   "partner",
+			Issuer: "https://accounts.partner.com",
+		})},
+		users: []auth.User{{
+			ID: "default-user",
+			UserOptions: auth.UserOptions{
+				Provider: auth.DefaultIdentityProvider,
+				Subject:  "alice",
+			},
+		}, {
+			ID: "partner-user",
+			UserOptions: auth.UserOptions{
+				Provider: "partner",
+				Subject:  "alice",
+			},
+		}},
+	}
+	providers := delegatedauth.NewProviders(nil, "", store, delegatedauth.Config{
+		Name:   auth.DefaultIdentityProvider,
+		Issuer: "https://accounts.default.com",
+	})
+	facade := NewStorageFacade(store, providers, nil, DefaultTokenLifetimes, MTLSConfig{}, nil, nil)
+
+	// The users are looked up with the identity provider of the issuer, the subjects are only unique per provider
+	user, err := facade.findUpstreamUser(context.Background(), "https://accounts.default.com", "alice")
+	require.NoError(t, err)
+	require.Equal(t, "default-user", user.ID)
+
+	user, err = facade.findUpstreamUser(context.Background(), "https://accounts.partner.com", "alice")
+	require.NoError(t, err)
+	require.Equal(t, "partner-user", user.ID)
+
+	user, err = facade.findUpstreamUser(context.Background(), "https://accounts.partner.com", "bob")
+	require.NoError(t, err)
+	require.Nil(t, user)
+
+	// The users of an issuer matching no identity provider are unknown
+	user, err = facade.findUpstreamUser(context.Background(), "https://accounts.other.com", "alice")
+	require.NoError(t, err)
+	require.Nil(t, user)
+}
@@ -0,0 +1,159 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	auth "github.com/formancehq/auth/pkg"
+	"github.com/formancehq/auth/pkg/delegatedauth"
+	httphelper "github.com/zitadel/oidc/v2/pkg/http"
+	"github.com/zitadel/oidc/v2/pkg/oidc"
+	"github.com/zitadel/oidc/v2/pkg/op"
+)
+
+const (
+	// ActorClaim holds the party acting on behalf of the subject of a token (RFC 8693)
+	ActorClaim = "act"
+	// MayActClaim restricts the parties allowed to act on behalf of the subject of a token (RFC 8693)
+	MayActClaim = "may_act"
+)
+
+func errInvalidTarget() *oidc.Error {
+	return &oidc.Error{
+		ErrorType: "invalid_target",
+	}
+}
+
+// isTokenExchangeRequest returns true for the token requests of the token exchange grant
+func isTokenExchangeRequest(r *http.Request) bool {
+	return r.URL.Path == op.DefaultEndpoints.Token.Relative() &&
+		r.FormValue("grant_type") == string(oidc.GrantTypeTokenExchange)
+}
+
+// exchangedTokenVerifier verifies the subject and actor tokens, which are access tokens
+// issued by this server or by one of the identity providers
+type exchangedTokenVerifier struct {
+	provider  op.OpenIDProvider
+	providers *delegatedauth.Providers
+}
+
+// Verify returns the id of the tokens issued by this server or the token itself, its subject and its claims
+func (v exchangedTokenVerifier) Verify(ctx context.Context, token string, tokenType oidc.TokenType) (string, string, map[string]any, error) {
+	if tokenType != oidc.AccessTokenType && tokenType != oidc.JWTTokenType {
+		return "", "", nil, oidc.ErrInvalidRequest().WithDescription("token type %s is not supported", tokenType)
+	}
+
+	unverified := &oidc.TokenClaims{}
+	if _, err := oidc.ParseToken(token, unverified); err != nil {
+		return "", "", nil, oidc.ErrInvalidGrant().WithParent(err)
+	}
+
+	var verifier op.AccessTokenVerifier
+	if unverified.Issuer == op.IssuerFromContext(ctx) {
+		verifier = v.provider.AccessTokenVerifier(ctx)
+	} else {
+		keySet, err := upstreamKeySet(ctx, v.providers, unverified.Issuer)
+		if err != nil {
+			return "", "", nil, err
+		}
+		verifier = op.NewAccessTokenVerifier(unverified.Issuer, keySet)
+	}
+
+	claims, err := op.VerifyAccessToken[*oidc.AccessTokenClaims](ctx, token, verifier)
+	if err != nil {
+		return "", "", nil, oidc.ErrInvalidGrant().WithParent(err)
+	}
+
+	subject := claims.Subject
+	if subject == "" {
+		// the tokens obtained with client credentials have no subject
+		subject = claims.ClientID
+	}
+	if unverified.Issuer == op.IssuerFromContext(ctx) {
+		return claims.JWTID, subject, claims.Claims, nil
+	}
+	return token, subject, claims.Claims, nil
+}
+
+// tokenExchange handles the token requests of the token exchange grant (RFC 8693),
+// as the library only authenticates the clients with basic auth and does not verify the tokens of the identity providers.
+func tokenExchange(provider op.OpenIDProvider, verifier exchangedTokenVerifier) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		storage, ok := provider.Storage().(op.TokenExchangeStorage)
+		if !ok {
+			op.RequestError(w, r, oidc.ErrUnsupportedGrantType().WithDescription("token_exchange grant not supported"))
+			return
+		}
+
+		client, err := authenticateRequestClient(r, provider)
+		if err != nil {
+			op.RequestError(w, r, err)
+			return
+		}
+		request, _, _, err := op.ParseTokenExchangeRequest(r, provider.Decoder())
+		if err != nil {
+			op.RequestError(w, r, err)
+			return
+		}
+		if !op.ValidateGrantType(client, oidc.GrantTypeTokenExchange) {
+			op.RequestError(w, r, oidc.ErrUnauthorizedClient())
+			return
+		}
+		if request.SubjectToken == "" || request.SubjectTokenType == "" {
+			op.RequestError(w, r, oidc.ErrInvalidRequest().WithDescription("subject_token and subject_token_type are required"))
+			return
+		}
+		if (request.ActorToken == "") != (request.ActorTokenType == "") {
+			op.RequestError(w, r, oidc.ErrInvalidRequest().WithDescription("actor_token and actor_token_type must be provided together"))
+			return
+		}
+
+		tokenRequest := &auth.TokenExchangeRequest{
+			ApplicationID:      client.GetID(),
+			Audience:           request.Audience,
+			Resources:          request.Resource,
+			Scopes:             request.Scopes,
+			RequestedTokenType: request.RequestedTokenType,
+			AuthTime:           time.Now(),
+			SubjectTokenType:   request.SubjectTokenType,
+			ActorTokenType:     request.ActorTokenType,
+		}
+		tokenRequest.SubjectTokenIDOrToken, tokenRequest.SubjectTokenSubject, tokenRequest.SubjectTokenClaims, err =
+			verifier.Verify(r.Context(), request.SubjectToken, request.SubjectTokenType)
+		if err != nil {
+			op.RequestError(w, r, err)
+			return
+		}
+		if request.ActorToken != "" {
+			tokenRequest.ActorTokenIDOrToken, tokenRequest.ActorTokenSubject, tokenRequest.ActorTokenClaims, err =
+				verifier.Verify(r.Context(), request.ActorToken, request.ActorTokenType)
+			if err != nil {
+				op.RequestError(w, r, err)
+				return
+			}
+		}
+		// the resources are the audiences of the issued token
+		tokenRequest.Audience = append(tokenRequest.Audience, tokenRequest.Resources...)
+		if len(tokenRequest.Audience) == 0 {
+			tokenRequest.Audience = []string{client.GetID()}
+		}
+
+		if err := storage.ValidateTokenExchangeRequest(r.Context(), tokenRequest); err != nil {
+			op.RequestError(w, r, err)
+			return
+		}
+		if err := storage.CreateTokenExchangeRequest(r.Context(), tokenRequest); err != nil {
+			op.RequestError(w, r, err)
+			return
+		}
+
+		response, err := op.CreateTokenExchangeResponse(r.Context(), tokenRequest, client, provider)
+		if err != nil {
+			op.RequestError(w, r, err)
+			return
+		}
+
+		httphelper.MarshalJSON(w, response)
+	}
+}
@@ -0,0 +1,159 @@
+package oidc_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	auth "github.com/formancehq/auth/pkg"
+	"github.com/formancehq/auth/pkg/storage/sqlstorage"
+	"github.com/golang-jwt/jwt"
+	"github.com/oauth2-proxy/mockoidc"
+	"github.com/stretchr/testify/require"
+	zoidc "github.com/zitadel/oidc/v2/pkg/oidc"
+	"github.com/zitadel/oidc/v2/pkg/op"
+)
+
+func TestTokenExchange(t *testing.T) {
+	withServer(t, func(m *mockoidc.MockOIDC, storage *sqlstorage.Storage, issuer string, provider op.OpenIDProvider) {
+		user := &auth.User{
+			ID: "user1",
+			UserOptions: auth.UserOptions{
+				Provider: auth.DefaultIdentityProvider,
+				Subject:  "upstream-user",
+				Email:    "user1@example.com",
+			},
+		}
+		require.NoError(t, storage.SaveUser(context.TODO(), user))
+
+		client := auth.NewClient(auth.ClientOptions{
+			TokenExchange: &auth.TokenExchangePolicy{
+				SubjectTokenIssuers: []string{m.Issuer(), issuer},
+				Audiences:           []string{"ledger"},
+				Scopes:              []string{"ledger:read"},
+			},
+		})
+		_, clear := client.GenerateNewSecret(auth.SecretCreate{})
+		require.NoError(t, storage.SaveClient(context.TODO(), client))
+
+		// The user token issued by the delegated identity provider
+		subjectToken, err := m.Keypair.SignJWT(jwt.MapClaims{
+			"iss":   m.Issuer(),
+			"sub":   user.Subject,
+			"aud":   []string{m.ClientID},
+			"exp":   time.Now().Add(time.Minute).Unix(),
+			"scope": "ledger:read ledger:write",
+		})
+		require.NoError(t, err)
+
+		tokenURL := issuer + op.DefaultEndpoints.Token.Relative()
+		exchange := func(form url.Values) (int, map[string]any) {
+			form.Set("grant_type", string(zoidc.GrantTypeTokenExchange))
+			form.Set("client_id", client.Id)
+			form.Set("client_secret", clear)
+			return postForm(t, tokenURL, form)
+		}
+
+		status, rsp := exchange(url.Values{
+			"subject_token":      []string{subjectToken},
+			"subject_token_type": []string{string(zoidc.AccessTokenType)},
+			"audience":           []string{"ledger"},
+		})
+		require.Equal(t, http.StatusOK, status)
+		require.Equal(t, string(zoidc.AccessTokenType), rsp["issued_token_type"])
+		require.Equal(t, "ledger:read", rsp["scope"])
+
+		exchanged := &zoidc.AccessTokenClaims{}
+		_, err = zoidc.ParseToken(rsp["access_token"].(string), exchanged)
+		require.NoError(t, err)
+		require.Equal(t, user.ID, exchanged.Subject)
+		require.Equal(t, []string{"ledger"}, []string(exchanged.Audience))
+		require.Equal(t, map[string]any{"sub": client.Id}, exchanged.Claims["act"])
+
+		// The exchanged token is exchanged again, with an actor
+		actor := auth.NewClient(auth.ClientOptions{})
+		_, actorSecret := actor.GenerateNewSecret(auth.SecretCreate{})
+		require.NoError(t, storage.SaveClient(context.TODO(), actor))
+		status, rsp = postForm(t, tokenURL, url.Values{
+			"grant_type":    []string{string(zoidc.GrantTypeClientCredentials)},
+			"client_id":     []string{actor.Id},
+			"client_secret": []string{actorSecret},
+		})
+		require.Equal(t, http.StatusOK, status)
+		actorToken := rsp["access_token"].(string)
+
+		status, rsp = exchange(url.Values{
+			"subject_token":      []string{rsp["access_token"].(string)},
+			"subject_token_type": []string{string(zoidc.AccessTokenType)},
+			"actor_token":        []string{actorToken},
+			"actor_token_type":   []string{string(zoidc.AccessTokenType)},
+		})
+		require.Equal(t, http.StatusBadRequest, status, "a token without user cannot be the subject")
+
+		status, rsp = exchange(url.Values{
+			"subject_token":      []string{subjectToken},
+			"subject_token_type": []string{string(zoidc.AccessTokenType)},
+		})
+		require.Equal(t, http.StatusOK, status)
+		status, rsp = exchange(url.Values{
+			"subject_token":      []string{rsp["access_token"].(string)},
+			"subject_token_type": []string{string(zoidc.AccessTokenType)},
+			"actor_token":        []string{actorToken},
+			"actor_token_type":   []string{string(zoidc.AccessTokenType)},
+		})
+		require.Equal(t, http.StatusOK, status)
+
+		delegated := &zoidc.AccessTokenClaims{}
+		_, err = zoidc.ParseToken(rsp["access_token"].(string), delegated)
+		require.NoError(t, err)
+		require.Equal(t, user.ID, delegated.Subject)
+		require.Equal(t, map[string]any{
+			"sub": actor.Id,
+			"act": map[string]any{"sub": client.Id},
+		}, delegated.Claims["act"])
+
+		// The policy restricts the audiences and the scopes
+		status, rsp = exchange(url.Values{
+			"subject_token":      []string{subjectToken},
+			"subject_token_type": []string{string(zoidc.AccessTokenType)},
+			"audience":           []string{"payments"},
+		})
+		require.Equal(t, http.StatusBadRequest, status)
+		require.Equal(t, "invalid_target", rsp["error"])
+
+		status, rsp = exchange(url.Values{
+			"subject_token":      []string{subjectToken},
+			"subject_token_type": []string{string(zoidc.AccessTokenType)},
+			"scope":              []string{"ledger:write"},
+		})
+		require.Equal(t, http.StatusBadRequest, status)
+		require.Equal(t, "invalid_scope", rsp["error"])
+	})
+}
+
+func TestTokenExchangeNotEnabled(t *testing.T) {
+	withServer(t, func(m *mockoidc.MockOIDC, storage *sqlstorage.Storage, issuer string, provider op.OpenIDProvider) {
+		client := auth.NewClient(auth.ClientOptions{})
+		_, clear := client.GenerateNewSecret(auth.SecretCreate{})
+		require.NoError(t, storage.SaveClient(context.TODO(), client))
+
+		subjectToken, err := m.Keypair.SignJWT(jwt.MapClaims{
+			"iss": m.Issuer(),
+			"sub": "upstream-user",
+			"exp": time.Now().Add(time.Minute).Unix(),
+		})
+		require.NoError(t, err)
+
+		status, rsp := postForm(t, issuer+op.DefaultEndpoints.Token.Relative(), url.Values{
+			"grant_type":         []string{string(zoidc.GrantTypeTokenExchange)},
+			"client_id":          []string{client.Id},
+			"client_secret":      []string{clear},
+			"subject_token":      []string{subjectToken},
+			"subject_token_type": []string{string(zoidc.AccessTokenType)},
+		})
+		require.Equal(t, http.StatusBadRequest, status)
+		require.Equal(t, "unauthorized_client", rsp["error"])
+	})
+}
@@ -0,0 +1,89 @@
+package auth
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Provisioning are the rules applied to the users logging in for the first time with an identity provider,
+// the users created beforehand with the api are not subject to them
+type Provisioning struct {
+	// DenyUnknownUsers rejects the users not created beforehand with the api, no user is created on login
+	DenyUnknownUsers bool `json:"denyUnknownUsers" yaml:"denyUnknownUsers"`
+	// AllowedDomains restricts the creation of users to the emails of these domains verified by the identity provider,
+	// all the domains are allowed if empty
+	AllowedDomains []string `json:"allowedDomains,omitempty" yaml:"allowedDomains"`
+	// RequiredClaims are the claims the identity provider must assert to create a user
+	RequiredClaims []ClaimRequirement `json:"requiredClaims,omitempty" yaml:"requiredClaims"`
+	// ClaimMapping maps the claims of the identity provider to the attributes of the created users
+	ClaimMapping ClaimMapping `json:"claimMapping" yaml:"claimMapping"`
+}
+
+// ClaimRequirement requires a claim asserted by an identity provider
+type ClaimRequirement struct {
+	// Claim is the name of the claim, a nested claim can be addressed with a dotted path
+	Claim string `json:"claim" yaml:"claim"`
+	// Values are the accepted values of the claim, any value is accepted if empty.
+	// A claim holding an array is accepted if one of its values is.
+	Values []string `json:"values,omitempty" yaml:"values"`
+}
+
+// ClaimMapping defines the claims holding the attributes of the users, the standard claims are used if empty
+type ClaimMapping struct {
+	DisplayName string `json:"displayName,omitempty" yaml:"displayName"`
+	GivenName   string `json:"givenName,omitempty" yaml:"givenName"`
+	FamilyName  string `json:"familyName,omitempty" yaml:"familyName"`
+	Locale      string `json:"locale,omitempty" yaml:"locale"`
+	// Metadata maps the keys of the metadata of the users to claims
+	Metadata map[string]string `json:"metadata,omitempty" yaml:"metadata"`
+}
+
+func (p *Provisioning) Validate() error {
+	for _, domain := range p.AllowedDomains {
+		if domain == "" {
+			return errors.New("allowed domains can not be empty")
+		}
+	}
+	for _, requirement := range p.RequiredClaims {
+		if requirement.Claim == "" {
+			return errors.New("the claim of a required claim is required")
+		}
+	}
+	for key, claim := range p.ClaimMapping.Metadata {
+		if key == "" || claim == "" {
+			return fmt.Errorf("invalid metadata mapping '%s': '%s'", key, claim)
+		}
+	}
+	return nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (p *Provisioning) Scan(src interface{}) error {
+	*p = Provisioning{}
+	var err error
+	switch src := src.(type) {
+	case []byte:
+		err = json.Unmarshal(src, p)
+	case string:
+		err = json.Unmarshal([]byte(src), p)
+	case nil:
+	default:
+		return fmt.Errorf("type '%T' not handled", src)
+	}
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (p Provisioning) Value() (driver.Value, error) {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(data), nil
+}
@@ -9,15 +9,28 @@ import (
 type RefreshToken struct {
 	bun.BaseModel `bun:"table:refresh_tokens"`
 
-	ID            string `bun:",pk"`
+	ID string `bun:",pk"`
+	// FamilyID identifies the chain of tokens obtained by successive renewals of the same grant
+	FamilyID      string
 	Token         string
+	CreatedAt     time.Time
 	AuthTime      time.Time
 	AMR           Array[string] `bun:"type:text"`
 	Audience      Array[string] `bun:"type:text"`
 	UserID        string
 	ApplicationID string
 	Expiration    time.Time
-	Scopes        Array[string] `bun:"type:text"`
+	// AbsoluteExpiration is the date after which the token cannot be renewed anymore
+	AbsoluteExpiration time.Time
+	// RotatedAt is set when the token has been exchanged for a new one, it must not be presented anymore
+	RotatedAt *time.Time
+	Scopes    Array[string] `bun:"type:text"`
+	// DPoPKeyThumbprint binds the token of a public client to the key of the DPoP proofs, it cannot be renewed without a proof of this key
+	DPoPKeyThumbprint string `bun:"dpop_key_thumbprint"`
+}
+
+func (r *RefreshToken) IsRotated() bool {
+	return r.RotatedAt != nil
 }
 
 type RefreshTokenRequest struct {
@@ -28,6 +28,13 @@ type AuthRequest struct {
 	UserID        string
 	AuthTime      time.Time
 	Code          string
+	// Resources are the resource servers requested with the resource parameter (RFC 8707), the audiences of the access tokens
+	Resources Array[string] `bun:"type:text"`
+	// IdentityProviderHint is the upstream identity provider requested with the idp_hint parameter, if any
+	IdentityProviderHint string
+	// RequestURIExpiration is the expiration of the request_uri of a pushed authorization request (RFC 9126),
+	// nil if the request has not been pushed or once the request_uri has been used
+	RequestURIExpiration *time.Time
 }
 
 func (a *AuthRequest) GetID() string {
@@ -42,8 +49,9 @@ func (a *AuthRequest) GetAMR() []string {
 	return nil
 }
 
+// GetAudience returns the requested resources, the client stays an audience as it is the audience of the id tokens
 func (a *AuthRequest) GetAudience() []string {
-	return []string{a.ApplicationID}
+	return append(append([]string{}, a.Resources...), a.ApplicationID)
 }
 
 func (a *AuthRequest) GetAuthTime() time.Time {
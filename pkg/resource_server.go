@@ -0,0 +1,77 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/formancehq/go-libs/v3/collectionutils"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type ResourceServerOptions struct {
+	Name string `json:"name" yaml:"name"`
+	// Identifier is the absolute URI of the resource server, requested with the resource parameter (RFC 8707)
+	// and used as audience of the access tokens issued for it
+	Identifier string `json:"identifier" yaml:"identifier"`
+	// Scopes are the scopes accepted by the resource server, the scopes of its access tokens are narrowed to them
+	Scopes Array[string] `json:"scopes" yaml:"scopes" bun:"type:text"`
+}
+
+func (o *ResourceServerOptions) Validate() error {
+	if o.Name == "" {
+		return errors.New("name is required")
+	}
+	return ValidateResourceIdentifier(o.Identifier)
+}
+
+// ResourceServer is an API accepting the access tokens of the server, like the ledger or the payments services
+type ResourceServer struct {
+	bun.BaseModel `bun:"table:resource_servers"`
+
+	ID string `json:"id" bun:",pk"`
+	ResourceServerOptions
+}
+
+func (r *ResourceServer) Update(opts ResourceServerOptions) {
+	r.ResourceServerOptions = opts
+}
+
+func (r *ResourceServer) HasScope(scope string) bool {
+	return collectionutils.Contains(r.Scopes, scope)
+}
+
+func NewResourceServer(opts ResourceServerOptions) *ResourceServer {
+	return &ResourceServer{
+		ID:                    uuid.NewString(),
+		ResourceServerOptions: opts,
+	}
+}
+
+// ValidateResourceIdentifier checks that a resource is an absolute URI without fragment, as required by RFC 8707
+func ValidateResourceIdentifier(identifier string) error {
+	u, err := url.Parse(identifier)
+	if err != nil || !u.IsAbs() || u.Fragment != "" {
+		return fmt.Errorf("invalid resource identifier '%s'", identifier)
+	}
+	return nil
+}
+
+// NarrowScopesToResources keeps the scopes accepted by at least one of the resource servers, and the OpenID Connect scopes
+func NarrowScopesToResources(scopes []string, resourceServers []*ResourceServer) []string {
+	ret := make([]string, 0)
+	for _, scope := range scopes {
+		if IsOpenIDScope(scope) {
+			ret = append(ret, scope)
+			continue
+		}
+		for _, resourceServer := range resourceServers {
+			if resourceServer.HasScope(scope) {
+				ret = append(ret, scope)
+				break
+			}
+		}
+	}
+	return ret
+}
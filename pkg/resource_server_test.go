@@ -0,0 +1,37 @@
+package auth_test
+
+import (
+	"testing"
+
+	auth "github.com/formancehq/auth/pkg"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceServerOptionsValidate(t *testing.T) {
+	for _, identifier := range []string{"https://ledger.formance.com", "urn:formance:ledger"} {
+		require.NoError(t, (&auth.ResourceServerOptions{Name: "ledger", Identifier: identifier}).Validate())
+	}
+	for _, identifier := range []string{"", "ledger", "/ledger", "https://ledger.formance.com#v2"} {
+		require.Error(t, (&auth.ResourceServerOptions{Name: "ledger", Identifier: identifier}).Validate(), identifier)
+	}
+	require.Error(t, (&auth.ResourceServerOptions{Identifier: "https://ledger.formance.com"}).Validate())
+}
+
+func TestNarrowScopesToResources(t *testing.T) {
+	ledger := auth.NewResourceServer(auth.ResourceServerOptions{
+		Name:       "ledger",
+		Identifier: "https://ledger.formance.com",
+		Scopes:     []string{"ledger:read", "ledger:write"},
+	})
+	payments := auth.NewResourceServer(auth.ResourceServerOptions{
+		Name:       "payments",
+		Identifier: "https://payments.formance.com",
+		Scopes:     []string{"payments:read"},
+	})
+	scopes := []string{"openid", "email", "ledger:read", "payments:read", "wallets:read"}
+
+	require.Equal(t, []string{"openid", "email", "ledger:read"},
+		auth.NarrowScopesToResources(scopes, []*auth.ResourceServer{ledger}))
+	require.Equal(t, []string{"openid", "email", "ledger:read", "payments:read"},
+		auth.NarrowScopesToResources(scopes, []*auth.ResourceServer{ledger, payments}))
+}
@@ -0,0 +1,74 @@
+package auth
+
+import (
+	"errors"
+
+	"github.com/formancehq/go-libs/v3/collectionutils"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type RoleOptions struct {
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description" yaml:"description"`
+	// Scopes are the scopes granted to the users having the role, wildcards are allowed
+	Scopes Array[string] `json:"scopes" yaml:"scopes" bun:"type:text"`
+}
+
+func (o *RoleOptions) Validate() error {
+	if o.Name == "" {
+		return errors.New("name is required")
+	}
+	for _, scope := range o.Scopes {
+		if err := ValidateScopeName(scope); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Role grants scopes to the members of the groups having it, like analyst or operator.
+// Once roles are defined, the users are only granted the scopes of their roles, besides the OpenID Connect ones.
+type Role struct {
+	bun.BaseModel `bun:"table:roles"`
+
+	ID string `json:"id" bun:",pk"`
+	RoleOptions
+}
+
+func (r *Role) Update(opts RoleOptions) {
+	r.RoleOptions = opts
+}
+
+func NewRole(opts RoleOptions) *Role {
+	return &Role{
+		ID:          uuid.NewString(),
+		RoleOptions: opts,
+	}
+}
+
+// RolesOfGroups returns the roles of the groups
+func RolesOfGroups(groups []Group, roles []Role) []Role {
+	ret := make([]Role, 0)
+	for _, role := range roles {
+		for _, group := range groups {
+			if collectionutils.Contains(group.Roles, role.ID) {
+				ret = append(ret, role)
+				break
+			}
+		}
+	}
+	return ret
+}
+
+// RestrictScopesToRoles returns the scopes granted by the roles, the OpenID Connect scopes are always granted.
+// The scopes of the roles must be expanded by the caller.
+func RestrictScopesToRoles(scopes []string, roleScopes []string) []string {
+	ret := make([]string, 0)
+	for _, scope := range scopes {
+		if IsOpenIDScope(scope) || collectionutils.Contains(roleScopes, scope) {
+			ret = append(ret, scope)
+		}
+	}
+	return ret
+}
@@ -0,0 +1,39 @@
+package auth_test
+
+import (
+	"testing"
+
+	auth "github.com/formancehq/auth/pkg"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoleOptionsValidate(t *testing.T) {
+	require.NoError(t, (&auth.RoleOptions{Name: "operator", Scopes: []string{"ledger:*", "payments:read"}}).Validate())
+	require.Error(t, (&auth.RoleOptions{Scopes: []string{"ledger:read"}}).Validate())
+	require.Error(t, (&auth.RoleOptions{Name: "operator", Scopes: []string{"ledger read"}}).Validate())
+}
+
+func TestRolesOfGroups(t *testing.T) {
+	roles := []auth.Role{
+		{ID: "analyst", RoleOptions: auth.RoleOptions{Name: "analyst"}},
+		{ID: "operator", RoleOptions: auth.RoleOptions{Name: "operator"}},
+		{ID: "admin", RoleOptions: auth.RoleOptions{Name: "admin"}},
+	}
+	groups := []auth.Group{
+		{ID: "analysts", GroupOptions: auth.GroupOptions{Roles: []string{"analyst"}}},
+		{ID: "operators", GroupOptions: auth.GroupOptions{Roles: []string{"analyst", "operator"}}},
+	}
+
+	require.Equal(t, roles[:2], auth.RolesOfGroups(groups, roles))
+	require.Equal(t, roles[:1], auth.RolesOfGroups(groups[:1], roles))
+	require.Empty(t, auth.RolesOfGroups(nil, roles))
+}
+
+func TestRestrictScopesToRoles(t *testing.T) {
+	scopes := []string{"openid", "email", "ledger:read", "ledger:write", "payments:read"}
+
+	require.Equal(t, []string{"openid", "email", "ledger:read"},
+		auth.RestrictScopesToRoles(scopes, []string{"ledger:read"}))
+	require.Equal(t, []string{"openid", "email"},
+		auth.RestrictScopesToRoles(scopes, nil))
+}
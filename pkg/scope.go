@@ -0,0 +1,184 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/formancehq/go-libs/v3/collectionutils"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+	"github.com/zitadel/oidc/v2/pkg/oidc"
+)
+
+// OpenIDScopes are the scopes of the user information defined by OpenID Connect, they are always supported
+// and do not have to be registered
+var OpenIDScopes = []string{
+	oidc.ScopeOpenID,
+	oidc.ScopeProfile,
+	oidc.ScopeEmail,
+	oidc.ScopePhone,
+	oidc.ScopeAddress,
+	oidc.ScopeOfflineAccess,
+}
+
+func IsOpenIDScope(scope string) bool {
+	return collectionutils.Contains(OpenIDScopes, scope)
+}
+
+type ScopeOptions struct {
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description" yaml:"description"`
+	// Service is the name of the service owning the scope, like ledger or payments
+	Service string `json:"service" yaml:"service"`
+	// RequiresConsent indicates if the user must consent to the scope before it is granted to a client
+	RequiresConsent bool `json:"requiresConsent" yaml:"requiresConsent"`
+	// Implies are the scopes granted with the scope, like ledger:read for ledger:write, wildcards are allowed
+	Implies Array[string] `json:"implies" yaml:"implies" bun:"type:text"`
+}
+
+func (o *ScopeOptions) Validate() error {
+	if o.Name == "" {
+		return errors.New("name is required")
+	}
+	if IsOpenIDScope(o.Name) {
+		return fmt.Errorf("scope '%s' is reserved by OpenID Connect", o.Name)
+	}
+	if IsScopePattern(o.Name) {
+		return fmt.Errorf("scope '%s' can not contain the wildcard %s", o.Name, ScopeWildcard)
+	}
+	if err := ValidateScopeName(o.Name); err != nil {
+		return err
+	}
+	for _, implied := range o.Implies {
+		if err := ValidateScopeName(implied); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Scope is a scope registered on the server, the clients can only be configured with registered scopes
+type Scope struct {
+	bun.BaseModel `bun:"table:scopes"`
+
+	ID string `json:"id" bun:",pk"`
+	ScopeOptions
+}
+
+func (s *Scope) Update(opts ScopeOptions) {
+	s.ScopeOptions = opts
+}
+
+func NewScope(opts ScopeOptions) *Scope {
+	return &Scope{
+		ID:           uuid.NewString(),
+		ScopeOptions: opts,
+	}
+}
+
+// ValidateScopeName checks that a scope only contains the characters allowed by RFC 6749
+func ValidateScopeName(name string) error {
+	for _, c := range name {
+		if c < 0x21 || c > 0x7E || c == '"' || c == '\\' {
+			return fmt.Errorf("invalid scope '%s'", name)
+		}
+	}
+	return nil
+}
+
+// ValidateScopes checks that the scopes are either OpenID Connect scopes, registered ones or patterns
+func ValidateScopes(scopes []string, registered []string) error {
+	unknown := make([]string, 0)
+	for _, scope := range scopes {
+		if IsScopePattern(scope) {
+			if err := ValidateScopeName(scope); err != nil {
+				return err
+			}
+			continue
+		}
+		if !IsOpenIDScope(scope) && !collectionutils.Contains(registered, scope) {
+			unknown = append(unknown, scope)
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("unknown scopes: %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+const (
+	// ScopeWildcard matches any segment of a scope, the segments being separated by ScopeSeparator
+	ScopeWildcard  = "*"
+	ScopeSeparator = ":"
+)
+
+// IsScopePattern returns true if the scope contains wildcards, like ledger:* or *:read
+func IsScopePattern(scope string) bool {
+	return strings.Contains(scope, ScopeWildcard)
+}
+
+// MatchScope returns true if the scope is matched by the pattern.
+// A wildcard segment matches any segment, a trailing one matches all the remaining segments.
+func MatchScope(pattern, scope string) bool {
+	if pattern == scope {
+		return true
+	}
+	if !IsScopePattern(pattern) {
+		return false
+	}
+	patternSegments := strings.Split(pattern, ScopeSeparator)
+	scopeSegments := strings.Split(scope, ScopeSeparator)
+	for i, segment := range patternSegments {
+		if i >= len(scopeSegments) {
+			return false
+		}
+		if segment == ScopeWildcard && i == len(patternSegments)-1 {
+			return true
+		}
+		if segment != ScopeWildcard && segment != scopeSegments[i] {
+			return false
+		}
+	}
+	return len(patternSegments) == len(scopeSegments)
+}
+
+// MatchAnyScope returns true if the scope is matched by one of the patterns
+func MatchAnyScope(patterns []string, scope string) bool {
+	for _, pattern := range patterns {
+		if MatchScope(pattern, scope) {
+			return true
+		}
+	}
+	return false
+}
+
+// ExpandScopes returns the concrete scopes granted by a list of scopes:
+// the patterns are replaced by the registered scopes they match, then the implied scopes are added.
+// The scopes which are not patterns are kept even if they are not registered, like the OpenID Connect ones.
+func ExpandScopes(scopes []string, registered []Scope) []string {
+	ret := make([]string, 0)
+	add := func(patterns ...string) {
+		for _, pattern := range patterns {
+			if !IsScopePattern(pattern) && !collectionutils.Contains(ret, pattern) {
+				ret = append(ret, pattern)
+			}
+			for _, scope := range registered {
+				if IsScopePattern(pattern) && MatchScope(pattern, scope.Name) && !collectionutils.Contains(ret, scope.Name) {
+					ret = append(ret, scope.Name)
+				}
+			}
+		}
+	}
+	add(scopes...)
+
+	// ret grows while it is browsed, until all the implied scopes are added
+	for i := 0; i < len(ret); i++ {
+		for _, scope := range registered {
+			if scope.Name == ret[i] {
+				add(scope.Implies...)
+			}
+		}
+	}
+	return ret
+}
@@ -0,0 +1,67 @@
+package auth_test
+
+import (
+	"testing"
+
+	auth "github.com/formancehq/auth/pkg"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScopeOptionsValidate(t *testing.T) {
+	for _, name := range []string{"ledger:read", "urn:formance:ledger:write", "payments.read"} {
+		require.NoError(t, (&auth.ScopeOptions{Name: name}).Validate(), name)
+	}
+	for _, name := range []string{"", "ledger read", "ledger\"read", "openid", "offline_access", "ledger:*"} {
+		require.Error(t, (&auth.ScopeOptions{Name: name}).Validate(), name)
+	}
+}
+
+func TestValidateScopes(t *testing.T) {
+	registered := []string{"ledger:read", "ledger:write"}
+
+	require.NoError(t, auth.ValidateScopes([]string{"openid", "offline_access", "ledger:read"}, registered))
+	require.NoError(t, auth.ValidateScopes([]string{"ledger:*", "*:read"}, registered))
+	require.EqualError(t, auth.ValidateScopes([]string{"ledger:read", "payments:read", "wallets:read"}, registered),
+		"unknown scopes: payments:read, wallets:read")
+}
+
+func TestMatchScope(t *testing.T) {
+	for _, tc := range []struct {
+		pattern string
+		scope   string
+		match   bool
+	}{
+		{"ledger:read", "ledger:read", true},
+		{"ledger:read", "ledger:write", false},
+		{"ledger:*", "ledger:read", true},
+		{"ledger:*", "ledger:transactions:read", true},
+		{"ledger:*", "ledger", false},
+		{"ledger:*", "payments:read", false},
+		{"*:read", "ledger:read", true},
+		{"*:read", "ledger:write", false},
+		{"*:read", "ledger:transactions:read", false},
+		{"*", "ledger:read", true},
+	} {
+		require.Equal(t, tc.match, auth.MatchScope(tc.pattern, tc.scope), "%s %s", tc.pattern, tc.scope)
+	}
+}
+
+func TestExpandScopes(t *testing.T) {
+	registered := []auth.Scope{
+		{ScopeOptions: auth.ScopeOptions{Name: "ledger:read"}},
+		{ScopeOptions: auth.ScopeOptions{Name: "ledger:write", Implies: []string{"ledger:read"}}},
+		{ScopeOptions: auth.ScopeOptions{Name: "payments:read"}},
+		{ScopeOptions: auth.ScopeOptions{Name: "payments:write", Implies: []string{"payments:read"}}},
+		{ScopeOptions: auth.ScopeOptions{Name: "admin", Implies: []string{"*:write"}}},
+	}
+
+	require.Equal(t, []string{"openid", "ledger:write", "ledger:read"},
+		auth.ExpandScopes([]string{"openid", "ledger:write"}, registered))
+	require.Equal(t, []string{"ledger:read", "ledger:write"},
+		auth.ExpandScopes([]string{"ledger:*"}, registered))
+	require.Equal(t, []string{"ledger:read", "payments:read"},
+		auth.ExpandScopes([]string{"*:read"}, registered))
+	require.Equal(t, []string{"admin", "ledger:write", "payments:write", "ledger:read", "payments:read"},
+		auth.ExpandScopes([]string{"admin"}, registered))
+	require.Empty(t, auth.ExpandScopes([]string{"wallets:*"}, registered))
+}
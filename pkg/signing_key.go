@@ -0,0 +1,26 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type SigningKey struct {
+	bun.BaseModel `bun:"table:signing_keys"`
+
+	ID          string `bun:",pk"`
+	Algorithm   string
+	PrivateKey  string
+	CreatedAt   time.Time
+	ActivatedAt *time.Time
+	RetiredAt   *time.Time
+}
+
+func (k *SigningKey) IsActive() bool {
+	return k.ActivatedAt != nil && k.RetiredAt == nil
+}
+
+func (k *SigningKey) IsRetired() bool {
+	return k.RetiredAt != nil
+}
@@ -8,28 +8,17 @@ import (
 	"github.com/uptrace/bun"
 )
 
-const (
-	Wallets        = "wallets"
-	Orchestration  = "orchestration"
-	Ledger         = "ledger"
-	Payments       = "payments"
-	Webhooks       = "webhooks"
-	Auth           = "auth"
-	Reconciliation = "reconciliation"
-	Search         = "search"
-)
-
-type Services []string
-
-var AllServices = Services{
-	Wallets,
-	Orchestration,
-	Ledger,
-	Payments,
-	Webhooks,
-	Auth,
-	Reconciliation,
-	Search,
+// legacyServices are the services whose scopes were granted to every client before the scopes registry,
+// their scopes are registered by the migrations so the existing clients stay valid
+var legacyServices = []string{
+	"wallets",
+	"orchestration",
+	"ledger",
+	"payments",
+	"webhooks",
+	"auth",
+	"reconciliation",
+	"search",
 }
 
 func Migrate(ctx context.Context, db *bun.DB) error {
@@ -141,7 +130,7 @@ func Migrate(ctx context.Context, db *bun.DB) error {
 		migrations.Migration{
 			Up: func(ctx context.Context, db bun.IDB) error {
 				scopes := auth.Array[string]{"openid"}
-				for _, service := range AllServices {
+				for _, service := range legacyServices {
 					scopes = append(scopes, service+":read", service+":write")
 				}
 				_, err := db.ExecContext(ctx,
@@ -155,6 +144,353 @@ func Migrate(ctx context.Context, db *bun.DB) error {
 				return err
 			},
 		},
+		migrations.Migration{
+			Up: func(ctx context.Context, db bun.IDB) error {
+				_, err := db.ExecContext(ctx, `
+					CREATE TABLE IF NOT EXISTS signing_keys (
+						id text NOT NULL,
+						algorithm text,
+						private_key text,
+						created_at timestamp with time zone,
+						activated_at timestamp with time zone,
+						retired_at timestamp with time zone
+					);
+
+					ALTER TABLE ONLY signing_keys
+					ADD CONSTRAINT signing_keys_pkey PRIMARY KEY (id);
+				`)
+				return err
+			},
+		},
+		migrations.Migration{
+			Up: func(ctx context.Context, db bun.IDB) error {
+				_, err := db.ExecContext(ctx, `
+					ALTER TABLE clients
+					ADD COLUMN IF NOT EXISTS access_token_lifetime bigint NOT NULL DEFAULT 0,
+					ADD COLUMN IF NOT EXISTS id_token_lifetime bigint NOT NULL DEFAULT 0,
+					ADD COLUMN IF NOT EXISTS refresh_token_lifetime bigint NOT NULL DEFAULT 0,
+					ADD COLUMN IF NOT EXISTS refresh_token_idle_lifetime bigint NOT NULL DEFAULT 0;
+
+					ALTER TABLE refresh_tokens
+					ADD COLUMN IF NOT EXISTS absolute_expiration timestamp with time zone;
+				`)
+				return err
+			},
+		},
+		migrations.Migration{
+			Up: func(ctx context.Context, db bun.IDB) error {
+				_, err := db.ExecContext(ctx, `
+					ALTER TABLE refresh_tokens
+					ADD COLUMN IF NOT EXISTS family_id text,
+					ADD COLUMN IF NOT EXISTS rotated_at timestamp with time zone;
+
+					UPDATE refresh_tokens
+					SET family_id = id
+					WHERE family_id IS NULL;
+
+					CREATE INDEX IF NOT EXISTS refresh_tokens_family_id_idx ON refresh_tokens (family_id);
+					CREATE INDEX IF NOT EXISTS access_tokens_refresh_token_id_idx ON access_tokens (refresh_token_id);
+				`)
+				return err
+			},
+		},
+		migrations.Migration{
+			Up: func(ctx context.Context, db bun.IDB) error {
+				_, err := db.ExecContext(ctx, `
+					ALTER TABLE access_tokens
+					ADD COLUMN IF NOT EXISTS created_at timestamp with time zone;
+
+					ALTER TABLE refresh_tokens
+					ADD COLUMN IF NOT EXISTS created_at timestamp with time zone;
+				`)
+				return err
+			},
+		},
+		migrations.Migration{
+			Up: func(ctx context.Context, db bun.IDB) error {
+				_, err := db.ExecContext(ctx, `
+					ALTER TABLE clients
+					ADD COLUMN IF NOT EXISTS jwks text,
+					ADD COLUMN IF NOT EXISTS jwks_uri text NOT NULL DEFAULT '';
+				`)
+				return err
+			},
+		},
+		migrations.Migration{
+			Up: func(ctx context.Context, db bun.IDB) error {
+				_, err := db.ExecContext(ctx, `
+					ALTER TABLE clients
+					ADD COLUMN IF NOT EXISTS tls_client_auth text;
+
+					ALTER TABLE access_tokens
+					ADD COLUMN IF NOT EXISTS certificate_thumbprint text NOT NULL DEFAULT '';
+				`)
+				return err
+			},
+		},
+		migrations.Migration{
+			Up: func(ctx context.Context, db bun.IDB) error {
+				_, err := db.ExecContext(ctx, `
+					ALTER TABLE clients
+					ADD COLUMN IF NOT EXISTS require_dpop boolean NOT NULL DEFAULT false;
+
+					ALTER TABLE access_tokens
+					ADD COLUMN IF NOT EXISTS dpop_key_thumbprint text NOT NULL DEFAULT '';
+
+					ALTER TABLE refresh_tokens
+					ADD COLUMN IF NOT EXISTS dpop_key_thumbprint text NOT NULL DEFAULT '';
+				`)
+				return err
+			},
+		},
+		migrations.Migration{
+			Up: func(ctx context.Context, db bun.IDB) error {
+				_, err := db.ExecContext(ctx, `
+					ALTER TABLE clients
+					ADD COLUMN IF NOT EXISTS grant_types text;
+
+					CREATE TABLE IF NOT EXISTS device_authorizations (
+						id text NOT NULL PRIMARY KEY,
+						created_at timestamp with time zone,
+						application_id text,
+						device_code text NOT NULL UNIQUE,
+						user_code text NOT NULL UNIQUE,
+						scopes text,
+						expiration timestamp with time zone,
+						last_polled_at timestamp with time zone,
+						user_id text,
+						auth_time timestamp with time zone,
+						denied boolean NOT NULL DEFAULT false
+					);
+				`)
+				return err
+			},
+		},
+		migrations.Migration{
+			Up: func(ctx context.Context, db bun.IDB) error {
+				_, err := db.ExecContext(ctx, `
+					ALTER TABLE clients
+					ADD COLUMN IF NOT EXISTS token_exchange text;
+
+					ALTER TABLE access_tokens
+					ADD COLUMN IF NOT EXISTS actor text;
+				`)
+				return err
+			},
+		},
+		migrations.Migration{
+			Up: func(ctx context.Context, db bun.IDB) error {
+				_, err := db.ExecContext(ctx, `
+					ALTER TABLE clients
+					ADD COLUMN IF NOT EXISTS require_par boolean NOT NULL DEFAULT false;
+
+					ALTER TABLE auth_requests
+					ADD COLUMN IF NOT EXISTS request_uri_expiration timestamp with time zone;
+				`)
+				return err
+			},
+		},
+		migrations.Migration{
+			Up: func(ctx context.Context, db bun.IDB) error {
+				_, err := db.ExecContext(ctx, `
+					CREATE TABLE IF NOT EXISTS resource_servers (
+						id text PRIMARY KEY,
+						name text,
+						identifier text NOT NULL UNIQUE,
+						scopes text
+					);
+
+					ALTER TABLE auth_requests
+					ADD COLUMN IF NOT EXISTS resources text;
+				`)
+				return err
+			},
+		},
+		migrations.Migration{
+			Up: func(ctx context.Context, db bun.IDB) error {
+				_, err := db.ExecContext(ctx, `
+					CREATE TABLE IF NOT EXISTS scopes (
+						id text PRIMARY KEY,
+						name text NOT NULL UNIQUE,
+						description text NOT NULL DEFAULT '',
+						service text NOT NULL DEFAULT '',
+						requires_consent boolean NOT NULL DEFAULT false
+					);
+				`)
+				if err != nil {
+					return err
+				}
+
+				scopes := make([]auth.ScopeOptions, 0)
+				for _, service := range legacyServices {
+					scopes = append(scopes,
+						auth.ScopeOptions{Name: service + ":read", Service: service},
+						auth.ScopeOptions{Name: service + ":write", Service: service},
+					)
+				}
+
+				// register the scopes already granted to the clients
+				clientScopes := make([]string, 0)
+				if err := db.NewRaw(`
+					SELECT DISTINCT jsonb_array_elements_text(scopes::jsonb)
+					FROM clients
+					WHERE scopes IS NOT NULL AND scopes <> 'null'
+				`).Scan(ctx, &clientScopes); err != nil {
+					return err
+				}
+				for _, scope := range clientScopes {
+					if !auth.IsOpenIDScope(scope) {
+						scopes = append(scopes, auth.ScopeOptions{Name: scope})
+					}
+				}
+
+				for _, scope := range scopes {
+					if _, err := db.NewInsert().
+						Model(auth.NewScope(scope)).
+						On("CONFLICT (name) DO NOTHING").
+						Exec(ctx); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		},
+		migrations.Migration{
+			Up: func(ctx context.Context, db bun.IDB) error {
+				_, err := db.ExecContext(ctx, `
+					ALTER TABLE scopes
+					ADD COLUMN IF NOT EXISTS implies text;
+				`)
+				if err != nil {
+					return err
+				}
+
+				// the write scopes of the services imply their read scopes
+				for _, service := range legacyServices {
+					if _, err := db.NewUpdate().
+						Model((*auth.Scope)(nil)).
+						Set("implies = ?", auth.Array[string]{service + ":read"}).
+						Where("name = ?", service+":write").
+						Where("implies IS NULL").
+						Exec(ctx); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		},
+		migrations.Migration{
+			Up: func(ctx context.Context, db bun.IDB) error {
+				// the emails of the existing users were always reported as verified
+				_, err := db.ExecContext(ctx, `
+					ALTER TABLE users
+					ADD COLUMN IF NOT EXISTS email_verified boolean NOT NULL DEFAULT false,
+					ADD COLUMN IF NOT EXISTS display_name text NOT NULL DEFAULT '',
+					ADD COLUMN IF NOT EXISTS given_name text NOT NULL DEFAULT '',
+					ADD COLUMN IF NOT EXISTS family_name text NOT NULL DEFAULT '',
+					ADD COLUMN IF NOT EXISTS locale text NOT NULL DEFAULT '',
+					ADD COLUMN IF NOT EXISTS metadata text,
+					ADD COLUMN IF NOT EXISTS disabled boolean NOT NULL DEFAULT false,
+					ADD COLUMN IF NOT EXISTS created_at timestamp with time zone,
+					ADD COLUMN IF NOT EXISTS last_login_at timestamp with time zone;
+
+					UPDATE users
+					SET email_verified = true;
+				`)
+				return err
+			},
+		},
+		migrations.Migration{
+			Up: func(ctx context.Context, db bun.IDB) error {
+				_, err := db.ExecContext(ctx, `
+					CREATE TABLE IF NOT EXISTS roles (
+						id text PRIMARY KEY,
+						name text NOT NULL UNIQUE,
+						description text NOT NULL DEFAULT '',
+						scopes text
+					);
+
+					CREATE TABLE IF NOT EXISTS groups (
+						id text PRIMARY KEY,
+						name text NOT NULL UNIQUE,
+						description text NOT NULL DEFAULT '',
+						roles text
+					);
+
+					CREATE TABLE IF NOT EXISTS group_members (
+						group_id text NOT NULL,
+						user_id text NOT NULL,
+						PRIMARY KEY (group_id, user_id)
+					);
+
+					CREATE INDEX IF NOT EXISTS group_members_user_id_idx ON group_members (user_id);
+				`)
+				return err
+			},
+		},
+		migrations.Migration{
+			Up: func(ctx context.Context, db bun.IDB) error {
+				_, err := db.ExecContext(ctx, `
+					ALTER TABLE group_members
+					ADD COLUMN IF NOT EXISTS synchronized boolean NOT NULL DEFAULT false;
+				`)
+				return err
+			},
+		},
+		migrations.Migration{
+			Up: func(ctx context.Context, db bun.IDB) error {
+				// the users are identified by their subject at their identity provider,
+				// the existing users belong to the provider configured with the delegated issuer flags
+				_, err := db.ExecContext(ctx, `
+					CREATE TABLE IF NOT EXISTS identity_providers (
+						id text PRIMARY KEY,
+						name text NOT NULL UNIQUE,
+						display_name text NOT NULL DEFAULT '',
+						issuer text NOT NULL,
+						client_id text NOT NULL,
+						client_secret text NOT NULL DEFAULT '',
+						scopes text,
+						domains text,
+						groups_claim text NOT NULL DEFAULT '',
+						group_mappings text
+					);
+
+					ALTER TABLE users
+					ADD COLUMN IF NOT EXISTS provider text NOT NULL DEFAULT 'default';
+
+					ALTER TABLE ONLY users
+					DROP CONSTRAINT IF EXISTS users_subject_key;
+
+					CREATE UNIQUE INDEX IF NOT EXISTS users_provider_subject_idx ON users (provider, subject);
+
+					ALTER TABLE auth_requests
+					ADD COLUMN IF NOT EXISTS identity_provider_hint text NOT NULL DEFAULT '';
+				`)
+				return err
+			},
+		},
+		migrations.Migration{
+			Up: func(ctx context.Context, db bun.IDB) error {
+				_, err := db.ExecContext(ctx, `
+					ALTER TABLE identity_providers
+					ADD COLUMN IF NOT EXISTS provisioning text;
+				`)
+				return err
+			},
+		},
+		migrations.Migration{
+			Up: func(ctx context.Context, db bun.IDB) error {
+				_, err := db.ExecContext(ctx, `
+					CREATE TABLE IF NOT EXISTS used_jwt_ids (
+						id text PRIMARY KEY,
+						expiration timestamp with time zone NOT NULL
+					);
+
+					CREATE INDEX IF NOT EXISTS used_jwt_ids_expiration_idx ON used_jwt_ids (expiration);
+				`)
+				return err
+			},
+		},
 	)
 	return migrator.Up(ctx)
 }
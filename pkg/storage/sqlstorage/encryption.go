@@ -0,0 +1,111 @@
+package sqlstorage
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"sync"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/argon2"
+)
+
+// Parameters of the derivation of the key encryption key from its passphrase, as recommended by RFC 9106.
+// The derivation runs once per salt, the derived keys are cached.
+const (
+	kekMemory      = 64 * 1024
+	kekIterations  = 1
+	kekParallelism = 4
+	kekSaltLength  = 16
+	kekLength      = 32
+)
+
+// Encryption protects the secrets stored in database with a key encryption key derived from a passphrase.
+// The encrypted values embed the salt of the derivation, so the values encrypted by other replicas can be decrypted.
+type Encryption struct {
+	passphrase []byte
+	salt       []byte
+
+	mu    sync.Mutex
+	aeads map[string]cipher.AEAD
+}
+
+// aead returns the cipher of the key derived with a salt
+func (e *Encryption) aead(salt []byte) (cipher.AEAD, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if aead, ok := e.aeads[string(salt)]; ok {
+		return aead, nil
+	}
+
+	block, err := aes.NewCipher(argon2.IDKey(e.passphrase, salt, kekIterations, kekMemory, kekParallelism, kekLength))
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	e.aeads[string(salt)] = aead
+	return aead, nil
+}
+
+func (e *Encryption) Encrypt(data []byte) (string, error) {
+	aead, err := e.aead(e.salt)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := append(append([]byte{}, e.salt...), nonce...)
+	return base64.StdEncoding.EncodeToString(aead.Seal(sealed, nonce, data, nil)), nil
+}
+
+func (e *Encryption) Decrypt(value string) ([]byte, error) {
+	data, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < kekSaltLength {
+		return nil, errors.New("invalid encrypted value")
+	}
+
+	salt, data := data[:kekSaltLength], data[kekSaltLength:]
+	aead, err := e.aead(salt)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < aead.NonceSize() {
+		return nil, errors.New("invalid encrypted value")
+	}
+
+	nonce, ciphertext := data[:aead.NonceSize()], data[aead.NonceSize():]
+	data, err = aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "decrypting value, check the key encryption key")
+	}
+	return data, nil
+}
+
+func NewEncryption(passphrase string) (*Encryption, error) {
+	if passphrase == "" {
+		return nil, errors.New("encryption key must be defined")
+	}
+
+	salt := make([]byte, kekSaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+
+	return &Encryption{
+		passphrase: []byte(passphrase),
+		salt:       salt,
+		aeads:      map[string]cipher.AEAD{},
+	}, nil
+}
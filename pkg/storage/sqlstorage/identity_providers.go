@@ -0,0 +1,75 @@
+package sqlstorage
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/uptrace/bun"
+
+	auth "github.com/formancehq/auth/pkg"
+)
+
+// encryptedSecretPrefix marks the encrypted client secrets of the identity providers,
+// the secrets stored without key encryption key are kept in plaintext
+const encryptedSecretPrefix = "encrypted:"
+
+// EncryptIdentityProviderSecret encrypts the client secret of an identity provider before it is stored,
+// the secret is stored in plaintext if no key encryption key is defined
+func EncryptIdentityProviderSecret(encryption *Encryption, secret string) (string, error) {
+	if encryption == nil || secret == "" {
+		return secret, nil
+	}
+	encrypted, err := encryption.Encrypt([]byte(secret))
+	if err != nil {
+		return "", err
+	}
+	return encryptedSecretPrefix + encrypted, nil
+}
+
+// decryptIdentityProviderSecret decrypts the client secret of an identity provider read from the database,
+// the secrets stored in plaintext are returned as is
+func decryptIdentityProviderSecret(encryption *Encryption, value string) (string, error) {
+	encrypted, found := strings.CutPrefix(value, encryptedSecretPrefix)
+	if !found {
+		return value, nil
+	}
+	if encryption == nil {
+		return "", errors.New("identity provider client secret is encrypted, key encryption key must be defined")
+	}
+	secret, err := encryption.Decrypt(encrypted)
+	if err != nil {
+		return "", err
+	}
+	return string(secret), nil
+}
+
+// upgradeIdentityProviderSecrets encrypts the client secrets of the identity providers stored in plaintext
+func upgradeIdentityProviderSecrets(ctx context.Context, db *bun.DB, encryption *Encryption) error {
+	return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		identityProviders := make([]auth.IdentityProvider, 0)
+		if err := tx.NewSelect().
+			Model(&identityProviders).
+			Where("client_secret != ''").
+			Where("client_secret not like ?", encryptedSecretPrefix+"%").
+			For("UPDATE").
+			Scan(ctx); err != nil {
+			return err
+		}
+
+		for _, identityProvider := range identityProviders {
+			secret, err := EncryptIdentityProviderSecret(encryption, identityProvider.ClientSecret)
+			if err != nil {
+				return err
+			}
+			if _, err := tx.NewUpdate().
+				Model(&auth.IdentityProvider{}).
+				Set("client_secret = ?", secret).
+				Where("id = ?", identityProvider.ID).
+				Exec(ctx); err != nil {
+				return errors.Wrapf(err, "encrypting the client secret of identity provider %s", identityProvider.Name)
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,74 @@
+package sqlstorage
+
+import (
+	"strings"
+	"testing"
+
+	auth "github.com/formancehq/auth/pkg"
+	"github.com/formancehq/go-libs/v3/bun/bunconnect"
+	"github.com/formancehq/go-libs/v3/logging"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdentityProviderSecretEncryption(t *testing.T) {
+	t.Parallel()
+
+	ctx := logging.TestingContext()
+
+	db, err := bunconnect.OpenSQLDB(ctx, bunconnect.ConnectionOptions{
+		DatabaseSourceName: srv.NewDatabase(t).ConnString(),
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	require.NoError(t, Migrate(ctx, db))
+
+	// The secrets stored before the key encryption key is defined are kept in plaintext
+	legacy := auth.NewIdentityProvider(auth.IdentityProviderOptions{
+		Name:         "legacy",
+		Issuer:       "https://accounts.legacy.com",
+		ClientID:     "auth",
+		ClientSecret: "legacy-secret",
+	})
+	require.NoError(t, New(db, nil).SaveIdentityProvider(ctx, legacy))
+
+	encryption, err := NewEncryption("foo")
+	require.NoError(t, err)
+	store := New(db, encryption)
+
+	partner := auth.NewIdentityProvider(auth.IdentityProviderOptions{
+		Name:         "partner",
+		Issuer:       "https://accounts.partner.com",
+		ClientID:     "auth",
+		ClientSecret: "partner-secret",
+	})
+	require.NoError(t, store.SaveIdentityProvider(ctx, partner))
+	require.Equal(t, "partner-secret", partner.ClientSecret)
+
+	storedSecrets := func() map[string]string {
+		identityProviders := make([]auth.IdentityProvider, 0)
+		require.NoError(t, db.NewSelect().Model(&identityProviders).Scan(ctx))
+		ret := map[string]string{}
+		for _, identityProvider := range identityProviders {
+			ret[identityProvider.Name] = identityProvider.ClientSecret
+		}
+		return ret
+	}
+	require.Equal(t, "legacy-secret", storedSecrets()["legacy"])
+	require.True(t, strings.HasPrefix(storedSecrets()["partner"], encryptedSecretPrefix))
+
+	// The secrets stored in plaintext are encrypted on startup
+	require.NoError(t, upgradeIdentityProviderSecrets(ctx, db, encryption))
+	for _, secret := range storedSecrets() {
+		require.True(t, strings.HasPrefix(secret, encryptedSecretPrefix))
+	}
+
+	identityProviders, err := store.ListIdentityProviders(ctx)
+	require.NoError(t, err)
+	require.Len(t, identityProviders, 2)
+	require.Equal(t, "legacy-secret", identityProviders[0].ClientSecret)
+	require.Equal(t, "partner-secret", identityProviders[1].ClientSecret)
+
+	// The encrypted secrets can not be read without the key encryption key
+	_, err = New(db, nil).ListIdentityProviders(ctx)
+	require.Error(t, err)
+}
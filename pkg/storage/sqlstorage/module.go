@@ -2,7 +2,6 @@ package sqlstorage
 
 import (
 	"context"
-	"crypto/rsa"
 
 	"github.com/formancehq/go-libs/v3/logging"
 	"github.com/uptrace/bun"
@@ -10,13 +9,15 @@ import (
 	"github.com/formancehq/go-libs/v3/bun/bunconnect"
 
 	auth "github.com/formancehq/auth/pkg"
+	"github.com/formancehq/auth/pkg/delegatedauth"
 	"github.com/formancehq/auth/pkg/oidc"
 	"github.com/formancehq/go-libs/v3/health"
 	"github.com/zitadel/oidc/v2/pkg/op"
 	"go.uber.org/fx"
 )
 
-func Module(connectionOptions bunconnect.ConnectionOptions, key *rsa.PrivateKey, debug bool, staticClients ...auth.StaticClient) fx.Option {
+// Module stores the data in database, the client secrets of the identity providers are encrypted if encryption is not nil
+func Module(connectionOptions bunconnect.ConnectionOptions, keyRing *oidc.KeyRing, encryption *Encryption, debug bool, staticClients ...auth.StaticClient) fx.Option {
 	return fx.Options(
 		bunconnect.Module(connectionOptions, debug),
 		fx.Invoke(func(lc fx.Lifecycle, db *bun.DB) {
@@ -24,14 +25,24 @@ func Module(connectionOptions bunconnect.ConnectionOptions, key *rsa.PrivateKey,
 				OnStart: func(ctx context.Context) error {
 					logging.FromContext(ctx).Info("Migrate tables")
 
-					return Migrate(ctx, db)
+					if err := Migrate(ctx, db); err != nil {
+						return err
+					}
+					if encryption == nil {
+						return nil
+					}
+					return upgradeIdentityProviderSecrets(ctx, db, encryption)
 				},
 			})
 		}),
-		fx.Supply(key),
+		fx.Supply(keyRing),
 		fx.Supply(staticClients),
+		fx.Provide(func() *Encryption {
+			return encryption
+		}),
 		fx.Provide(fx.Annotate(New,
 			fx.As(new(oidc.Storage)),
+			fx.As(new(delegatedauth.ProviderStorage)),
 		)),
 		health.ProvideHealthCheck(func(storage op.Storage) health.NamedCheck {
 			return health.NewNamedCheck("Database", health.CheckFn(storage.Health))
@@ -0,0 +1,195 @@
+package sqlstorage
+
+import (
+	"context"
+	"time"
+
+	auth "github.com/formancehq/auth/pkg"
+	"github.com/formancehq/go-libs/v3/logging"
+	"github.com/pkg/errors"
+	"github.com/uptrace/bun"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+	"go.uber.org/fx"
+)
+
+const (
+	DefaultPurgeInterval        = time.Hour
+	DefaultPurgeBatchSize       = 1000
+	DefaultAuthRequestRetention = time.Hour
+)
+
+type PurgeConfig struct {
+	// Interval is the delay between two purges
+	Interval time.Duration
+	// BatchSize is the maximum number of rows deleted by a single query, to avoid long locks on the tables
+	BatchSize int
+	// AuthRequestRetention is the delay after which an auth request is deleted, whether it has been consumed or abandoned
+	AuthRequestRetention time.Duration
+}
+
+// Validate checks the configuration, the zero values are replaced by the defaults
+func (c PurgeConfig) Validate() error {
+	if c.Interval < 0 {
+		return errors.Errorf("invalid purge interval %s, it must not be negative", c.Interval)
+	}
+	if c.BatchSize < 0 {
+		return errors.Errorf("invalid purge batch size %d, it must not be negative", c.BatchSize)
+	}
+	if c.AuthRequestRetention < 0 {
+		return errors.Errorf("invalid auth request retention %s, it must not be negative", c.AuthRequestRetention)
+	}
+	return nil
+}
+
+type PurgeResult struct {
+	AccessTokens         int64
+	RefreshTokens        int64
+	AuthRequests         int64
+	DeviceAuthorizations int64
+	UsedJWTIDs           int64
+}
+
+// Purger deletes the expired tokens, the stale auth requests, the expired device authorizations
+// and the ids of the expired single use JWTs
+type Purger struct {
+	db          *bun.DB
+	config      PurgeConfig
+	deletedRows metric.Int64Counter
+}
+
+func (p *Purger) purgeTable(ctx context.Context, model any, table string, where string, args ...any) (int64, error) {
+	total := int64(0)
+	for {
+		ret, err := p.db.NewDelete().
+			Model(model).
+			Where("id in (?)", p.db.NewSelect().
+				Model(model).
+				Column("id").
+				Where(where, args...).
+				Limit(p.config.BatchSize)).
+			Exec(ctx)
+		if err != nil {
+			return total, errors.Wrapf(err, "purging %s", table)
+		}
+		rowsAffected, err := ret.RowsAffected()
+		if err != nil {
+			return total, err
+		}
+
+		total += rowsAffected
+		p.deletedRows.Add(ctx, rowsAffected, metric.WithAttributes(attribute.String("table", table)))
+
+		if rowsAffected < int64(p.config.BatchSize) {
+			return total, nil
+		}
+	}
+}
+
+// Purge deletes, by batches, the expired access tokens, the expired refresh tokens, the auth requests older than the retention
+// the expired device authorizations and the ids of the expired single use JWTs.
+// Rotated refresh tokens are kept until the absolute expiration of their family, so a replay can still be detected.
+func (p *Purger) Purge(ctx context.Context) (*PurgeResult, error) {
+	var (
+		now    = time.Now()
+		result = &PurgeResult{}
+		err    error
+	)
+
+	result.AccessTokens, err = p.purgeTable(ctx, (*auth.AccessToken)(nil), "access_tokens",
+		"expiration < ?", now)
+	if err != nil {
+		return nil, err
+	}
+
+	result.RefreshTokens, err = p.purgeTable(ctx, (*auth.RefreshToken)(nil), "refresh_tokens",
+		"expiration < ? and (rotated_at is null or coalesce(absolute_expiration, expiration) < ?)", now, now)
+	if err != nil {
+		return nil, err
+	}
+
+	result.AuthRequests, err = p.purgeTable(ctx, (*auth.AuthRequest)(nil), "auth_requests",
+		"created_at < ?", now.Add(-p.config.AuthRequestRetention))
+	if err != nil {
+		return nil, err
+	}
+
+	result.DeviceAuthorizations, err = p.purgeTable(ctx, (*auth.DeviceAuthorization)(nil), "device_authorizations",
+		"expiration < ?", now)
+	if err != nil {
+		return nil, err
+	}
+
+	result.UsedJWTIDs, err = p.purgeTable(ctx, (*auth.UsedJWTID)(nil), "used_jwt_ids",
+		"expiration < ?", now)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Run purges the tables periodically until the context is canceled
+func (p *Purger) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			result, err := p.Purge(ctx)
+			if err != nil {
+				logging.FromContext(ctx).Errorf("error while purging tables: %s", err)
+				continue
+			}
+			logging.FromContext(ctx).Debugf("Purged %d access tokens, %d refresh tokens, %d auth requests, %d device authorizations and %d JWT ids",
+				result.AccessTokens, result.RefreshTokens, result.AuthRequests, result.DeviceAuthorizations, result.UsedJWTIDs)
+		}
+	}
+}
+
+func NewPurger(db *bun.DB, meterProvider metric.MeterProvider, config PurgeConfig) (*Purger, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if config.Interval == 0 {
+		config.Interval = DefaultPurgeInterval
+	}
+	if config.BatchSize == 0 {
+		config.BatchSize = DefaultPurgeBatchSize
+	}
+	if config.AuthRequestRetention == 0 {
+		config.AuthRequestRetention = DefaultAuthRequestRetention
+	}
+	if meterProvider == nil {
+		meterProvider = noop.NewMeterProvider()
+	}
+
+	deletedRows, err := meterProvider.Meter("auth").Int64Counter("auth.purge.deleted_rows",
+		metric.WithDescription("Number of rows deleted by the purge, by table"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Purger{
+		db:          db,
+		config:      config,
+		deletedRows: deletedRows,
+	}, nil
+}
+
+// PurgeModule purges the expired tokens and the stale auth requests in background.
+// It must be registered after Module so the tables are migrated first.
+func PurgeModule(config PurgeConfig) fx.Option {
+	return fx.Options(
+		fx.Provide(fx.Annotate(func(db *bun.DB, meterProvider metric.MeterProvider) (*Purger, error) {
+			return NewPurger(db, meterProvider, config)
+		}, fx.ParamTags(``, `optional:"true"`))),
+		fx.Invoke(func(lc fx.Lifecycle, purger *Purger) {
+			runInBackground(lc, nil, purger.Run)
+		}),
+	)
+}
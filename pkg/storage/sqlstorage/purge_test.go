@@ -0,0 +1,129 @@
+package sqlstorage
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	auth "github.com/formancehq/auth/pkg"
+	"github.com/formancehq/auth/pkg/storage"
+	"github.com/formancehq/go-libs/v3/bun/bunconnect"
+	"github.com/formancehq/go-libs/v3/logging"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPurgeConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	// The zero values are replaced by the defaults
+	require.NoError(t, PurgeConfig{}.Validate())
+	require.NoError(t, PurgeConfig{
+		Interval:             time.Hour,
+		BatchSize:            100,
+		AuthRequestRetention: time.Hour,
+	}.Validate())
+
+	require.Error(t, PurgeConfig{Interval: -time.Hour}.Validate())
+	require.Error(t, PurgeConfig{BatchSize: -1}.Validate())
+	require.Error(t, PurgeConfig{AuthRequestRetention: -time.Hour}.Validate())
+}
+
+func TestPurge(t *testing.T) {
+	t.Parallel()
+
+	ctx := logging.TestingContext()
+
+	db, err := bunconnect.OpenSQLDB(ctx, bunconnect.ConnectionOptions{
+		DatabaseSourceName: srv.NewDatabase(t).ConnString(),
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	require.NoError(t, Migrate(ctx, db))
+
+	store := New(db, nil)
+	now := time.Now()
+	rotatedAt := now.Add(-time.Hour)
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, store.SaveAccessToken(ctx, &auth.AccessToken{
+			ID:         fmt.Sprintf("expired-%d", i),
+			Expiration: now.Add(-time.Minute),
+		}))
+	}
+	require.NoError(t, store.SaveAccessToken(ctx, &auth.AccessToken{
+		ID:         "valid",
+		Expiration: now.Add(time.Minute),
+	}))
+
+	require.NoError(t, store.SaveRefreshToken(ctx, &auth.RefreshToken{
+		ID:                 "expired",
+		FamilyID:           "expired",
+		Expiration:         now.Add(-time.Minute),
+		AbsoluteExpiration: now.Add(-time.Minute),
+	}))
+	require.NoError(t, store.SaveRefreshToken(ctx, &auth.RefreshToken{
+		ID:                 "rotated",
+		FamilyID:           "family",
+		Expiration:         now.Add(-time.Minute),
+		AbsoluteExpiration: now.Add(time.Hour),
+		RotatedAt:          &rotatedAt,
+	}))
+
+	require.NoError(t, store.SaveAuthRequest(ctx, &auth.AuthRequest{
+		ID:        "stale",
+		CreatedAt: now.Add(-2 * time.Hour),
+	}))
+	require.NoError(t, store.SaveAuthRequest(ctx, &auth.AuthRequest{
+		ID:        "pending",
+		CreatedAt: now,
+	}))
+
+	require.NoError(t, store.SaveDeviceAuthorization(ctx, &auth.DeviceAuthorization{
+		ID:         "expired",
+		DeviceCode: "expired",
+		UserCode:   "EXPIRED",
+		Expiration: now.Add(-time.Minute),
+	}))
+	require.NoError(t, store.SaveDeviceAuthorization(ctx, &auth.DeviceAuthorization{
+		ID:         "pending",
+		DeviceCode: "pending",
+		UserCode:   "PENDING",
+		Expiration: now.Add(time.Minute),
+	}))
+
+	used, err := store.UseJWTID(ctx, "expired", now.Add(-time.Minute), now.Add(-2*time.Minute))
+	require.NoError(t, err)
+	require.True(t, used)
+	used, err = store.UseJWTID(ctx, "valid", now.Add(time.Minute), now)
+	require.NoError(t, err)
+	require.True(t, used)
+
+	purger, err := NewPurger(db, nil, PurgeConfig{
+		BatchSize: 2,
+	})
+	require.NoError(t, err)
+
+	result, err := purger.Purge(ctx)
+	require.NoError(t, err)
+	require.Equal(t, &PurgeResult{
+		AccessTokens:         3,
+		RefreshTokens:        1,
+		AuthRequests:         1,
+		DeviceAuthorizations: 1,
+		UsedJWTIDs:           1,
+	}, result)
+
+	_, err = store.FindAccessToken(ctx, "valid")
+	require.NoError(t, err)
+	_, err = store.FindRefreshToken(ctx, "rotated")
+	require.NoError(t, err)
+	_, err = store.FindRefreshToken(ctx, "expired")
+	require.ErrorIs(t, err, storage.ErrNotFound)
+	_, err = store.FindAuthRequest(ctx, "pending")
+	require.NoError(t, err)
+	_, err = store.FindDeviceAuthorization(ctx, "pending")
+	require.NoError(t, err)
+	used, err = store.UseJWTID(ctx, "valid", now.Add(time.Minute), now)
+	require.NoError(t, err)
+	require.False(t, used)
+}
@@ -0,0 +1,321 @@
+package sqlstorage
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"time"
+
+	auth "github.com/formancehq/auth/pkg"
+	"github.com/formancehq/auth/pkg/oidc"
+	"github.com/formancehq/go-libs/v3/logging"
+	"github.com/pkg/errors"
+	"github.com/uptrace/bun"
+	"go.uber.org/fx"
+	"gopkg.in/go-jose/go-jose.v2"
+)
+
+const (
+	// signingKeysLockID is the postgres advisory lock taken while rotating keys,
+	// so several replicas do not promote different keys at the same time
+	signingKeysLockID = 7_638_001
+
+	DefaultSigningKeysRefreshInterval = time.Minute
+)
+
+type SigningKeysConfig struct {
+	// EncryptionKey is the key encryption key used to protect the private keys at rest
+	EncryptionKey string
+	// Algorithm is the signature algorithm of the generated keys
+	Algorithm jose.SignatureAlgorithm
+	// RotationInterval is the delay after which the active key is replaced by a new one
+	RotationInterval time.Duration
+	// Retention is the delay a retired key stays published to verify tokens signed before the rotation,
+	// at least TokenLifetime and raised to the lifetimes of the clients stored in database
+	Retention time.Duration
+	// TokenLifetime is the longest lifetime of the tokens signed with the configured lifetimes,
+	// the lifetimes of the clients stored in database are also taken into account
+	TokenLifetime time.Duration
+	// RefreshInterval is the delay between two checks of the rotation schedule
+	RefreshInterval time.Duration
+	// InitialKey, if defined, is imported as the active key when the database does not contain any key
+	InitialKey crypto.Signer
+}
+
+// Validate checks the rotation schedule, so the keys are not rotated on each refresh
+// and the retired keys stay published while the tokens they signed are valid
+func (c SigningKeysConfig) Validate() error {
+	if c.RotationInterval <= 0 {
+		return errors.Errorf("invalid signing key rotation interval %s, it must be positive", c.RotationInterval)
+	}
+	if c.Retention < c.TokenLifetime {
+		return errors.Errorf("invalid signing key retention %s, it must be at least the longest token lifetime %s", c.Retention, c.TokenLifetime)
+	}
+	return nil
+}
+
+type SigningKeyManager struct {
+	db         *bun.DB
+	keyRing    *oidc.KeyRing
+	encryption *Encryption
+	config     SigningKeysConfig
+}
+
+func (m *SigningKeyManager) encrypt(key crypto.Signer) (string, error) {
+	data, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return "", err
+	}
+
+	return m.encryption.Encrypt(data)
+}
+
+func (m *SigningKeyManager) decrypt(value string) (crypto.Signer, error) {
+	data, err := m.encryption.Decrypt(value)
+	if err != nil {
+		return nil, errors.Wrap(err, "decrypting signing key")
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(data)
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, errors.Errorf("unsupported key type: %T", key)
+	}
+	return signer, nil
+}
+
+func (m *SigningKeyManager) newSigningKey(key crypto.Signer, now time.Time) (*auth.SigningKey, error) {
+	id, err := oidc.KeyThumbprint(key.Public())
+	if err != nil {
+		return nil, err
+	}
+
+	algorithm, err := oidc.SignatureAlgorithm(key.Public())
+	if err != nil {
+		return nil, err
+	}
+
+	encrypted, err := m.encrypt(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &auth.SigningKey{
+		ID:         id,
+		Algorithm:  string(algorithm),
+		PrivateKey: encrypted,
+		CreatedAt:  now,
+	}, nil
+}
+
+func (m *SigningKeyManager) generateSigningKey(now time.Time) (*auth.SigningKey, error) {
+	key, err := oidc.GenerateSigningKey(m.config.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.newSigningKey(key, now)
+}
+
+// retention returns the delay a retired key stays published, so the tokens it signed can be verified until they expire
+func (m *SigningKeyManager) retention(ctx context.Context, tx bun.Tx) (time.Duration, error) {
+	var clientsLifetime int64
+	if err := tx.NewSelect().
+		Model((*auth.Client)(nil)).
+		ColumnExpr("coalesce(max(greatest(access_token_lifetime, id_token_lifetime)), 0)").
+		Scan(ctx, &clientsLifetime); err != nil {
+		return 0, err
+	}
+
+	return max(m.config.Retention, m.config.TokenLifetime, time.Duration(clientsLifetime)*time.Second), nil
+}
+
+// Rotate applies the rotation schedule and reloads the key ring.
+// A pending key is always generated in advance, so it is published in the JWKS
+// for a full rotation interval before being used to sign tokens.
+func (m *SigningKeyManager) Rotate(ctx context.Context) error {
+	keys := make([]auth.SigningKey, 0)
+	err := m.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock(?)", signingKeysLockID); err != nil {
+			return err
+		}
+
+		now := time.Now()
+
+		retention, err := m.retention(ctx, tx)
+		if err != nil {
+			return err
+		}
+		_, err = tx.NewDelete().
+			Model(&auth.SigningKey{}).
+			Where("retired_at < ?", now.Add(-retention)).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.NewSelect().
+			Model(&keys).
+			Order("created_at").
+			Scan(ctx); err != nil {
+			return err
+		}
+
+		var active, pending *auth.SigningKey
+		for i := range keys {
+			switch {
+			case keys[i].IsRetired():
+			case keys[i].IsActive():
+				active = &keys[i]
+			case pending == nil:
+				pending = &keys[i]
+			}
+		}
+
+		if active == nil && pending == nil && m.config.InitialKey != nil {
+			pending, err = m.newSigningKey(m.config.InitialKey, now)
+			if err != nil {
+				return err
+			}
+			if _, err := tx.NewInsert().Model(pending).Exec(ctx); err != nil {
+				return err
+			}
+			logging.FromContext(ctx).Infof("Imported signing key %s", pending.ID)
+		}
+
+		if active == nil || now.Sub(*active.ActivatedAt) >= m.config.RotationInterval {
+			if pending == nil {
+				pending, err = m.generateSigningKey(now)
+				if err != nil {
+					return err
+				}
+				if _, err := tx.NewInsert().Model(pending).Exec(ctx); err != nil {
+					return err
+				}
+			}
+			if active != nil {
+				active.RetiredAt = &now
+				if _, err := tx.NewUpdate().
+					Model(active).
+					Column("retired_at").
+					WherePK().
+					Exec(ctx); err != nil {
+					return err
+				}
+			}
+			pending.ActivatedAt = &now
+			if _, err := tx.NewUpdate().
+				Model(pending).
+				Column("activated_at").
+				WherePK().
+				Exec(ctx); err != nil {
+				return err
+			}
+			logging.FromContext(ctx).Infof("Promoted signing key %s", pending.ID)
+
+			pending = nil
+		}
+
+		if pending == nil {
+			pending, err = m.generateSigningKey(now)
+			if err != nil {
+				return err
+			}
+			if _, err := tx.NewInsert().Model(pending).Exec(ctx); err != nil {
+				return err
+			}
+		}
+
+		keys = keys[:0]
+		return tx.NewSelect().
+			Model(&keys).
+			Order("created_at").
+			Scan(ctx)
+	})
+	if err != nil {
+		return errors.Wrap(err, "rotating signing keys")
+	}
+
+	return m.load(keys)
+}
+
+func (m *SigningKeyManager) load(keys []auth.SigningKey) error {
+	var active crypto.Signer
+	verificationKeys := make([]crypto.PublicKey, 0, len(keys))
+	for _, key := range keys {
+		signer, err := m.decrypt(key.PrivateKey)
+		if err != nil {
+			return errors.Wrapf(err, "loading signing key %s", key.ID)
+		}
+		if key.IsActive() {
+			active = signer
+			continue
+		}
+		verificationKeys = append(verificationKeys, signer.Public())
+	}
+	if active == nil {
+		return errors.New("no active signing key")
+	}
+
+	return m.keyRing.Set(active, verificationKeys...)
+}
+
+// Run checks the rotation schedule periodically until the context is canceled
+func (m *SigningKeyManager) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.config.RefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := m.Rotate(ctx); err != nil {
+				logging.FromContext(ctx).Errorf("error while rotating signing keys: %s", err)
+			}
+		}
+	}
+}
+
+func NewSigningKeyManager(db *bun.DB, keyRing *oidc.KeyRing, config SigningKeysConfig) (*SigningKeyManager, error) {
+	if config.EncryptionKey == "" {
+		return nil, errors.New("signing keys encryption key must be defined")
+	}
+	if config.Algorithm == "" {
+		config.Algorithm = jose.RS256
+	}
+	if config.RefreshInterval == 0 {
+		config.RefreshInterval = DefaultSigningKeysRefreshInterval
+	}
+
+	encryption, err := NewEncryption(config.EncryptionKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SigningKeyManager{
+		db:         db,
+		keyRing:    keyRing,
+		encryption: encryption,
+		config:     config,
+	}, nil
+}
+
+// SigningKeysModule stores the signing keys in database and rotates them on schedule.
+// It must be registered after Module so the tables are migrated first.
+func SigningKeysModule(config SigningKeysConfig) fx.Option {
+	return fx.Options(
+		fx.Provide(func(db *bun.DB, keyRing *oidc.KeyRing) (*SigningKeyManager, error) {
+			return NewSigningKeyManager(db, keyRing, config)
+		}),
+		fx.Invoke(func(lc fx.Lifecycle, manager *SigningKeyManager) {
+			runInBackground(lc, func(ctx context.Context) error {
+				logging.FromContext(ctx).Info("Load signing keys")
+				return manager.Rotate(ctx)
+			}, manager.Run)
+		}),
+	)
+}
@@ -0,0 +1,196 @@
+package sqlstorage
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+	"time"
+
+	auth "github.com/formancehq/auth/pkg"
+	"github.com/formancehq/auth/pkg/oidc"
+	"github.com/formancehq/go-libs/v3/bun/bunconnect"
+	"github.com/formancehq/go-libs/v3/logging"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSigningKeyEncryption(t *testing.T) {
+	t.Parallel()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	keyRing, err := oidc.NewKeyRing(key)
+	require.NoError(t, err)
+
+	manager, err := NewSigningKeyManager(nil, keyRing, SigningKeysConfig{
+		EncryptionKey: "foo",
+	})
+	require.NoError(t, err)
+
+	encrypted, err := manager.encrypt(key)
+	require.NoError(t, err)
+	require.NotContains(t, encrypted, "PRIVATE")
+
+	decrypted, err := manager.decrypt(encrypted)
+	require.NoError(t, err)
+	require.True(t, key.Equal(decrypted))
+
+	otherManager, err := NewSigningKeyManager(nil, keyRing, SigningKeysConfig{
+		EncryptionKey: "bar",
+	})
+	require.NoError(t, err)
+
+	_, err = otherManager.decrypt(encrypted)
+	require.Error(t, err)
+}
+
+func TestEncryption(t *testing.T) {
+	t.Parallel()
+
+	encryption, err := NewEncryption("foo")
+	require.NoError(t, err)
+	encrypted, err := encryption.Encrypt([]byte("secret"))
+	require.NoError(t, err)
+	require.NotContains(t, encrypted, "secret")
+
+	// The values encrypted by other replicas, with other salts, are decrypted
+	otherEncryption, err := NewEncryption("foo")
+	require.NoError(t, err)
+	decrypted, err := otherEncryption.Decrypt(encrypted)
+	require.NoError(t, err)
+	require.Equal(t, "secret", string(decrypted))
+
+	otherEncryption, err = NewEncryption("bar")
+	require.NoError(t, err)
+	_, err = otherEncryption.Decrypt(encrypted)
+	require.Error(t, err)
+}
+
+func TestSigningKeysConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	config := SigningKeysConfig{
+		RotationInterval: 30 * 24 * time.Hour,
+		Retention:        24 * time.Hour,
+		TokenLifetime:    time.Hour,
+	}
+	require.NoError(t, config.Validate())
+
+	// The keys would be rotated on each refresh
+	invalid := config
+	invalid.RotationInterval = 0
+	require.Error(t, invalid.Validate())
+	invalid.RotationInterval = -time.Hour
+	require.Error(t, invalid.Validate())
+
+	// The retired keys would be deleted while the tokens they signed are valid
+	invalid = config
+	invalid.Retention = 30 * time.Minute
+	require.Error(t, invalid.Validate())
+}
+
+func TestSigningKeysRotation(t *testing.T) {
+	t.Parallel()
+
+	ctx := logging.TestingContext()
+
+	db, err := bunconnect.OpenSQLDB(ctx, bunconnect.ConnectionOptions{
+		DatabaseSourceName: srv.NewDatabase(t).ConnString(),
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	require.NoError(t, Migrate(ctx, db))
+
+	initialKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	keyRing, err := oidc.NewKeyRing(initialKey)
+	require.NoError(t, err)
+
+	manager, err := NewSigningKeyManager(db, keyRing, SigningKeysConfig{
+		EncryptionKey:    "foo",
+		RotationInterval: time.Hour,
+		Retention:        time.Hour,
+		InitialKey:       initialKey,
+	})
+	require.NoError(t, err)
+
+	listKeys := func() (active, pending, retired []auth.SigningKey) {
+		keys := make([]auth.SigningKey, 0)
+		require.NoError(t, db.NewSelect().Model(&keys).Order("created_at").Scan(ctx))
+		for _, key := range keys {
+			switch {
+			case key.IsRetired():
+				retired = append(retired, key)
+			case key.IsActive():
+				active = append(active, key)
+			default:
+				pending = append(pending, key)
+			}
+		}
+		return
+	}
+	backdate := func(column, id string) {
+		_, err := db.NewUpdate().
+			Model(&auth.SigningKey{}).
+			Set(column+" = ?", time.Now().Add(-2*time.Hour)).
+			Where("id = ?", id).
+			Exec(ctx)
+		require.NoError(t, err)
+	}
+
+	// The initial key is imported as the active key and a pending key is published in advance
+	require.NoError(t, manager.Rotate(ctx))
+	active, pending, retired := listKeys()
+	require.Len(t, active, 1)
+	require.Len(t, pending, 1)
+	require.Empty(t, retired)
+	initialKeyID, err := oidc.KeyThumbprint(initialKey.Public())
+	require.NoError(t, err)
+	require.Equal(t, initialKeyID, active[0].ID)
+	require.Equal(t, initialKeyID, keyRing.SigningKey().ID())
+	require.Len(t, keyRing.PublicKeys(), 2)
+
+	// Nothing changes before the end of the rotation interval
+	require.NoError(t, manager.Rotate(ctx))
+	nextActive, nextPending, _ := listKeys()
+	require.Equal(t, active[0].ID, nextActive[0].ID)
+	require.Equal(t, pending[0].ID, nextPending[0].ID)
+
+	// The pending key is promoted and the active key retired, but still published
+	backdate("activated_at", active[0].ID)
+	require.NoError(t, manager.Rotate(ctx))
+	nextActive, nextPending, retired = listKeys()
+	require.Len(t, nextActive, 1)
+	require.Equal(t, pending[0].ID, nextActive[0].ID)
+	require.Len(t, nextPending, 1)
+	require.NotEqual(t, pending[0].ID, nextPending[0].ID)
+	require.Len(t, retired, 1)
+	require.Equal(t, active[0].ID, retired[0].ID)
+	require.Equal(t, pending[0].ID, keyRing.SigningKey().ID())
+	require.Len(t, keyRing.PublicKeys(), 3)
+
+	// The retired key is kept while the tokens it signed are valid
+	backdate("retired_at", retired[0].ID)
+	manager.config.TokenLifetime = 3 * time.Hour
+	require.NoError(t, manager.Rotate(ctx))
+	_, _, retired = listKeys()
+	require.Len(t, retired, 1)
+
+	// The retired key is kept while the tokens of the clients with longer lifetimes it signed are valid
+	manager.config.TokenLifetime = 0
+	client := auth.NewClient(auth.ClientOptions{
+		AccessTokenLifetime: int64((3 * time.Hour).Seconds()),
+	})
+	require.NoError(t, New(db, nil).SaveClient(ctx, client))
+	require.NoError(t, manager.Rotate(ctx))
+	_, _, retired = listKeys()
+	require.Len(t, retired, 1)
+
+	// The retired key is deleted after the retention
+	_, err = db.NewDelete().Model(client).Where("id = ?", client.Id).Exec(ctx)
+	require.NoError(t, err)
+	require.NoError(t, manager.Rotate(ctx))
+	_, _, retired = listKeys()
+	require.Empty(t, retired)
+	require.Len(t, keyRing.PublicKeys(), 2)
+}
@@ -3,6 +3,7 @@ package sqlstorage
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/uptrace/bun"
@@ -24,6 +25,8 @@ var _ oidc.Storage = (*Storage)(nil)
 
 type Storage struct {
 	db *bun.DB
+	// encryption protects the client secrets of the identity providers, they are stored in plaintext if nil
+	encryption *Encryption
 }
 
 func (s *Storage) CreateUser(ctx context.Context, user *auth.User) error {
@@ -39,9 +42,10 @@ func (s *Storage) FindUserByEmail(ctx context.Context, email string) (*auth.User
 	return user, nil
 }
 
-func New(db *bun.DB) *Storage {
+func New(db *bun.DB, encryption *Encryption) *Storage {
 	return &Storage{
-		db: db,
+		db:         db,
+		encryption: encryption,
 	}
 }
 
@@ -77,6 +81,34 @@ func (s *Storage) SaveClient(ctx context.Context, client *auth.Client) error {
 	return err
 }
 
+// UpdateClientSecret applies the update function to a secret of a client.
+// The client is locked and reloaded, so concurrent changes of the other secrets are not lost.
+func (s *Storage) UpdateClientSecret(ctx context.Context, clientID, secretID string, update func(secret *auth.ClientSecret)) error {
+	return s.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		client := &auth.Client{}
+		if err := tx.NewSelect().
+			Model(client).
+			Where("id = ?", clientID).
+			For("update").
+			Scan(ctx); err != nil {
+			return mapSqlError(err)
+		}
+
+		for i, secret := range client.Secrets {
+			if secret.ID == secretID {
+				update(&client.Secrets[i])
+			}
+		}
+
+		_, err := tx.NewUpdate().
+			Model(client).
+			Column("secrets").
+			Where("id = ?", clientID).
+			Exec(ctx)
+		return err
+	})
+}
+
 func (s *Storage) SaveAuthRequest(ctx context.Context, request *auth.AuthRequest) error {
 	_, err := s.db.NewInsert().Model(request).Exec(ctx)
 	return err
@@ -125,6 +157,47 @@ func (s *Storage) UpdateAuthRequestCode(ctx context.Context, id string, code str
 	return mapSqlError(err)
 }
 
+// UseAuthRequestURI invalidates the request_uri of a pushed authorization request.
+// It returns false if the request_uri has expired or has already been used.
+func (s *Storage) UseAuthRequestURI(ctx context.Context, id string, now time.Time) (bool, error) {
+	ret, err := s.db.NewUpdate().
+		Model(&auth.AuthRequest{}).
+		Set("request_uri_expiration = null").
+		Where("id = ?", id).
+		Where("request_uri_expiration > ?", now).
+		Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+	rowsAffected, err := ret.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rowsAffected > 0, nil
+}
+
+// UseJWTID records the id of a single use JWT until its expiration.
+// It returns false if the id is already recorded and has not expired, the JWT is replayed.
+func (s *Storage) UseJWTID(ctx context.Context, id string, expiration time.Time, now time.Time) (bool, error) {
+	ret, err := s.db.NewInsert().
+		Model(&auth.UsedJWTID{
+			ID:         id,
+			Expiration: expiration,
+		}).
+		On("CONFLICT (id) DO UPDATE").
+		Set("expiration = EXCLUDED.expiration").
+		Where("?TableAlias.expiration < ?", now).
+		Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+	rowsAffected, err := ret.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rowsAffected > 0, nil
+}
+
 func (s *Storage) DeleteAuthRequest(ctx context.Context, id string) error {
 	_, err := s.db.NewDelete().
 		Model(&auth.AuthRequest{}).
@@ -133,6 +206,57 @@ func (s *Storage) DeleteAuthRequest(ctx context.Context, id string) error {
 	return mapSqlError(err)
 }
 
+func (s *Storage) SaveDeviceAuthorization(ctx context.Context, authorization *auth.DeviceAuthorization) error {
+	_, err := s.db.NewInsert().Model(authorization).Exec(ctx)
+	return err
+}
+
+func (s *Storage) findDeviceAuthorization(ctx context.Context, column, value string) (*auth.DeviceAuthorization, error) {
+	ret := &auth.DeviceAuthorization{}
+	err := s.db.NewSelect().
+		Model(ret).
+		Where("? = ?", bun.Ident(column), value).
+		Limit(1).
+		Scan(ctx)
+	if err != nil {
+		return nil, mapSqlError(err)
+	}
+	return ret, nil
+}
+
+func (s *Storage) FindDeviceAuthorization(ctx context.Context, deviceCode string) (*auth.DeviceAuthorization, error) {
+	return s.findDeviceAuthorization(ctx, "device_code", deviceCode)
+}
+
+func (s *Storage) FindDeviceAuthorizationByUserCode(ctx context.Context, userCode string) (*auth.DeviceAuthorization, error) {
+	return s.findDeviceAuthorization(ctx, "user_code", userCode)
+}
+
+func (s *Storage) UpdateDeviceAuthorization(ctx context.Context, authorization *auth.DeviceAuthorization) error {
+	_, err := s.db.NewUpdate().
+		Model(authorization).
+		Where("id = ?", authorization.ID).
+		Exec(ctx)
+	return mapSqlError(err)
+}
+
+// DeleteDeviceAuthorization deletes a device authorization once the tokens have been issued.
+// It returns false if the authorization was already deleted, which means the device code has been used twice.
+func (s *Storage) DeleteDeviceAuthorization(ctx context.Context, id string) (bool, error) {
+	ret, err := s.db.NewDelete().
+		Model(&auth.DeviceAuthorization{}).
+		Where("id = ?", id).
+		Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+	rowsAffected, err := ret.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rowsAffected == 1, nil
+}
+
 func (s *Storage) SaveRefreshToken(ctx context.Context, token *auth.RefreshToken) error {
 	_, err := s.db.NewInsert().Model(token).Exec(ctx)
 	return err
@@ -159,6 +283,47 @@ func (s *Storage) DeleteRefreshToken(ctx context.Context, token string) error {
 	return mapSqlError(err)
 }
 
+// RotateRefreshToken marks a refresh token as rotated.
+// It returns false if the token was already rotated, which means it has been presented twice.
+func (s *Storage) RotateRefreshToken(ctx context.Context, token string, rotatedAt time.Time) (bool, error) {
+	ret, err := s.db.NewUpdate().
+		Model(&auth.RefreshToken{}).
+		Set("rotated_at = ?", rotatedAt).
+		Where("id = ?", token).
+		Where("rotated_at is null").
+		Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+	rowsAffected, err := ret.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rowsAffected > 0, nil
+}
+
+// RevokeRefreshTokenFamily deletes all the refresh tokens of a family and the access tokens issued from them
+func (s *Storage) RevokeRefreshTokenFamily(ctx context.Context, familyID string) error {
+	return s.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		_, err := tx.NewDelete().
+			Model(&auth.AccessToken{}).
+			Where("refresh_token_id in (?)", tx.NewSelect().
+				Model(&auth.RefreshToken{}).
+				Column("id").
+				Where("family_id = ?", familyID)).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.NewDelete().
+			Model(&auth.RefreshToken{}).
+			Where("family_id = ?", familyID).
+			Exec(ctx)
+		return err
+	})
+}
+
 func (s *Storage) SaveAccessToken(ctx context.Context, token *auth.AccessToken) error {
 	_, err := s.db.NewInsert().Model(token).Exec(ctx)
 	return err
@@ -227,15 +392,58 @@ func (s *Storage) FindClient(ctx context.Context, id string) (*auth.Client, erro
 	return ret, nil
 }
 
+func (s *Storage) SaveResourceServer(ctx context.Context, resourceServer *auth.ResourceServer) error {
+	_, err := s.db.NewInsert().Model(resourceServer).Exec(ctx)
+	return err
+}
+
+func (s *Storage) FindResourceServerByIdentifier(ctx context.Context, identifier string) (*auth.ResourceServer, error) {
+	ret := &auth.ResourceServer{}
+	err := s.db.NewSelect().
+		Model(ret).
+		Where("identifier = ?", identifier).
+		Limit(1).
+		Scan(ctx)
+	if err != nil {
+		return nil, mapSqlError(err)
+	}
+	return ret, nil
+}
+
+func (s *Storage) SaveScope(ctx context.Context, scope *auth.Scope) error {
+	_, err := s.db.NewInsert().Model(scope).Exec(ctx)
+	return err
+}
+
+func (s *Storage) ListScopes(ctx context.Context) ([]auth.Scope, error) {
+	ret := make([]auth.Scope, 0)
+	if err := s.db.NewSelect().
+		Model(&ret).
+		Order("name").
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
 func (s *Storage) SaveUser(ctx context.Context, user *auth.User) error {
 	_, err := s.db.NewInsert().Model(user).Exec(ctx)
 	return err
 }
 
-func (s *Storage) FindUserBySubject(ctx context.Context, subject string) (*auth.User, error) {
+func (s *Storage) UpdateUser(ctx context.Context, user *auth.User) error {
+	_, err := s.db.NewUpdate().
+		Model(user).
+		Where("id = ?", user.ID).
+		Exec(ctx)
+	return mapSqlError(err)
+}
+
+func (s *Storage) FindUserBySubject(ctx context.Context, provider, subject string) (*auth.User, error) {
 	ret := &auth.User{}
 	err := s.db.NewSelect().
 		Model(ret).
+		Where("provider = ?", provider).
 		Where("subject = ?", subject).
 		Limit(1).
 		Scan(ctx)
@@ -244,3 +452,119 @@ func (s *Storage) FindUserBySubject(ctx context.Context, subject string) (*auth.
 	}
 	return ret, nil
 }
+
+func (s *Storage) SaveIdentityProvider(ctx context.Context, identityProvider *auth.IdentityProvider) error {
+	secret, err := EncryptIdentityProviderSecret(s.encryption, identityProvider.ClientSecret)
+	if err != nil {
+		return err
+	}
+	stored := *identityProvider
+	stored.ClientSecret = secret
+	_, err = s.db.NewInsert().Model(&stored).Exec(ctx)
+	return err
+}
+
+func (s *Storage) ListIdentityProviders(ctx context.Context) ([]auth.IdentityProvider, error) {
+	ret := make([]auth.IdentityProvider, 0)
+	if err := s.db.NewSelect().
+		Model(&ret).
+		Order("name").
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+	for i := range ret {
+		secret, err := decryptIdentityProviderSecret(s.encryption, ret[i].ClientSecret)
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading identity provider %s", ret[i].Name)
+		}
+		ret[i].ClientSecret = secret
+	}
+	return ret, nil
+}
+
+func (s *Storage) SaveRole(ctx context.Context, role *auth.Role) error {
+	_, err := s.db.NewInsert().Model(role).Exec(ctx)
+	return err
+}
+
+func (s *Storage) ListRoles(ctx context.Context) ([]auth.Role, error) {
+	ret := make([]auth.Role, 0)
+	if err := s.db.NewSelect().
+		Model(&ret).
+		Order("name").
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (s *Storage) SaveGroup(ctx context.Context, group *auth.Group) error {
+	_, err := s.db.NewInsert().Model(group).Exec(ctx)
+	return err
+}
+
+func (s *Storage) AddGroupMember(ctx context.Context, groupID, userID string) error {
+	_, err := s.db.NewInsert().
+		Model(&auth.GroupMember{
+			GroupID: groupID,
+			UserID:  userID,
+		}).
+		On("CONFLICT DO NOTHING").
+		Exec(ctx)
+	return err
+}
+
+func (s *Storage) ListUserGroups(ctx context.Context, userID string) ([]auth.Group, error) {
+	ret := make([]auth.Group, 0)
+	if err := s.db.NewSelect().
+		Model(&ret).
+		Join("JOIN group_members ON group_members.group_id = ?TableAlias.id").
+		Where("group_members.user_id = ?", userID).
+		Order("name").
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// SyncUserGroups replaces the synchronized memberships of a user by memberships of the named groups,
+// the unknown groups are ignored and the memberships added through the API are kept
+func (s *Storage) SyncUserGroups(ctx context.Context, userID string, groupNames []string) error {
+	return s.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		groupIDs := make([]string, 0)
+		if len(groupNames) > 0 {
+			if err := tx.NewSelect().
+				Model((*auth.Group)(nil)).
+				Column("id").
+				Where("name IN (?)", bun.In(groupNames)).
+				Scan(ctx, &groupIDs); err != nil {
+				return err
+			}
+		}
+
+		query := tx.NewDelete().
+			Model((*auth.GroupMember)(nil)).
+			Where("user_id = ?", userID).
+			Where("synchronized")
+		if len(groupIDs) > 0 {
+			query = query.Where("group_id NOT IN (?)", bun.In(groupIDs))
+		}
+		if _, err := query.Exec(ctx); err != nil {
+			return err
+		}
+
+		for _, groupID := range groupIDs {
+			if _, err := tx.NewInsert().
+				Model(&auth.GroupMember{
+					GroupID:      groupID,
+					UserID:       userID,
+					Synchronized: true,
+				}).
+				On("CONFLICT DO NOTHING").
+				Exec(ctx); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
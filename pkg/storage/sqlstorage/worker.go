@@ -0,0 +1,50 @@
+package sqlstorage
+
+import (
+	"context"
+
+	"github.com/formancehq/go-libs/v3/logging"
+	"go.uber.org/fx"
+)
+
+// runInBackground registers a worker on the application lifecycle.
+// init is called synchronously on start, then run is executed in a goroutine until the application stops.
+func runInBackground(lc fx.Lifecycle, init func(ctx context.Context) error, run func(ctx context.Context)) {
+	var (
+		cancel  func()
+		stopped chan struct{}
+	)
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			if init != nil {
+				if err := init(ctx); err != nil {
+					return err
+				}
+			}
+
+			runContext, runCancel := context.WithCancel(logging.ContextWithLogger(
+				context.Background(),
+				logging.FromContext(ctx),
+			))
+			cancel = runCancel
+			stopped = make(chan struct{})
+			go func() {
+				defer close(stopped)
+				run(runContext)
+			}()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			if cancel == nil {
+				return nil
+			}
+			cancel()
+			select {
+			case <-stopped:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		},
+	})
+}
@@ -0,0 +1,126 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// TLSClientAuth configures the mutual TLS client authentication (RFC 8705).
+// With tls_client_auth, the certificate is validated against the trusted authorities and must match
+// the single configured subject DN or subject alternative name.
+// With self_signed_tls_client_auth, the certificate must be registered in the jwks (or jwksUri) of the client.
+type TLSClientAuth struct {
+	SelfSigned bool `json:"selfSigned" yaml:"selfSigned"`
+	// SubjectDN is the expected subject distinguished name, in its RFC 4514 string representation (ex: CN=client,O=Acme)
+	SubjectDN string `json:"subjectDn,omitempty" yaml:"subjectDn"`
+	SANDNS    string `json:"sanDns,omitempty" yaml:"sanDns"`
+	SANURI    string `json:"sanUri,omitempty" yaml:"sanUri"`
+	SANIP     string `json:"sanIp,omitempty" yaml:"sanIp"`
+	SANEmail  string `json:"sanEmail,omitempty" yaml:"sanEmail"`
+}
+
+// Scan implements the sql.Scanner interface.
+func (a *TLSClientAuth) Scan(src interface{}) error {
+	*a = TLSClientAuth{}
+	var err error
+	switch src := src.(type) {
+	case []byte:
+		err = json.Unmarshal(src, a)
+	case string:
+		err = json.Unmarshal([]byte(src), a)
+	case nil:
+	default:
+		return fmt.Errorf("type '%T' not handled", src)
+	}
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (a *TLSClientAuth) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(a)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(data), nil
+}
+
+func (a *TLSClientAuth) subjects() []string {
+	return []string{a.SubjectDN, a.SANDNS, a.SANURI, a.SANIP, a.SANEmail}
+}
+
+func (a *TLSClientAuth) Validate() error {
+	defined := 0
+	for _, subject := range a.subjects() {
+		if subject != "" {
+			defined++
+		}
+	}
+	if a.SelfSigned {
+		if defined > 0 {
+			return errors.New("subject is not used with self signed certificates")
+		}
+		return nil
+	}
+	if defined != 1 {
+		return errors.New("exactly one of subjectDn, sanDns, sanUri, sanIp or sanEmail is required")
+	}
+	if a.SANIP != "" && net.ParseIP(a.SANIP) == nil {
+		return fmt.Errorf("invalid sanIp '%s'", a.SANIP)
+	}
+	return nil
+}
+
+// Matches returns true if the subject of the certificate is the configured one
+func (a *TLSClientAuth) Matches(certificate *x509.Certificate) bool {
+	switch {
+	case a.SubjectDN != "":
+		return certificate.Subject.String() == a.SubjectDN
+	case a.SANDNS != "":
+		for _, name := range certificate.DNSNames {
+			if strings.EqualFold(name, a.SANDNS) {
+				return true
+			}
+		}
+	case a.SANURI != "":
+		for _, uri := range certificate.URIs {
+			if uri.String() == a.SANURI {
+				return true
+			}
+		}
+	case a.SANIP != "":
+		ip := net.ParseIP(a.SANIP)
+		for _, address := range certificate.IPAddresses {
+			if address.Equal(ip) {
+				return true
+			}
+		}
+	case a.SANEmail != "":
+		for _, email := range certificate.EmailAddresses {
+			if email == a.SANEmail {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// CertificateThumbprint returns the base64url-encoded SHA-256 thumbprint of the certificate,
+// as used in the x5t#S256 confirmation method of the certificate-bound tokens
+func CertificateThumbprint(certificate *x509.Certificate) string {
+	sum := sha256.Sum256(certificate.Raw)
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
@@ -10,10 +10,17 @@ type AccessToken struct {
 	bun.BaseModel `bun:"table:access_tokens"`
 
 	ID             string `bun:",pk"`
+	CreatedAt      time.Time
 	ApplicationID  string
 	UserID         string
 	Audience       Array[string] `bun:"type:text"`
 	Expiration     time.Time
 	Scopes         Array[string] `bun:"type:text"`
 	RefreshTokenID string        `json:"refreshTokenID"`
+	// CertificateThumbprint binds the token to the certificate of the client (RFC 8705), empty if the token is not bound
+	CertificateThumbprint string
+	// DPoPKeyThumbprint binds the token to the key of the DPoP proofs (RFC 9449), empty if the token is not bound
+	DPoPKeyThumbprint string `bun:"dpop_key_thumbprint"`
+	// Actor is the party acting on behalf of the user of a token obtained by token exchange (RFC 8693), nil otherwise
+	Actor *Actor `bun:"actor,type:text"`
 }
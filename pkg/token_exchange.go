@@ -0,0 +1,221 @@
+package auth
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/zitadel/oidc/v2/pkg/oidc"
+)
+
+// TokenExchangePolicy enables the token exchange grant (RFC 8693) for a client and restricts the tokens it can obtain
+type TokenExchangePolicy struct {
+	// SubjectTokenIssuers are the issuers of the subject and actor tokens the client can exchange,
+	// empty to accept only the tokens issued by this server
+	SubjectTokenIssuers []string `json:"subjectTokenIssuers,omitempty" yaml:"subjectTokenIssuers"`
+	// Audiences are the audiences the client can request in addition to itself
+	Audiences []string `json:"audiences,omitempty" yaml:"audiences"`
+	// Scopes are the scopes the client can request, wildcards are allowed, empty to allow all the scopes of the subject token
+	Scopes []string `json:"scopes,omitempty" yaml:"scopes"`
+	// Impersonation allows the client to obtain tokens without actor,
+	// otherwise the client (or the subject of the actor token) is recorded in the act claim
+	Impersonation bool `json:"impersonation" yaml:"impersonation"`
+}
+
+// Scan implements the sql.Scanner interface.
+func (p *TokenExchangePolicy) Scan(src interface{}) error {
+	*p = TokenExchangePolicy{}
+	var err error
+	switch src := src.(type) {
+	case []byte:
+		err = json.Unmarshal(src, p)
+	case string:
+		err = json.Unmarshal([]byte(src), p)
+	case nil:
+	default:
+		return fmt.Errorf("type '%T' not handled", src)
+	}
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (p *TokenExchangePolicy) Value() (driver.Value, error) {
+	if p == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(data), nil
+}
+
+func (p *TokenExchangePolicy) Validate() error {
+	for _, issuer := range p.SubjectTokenIssuers {
+		u, err := url.Parse(issuer)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("invalid subject token issuer '%s'", issuer)
+		}
+	}
+	return nil
+}
+
+// Actor is the party acting on behalf of the subject of a token (act claim of RFC 8693),
+// the nested actor is the previous one of the delegation chain
+type Actor struct {
+	Subject string `json:"sub"`
+	Actor   *Actor `json:"act,omitempty"`
+}
+
+// Scan implements the sql.Scanner interface.
+func (a *Actor) Scan(src interface{}) error {
+	*a = Actor{}
+	var err error
+	switch src := src.(type) {
+	case []byte:
+		err = json.Unmarshal(src, a)
+	case string:
+		err = json.Unmarshal([]byte(src), a)
+	case nil:
+	default:
+		return fmt.Errorf("type '%T' not handled", src)
+	}
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (a *Actor) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(a)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(data), nil
+}
+
+// ActorFromClaim decodes the act claim of a token, it returns nil if the claim is missing or malformed
+func ActorFromClaim(claim any) *Actor {
+	values, ok := claim.(map[string]any)
+	if !ok {
+		return nil
+	}
+	subject, _ := values["sub"].(string)
+	if subject == "" {
+		return nil
+	}
+	return &Actor{
+		Subject: subject,
+		Actor:   ActorFromClaim(values["act"]),
+	}
+}
+
+// TokenExchangeRequest is a token exchange request (RFC 8693) whose subject and actor tokens have been verified
+type TokenExchangeRequest struct {
+	ApplicationID      string
+	Subject            string
+	Audience           []string
+	Resources          []string
+	Scopes             []string
+	RequestedTokenType oidc.TokenType
+	AuthTime           time.Time
+	// Actor is the act claim of the issued token, nil for impersonation
+	Actor *Actor
+
+	SubjectTokenIDOrToken string
+	SubjectTokenType      oidc.TokenType
+	SubjectTokenSubject   string
+	SubjectTokenClaims    map[string]any
+
+	ActorTokenIDOrToken string
+	ActorTokenType      oidc.TokenType
+	ActorTokenSubject   string
+	ActorTokenClaims    map[string]any
+}
+
+func (r *TokenExchangeRequest) GetAMR() []string {
+	return nil
+}
+
+func (r *TokenExchangeRequest) GetAudience() []string {
+	return r.Audience
+}
+
+func (r *TokenExchangeRequest) GetResourses() []string {
+	return r.Resources
+}
+
+func (r *TokenExchangeRequest) GetAuthTime() time.Time {
+	return r.AuthTime
+}
+
+func (r *TokenExchangeRequest) GetClientID() string {
+	return r.ApplicationID
+}
+
+func (r *TokenExchangeRequest) GetScopes() []string {
+	return r.Scopes
+}
+
+func (r *TokenExchangeRequest) GetSubject() string {
+	return r.Subject
+}
+
+func (r *TokenExchangeRequest) GetRequestedTokenType() oidc.TokenType {
+	return r.RequestedTokenType
+}
+
+func (r *TokenExchangeRequest) GetExchangeSubject() string {
+	return r.SubjectTokenSubject
+}
+
+func (r *TokenExchangeRequest) GetExchangeSubjectTokenType() oidc.TokenType {
+	return r.SubjectTokenType
+}
+
+func (r *TokenExchangeRequest) GetExchangeSubjectTokenIDOrToken() string {
+	return r.SubjectTokenIDOrToken
+}
+
+func (r *TokenExchangeRequest) GetExchangeSubjectTokenClaims() map[string]any {
+	return r.SubjectTokenClaims
+}
+
+func (r *TokenExchangeRequest) GetExchangeActor() string {
+	return r.ActorTokenSubject
+}
+
+func (r *TokenExchangeRequest) GetExchangeActorTokenType() oidc.TokenType {
+	return r.ActorTokenType
+}
+
+func (r *TokenExchangeRequest) GetExchangeActorTokenIDOrToken() string {
+	return r.ActorTokenIDOrToken
+}
+
+func (r *TokenExchangeRequest) GetExchangeActorTokenClaims() map[string]any {
+	return r.ActorTokenClaims
+}
+
+func (r *TokenExchangeRequest) SetCurrentScopes(scopes []string) {
+	r.Scopes = scopes
+}
+
+func (r *TokenExchangeRequest) SetRequestedTokenType(tokenType oidc.TokenType) {
+	r.RequestedTokenType = tokenType
+}
+
+func (r *TokenExchangeRequest) SetSubject(subject string) {
+	r.Subject = subject
+}
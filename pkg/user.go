@@ -1,11 +1,69 @@
 package auth
 
-import "github.com/uptrace/bun"
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+	"golang.org/x/text/language"
+)
+
+type UserOptions struct {
+	// Provider is the name of the upstream identity provider of the user, the default one if empty
+	Provider string `json:"provider"`
+	// Subject is the identifier of the user at the upstream identity provider, unique per provider
+	Subject       string   `json:"subject"`
+	Email         string   `json:"email"`
+	EmailVerified bool     `json:"emailVerified"`
+	DisplayName   string   `json:"displayName"`
+	GivenName     string   `json:"givenName"`
+	FamilyName    string   `json:"familyName"`
+	Locale        string   `json:"locale"`
+	Metadata      Metadata `json:"metadata" bun:"type:text"`
+}
+
+func (o *UserOptions) Validate() error {
+	if o.Subject == "" {
+		return errors.New("subject is required")
+	}
+	if o.Provider == "" {
+		o.Provider = DefaultIdentityProvider
+	}
+	if o.Email != "" {
+		if _, err := mail.ParseAddress(o.Email); err != nil {
+			return fmt.Errorf("invalid email '%s'", o.Email)
+		}
+	}
+	if o.Locale != "" {
+		if _, err := language.Parse(o.Locale); err != nil {
+			return fmt.Errorf("invalid locale '%s'", o.Locale)
+		}
+	}
+	return nil
+}
 
 type User struct {
 	bun.BaseModel `bun:"table:users"`
 
-	ID      string `json:"id" bun:",pk"`
-	Subject string `json:"subject" bun:",unique"`
-	Email   string `json:"email"`
+	ID string `json:"id" bun:",pk"`
+	UserOptions
+	// Disabled users can not log in, their tokens are revoked when they are disabled
+	Disabled    bool       `json:"disabled"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	LastLoginAt *time.Time `json:"lastLoginAt,omitempty"`
+}
+
+func (u *User) Update(opts UserOptions) {
+	u.UserOptions = opts
+}
+
+func NewUser(opts UserOptions) *User {
+	return &User{
+		ID:          uuid.NewString(),
+		UserOptions: opts,
+		CreatedAt:   time.Now(),
+	}
 }
@@ -64,6 +64,14 @@ var _ = Context("Auth - OAuth2 Clients Management", func() {
 				TokenURL:     fmt.Sprintf("%s/oauth/token", srv.ServerURL()),
 			}
 			httpClient = config.Client(ctx)
+
+			// The scopes of the clients must be registered
+			for _, scope := range []string{"scope1", "scope2", "read:scope"} {
+				_, err := srv.Client(httpClient).Auth.V1.CreateScope(ctx, &components.CreateScopeRequest{
+					Name: scope,
+				})
+				Expect(err).To(Succeed())
+			}
 		})
 
 		Describe("Creating clients", func() {
@@ -137,6 +145,23 @@ var _ = Context("Auth - OAuth2 Clients Management", func() {
 					Expect(client.Secrets).To(BeEmpty())
 				})
 			})
+
+			When("creating a client with an unregistered scope", func() {
+				var (
+					err error
+				)
+
+				BeforeEach(func() {
+					_, err = srv.Client(httpClient).Auth.V1.CreateClient(ctx, &components.CreateClientRequest{
+						Name:   "test-client",
+						Scopes: []string{"unregistered:scope"},
+					})
+				})
+
+				It("should return an error", func() {
+					Expect(err).NotTo(BeNil())
+				})
+			})
 		})
 
 		Describe("Reading clients", func() {
@@ -589,6 +614,11 @@ var _ = Context("Auth - OAuth2 Clients Management", func() {
 			}
 			globalHTTPClient := globalConfig.Client(ctx)
 
+			_, err := srv.Client(globalHTTPClient).Auth.V1.CreateScope(ctx, &components.CreateScopeRequest{
+				Name: "other:scope",
+			})
+			Expect(err).To(Succeed())
+
 			// Create a new client without auth:write scope
 			createResponse, err := srv.Client(globalHTTPClient).Auth.V1.CreateClient(ctx, &components.CreateClientRequest{
 				Name:   "unauthorized-client",